@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"runtime"
 	"strings"
 )
 
@@ -59,72 +58,166 @@ func (c *Cat) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of cat command
+//
+// The arguments are the files, which are written one after another, and - is the input.
+// Without arguments, the input is written.
+//
+// The options are like in cat: -n numbers all lines, -b numbers the non-blank lines, -s squeezes the repeated blank lines,
+// -E writes $ at the end of every line, -T writes tabs as ^I and -v writes the non-printing characters with ^ and M- notation.
+// The option -A is the same as -vET, -e is the same as -vE and -t is the same as -vT.
 func (c *Cat) Execute(cp CommandProperties) error {
 	c.path = cp.Path
 	inputFile, outputFile := cp.InputFile, cp.OutputFile
 
-	outputFileData := func(file *os.File) error {
-		for {
-			text, err := checkRead(c, file)
-			if len(text) == 0 {
-				break
-			}
-			if err != nil && err != io.EOF {
-				return err
-			}
-			if err := checkWrite(c, outputFile, text); err != nil {
-				return err
-			}
-			if err == io.EOF {
-				break
+	options, arguments, err := parseOptions(cp, "nbsAETvet", []string{"number", "number-nonblank", "squeeze-blank",
+		"show-all", "show-ends", "show-tabs", "show-nonprinting"})
+	if err != nil {
+		return err
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+	f := &catFormat{
+		numberNonBlank:  has("b", "number-nonblank"),
+		squeezeBlank:    has("s", "squeeze-blank"),
+		showEnds:        has("E", "show-ends") || has("A", "show-all") || options.has("e"),
+		showTabs:        has("T", "show-tabs") || has("A", "show-all") || options.has("t"),
+		showNonPrinting: has("v", "show-nonprinting") || has("A", "show-all") || options.has("e") || options.has("t"),
+		lineStart:       true,
+	}
+	f.number = has("n", "number") && f.numberNonBlank == false // -b overrides -n
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+
+	out := newStreamWriter(c, outputFile)
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range arguments {
+		file := inputFile
+		if argument != "-" {
+			file, err = os.Open(FullFileName(c.path, argument))
+			if os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("%s - %w", argument, ErrCatFileNotExist))
+				continue
+			} else if err != nil {
+				errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+				continue
 			}
 		}
-		return nil
+
+		if f.isRaw() == true {
+			err = c.copyFile(file, out)
+		} else {
+			err = c.formatFile(file, out, f)
+		}
+		if file != inputFile {
+			file.Close()
+		}
+		if err == ErrStoppedExec {
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+		}
 	}
+	return out.finish(joinErrors(errs))
+}
+
+// copyFile is a method for writing the bytes of file to out without changing them
+func (c *Cat) copyFile(file *os.File, out *streamWriter) error {
+	return copyStream(newStreamReader(c, file), out)
+}
 
-	if len(cp.Arguments) == 0 { // when there are no arguments, cat command reads from inputFile
-		err := outputFileData(inputFile)
+// formatFile is a method for writing the lines of file to out, formatted by f
+func (c *Cat) formatFile(file *os.File, out *streamWriter, f *catFormat) error {
+	in := newStreamReader(c, file)
+	for {
+		line, err := in.ReadString('\n')
+		if len(line) > 0 {
+			if err := out.WriteString(f.format(line)); err != nil {
+				return err
+			}
+		}
+		if err := out.flushIfWaiting(in); err != nil {
+			return err
+		}
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
+	}
+}
+
+// catFormat stores the options for formatting the lines of cat and the state, which continues from one file to the next
+type catFormat struct {
+	number, numberNonBlank, squeezeBlank, showEnds, showTabs, showNonPrinting bool
+
+	lineNumber int  // lineNumber is the number of the last numbered line
+	lineStart  bool // lineStart is true when the next text starts new line, it is false after file, which does not end with new line
+	blankLines int  // blankLines is the number of blank lines before the next line
+}
+
+// isRaw is a method for checking if the files are written without changes
+func (f *catFormat) isRaw() bool {
+	return !f.number && !f.numberNonBlank && !f.squeezeBlank && !f.showEnds && !f.showTabs && !f.showNonPrinting
+}
 
-		// clean newline after EOF if the reading was from stdin
-		var bufferNewLine []byte
-		if runtime.GOOS == "windows" {
-			bufferNewLine = make([]byte, 2)
+// format is a method returning the formatted text for line, which ends with new line or is the last part of file
+func (f *catFormat) format(line string) string {
+	var result strings.Builder
+	if f.lineStart == true {
+		blank := line == "\n"
+		if blank == true {
+			f.blankLines++
+			if f.squeezeBlank == true && f.blankLines > 1 {
+				return ""
+			}
 		} else {
-			bufferNewLine = make([]byte, 1)
+			f.blankLines = 0
 		}
-		if _, err := inputFile.Read(bufferNewLine); err != nil && err != io.EOF {
-			return err
+		if f.number == true || (f.numberNonBlank == true && blank == false) {
+			f.lineNumber++
+			result.WriteString(fmt.Sprintf("%6d\t", f.lineNumber))
 		}
-		return nil
 	}
 
-	var errStrings []string // in slice errStrings we collect all the errors
-	for _, argument := range cp.Arguments {
-		file, err := os.Open(c.path + string(os.PathSeparator) + argument)
-		if os.IsNotExist(err) {
-			errStrings = append(errStrings, fmt.Errorf("%s - %w", argument, ErrCatFileNotExist).Error())
-		} else if err != nil {
-			errStrings = append(errStrings, fmt.Errorf("%s - %w", argument, err).Error())
-		} else {
-			err := outputFileData(file)
-			file.Close()
-			if err == ErrStoppedExec {
-				if len(errStrings) == 0 {
-					return nil
-				}
-				return errors.New(strings.Join(errStrings, "\n"))
-			}
-			if err != nil {
-				errStrings = append(errStrings, fmt.Errorf("%s - %w", argument, err).Error())
-			}
+	text := strings.TrimSuffix(line, "\n")
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+		switch {
+		case char == '\t' && f.showTabs == true:
+			result.WriteString("^I")
+		case char == '\t' || f.showNonPrinting == false:
+			result.WriteByte(char)
+		default:
+			result.WriteString(nonPrintingNotation(char))
 		}
 	}
 
-	if len(errStrings) == 0 {
-		return nil
+	f.lineStart = len(text) < len(line)
+	if f.lineStart == true {
+		if f.showEnds == true {
+			result.WriteByte('$')
+		}
+		result.WriteByte('\n')
+	}
+	return result.String()
+}
+
+// nonPrintingNotation function returns how the byte char is written by cat -v.
+// The control characters are written with ^ like ^A and ^?, and the bytes after 127 with M- followed by the notation of char-128.
+func nonPrintingNotation(char byte) string {
+	prefix := ""
+	if char >= 128 {
+		prefix, char = "M-", char-128
+	}
+	switch {
+	case char < 32:
+		return prefix + "^" + string(rune(char+64))
+	case char == 127:
+		return prefix + "^?"
 	}
-	return errors.New(strings.Join(errStrings, "\n"))
+	return prefix + string(rune(char))
 }
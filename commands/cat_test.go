@@ -1,8 +1,15 @@
 package commands
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -22,7 +29,7 @@ func testingCat(t *testing.T, inputText string, arguments []string, expectedResu
 	}
 	inputW.Close()
 	cat := Cat{}
-	if err := cat.Execute(CommandProperties{"test/path", arguments, []string{}, inputR, outputW}); err != nil {
+	if err := cat.Execute(CommandProperties{"test/path", arguments, []string{}, inputR, outputW, nil}); err != nil {
 		if expectedErr == "" {
 			t.Errorf("Expected no error, but got: %v", err)
 		} else if err.Error() != expectedErr {
@@ -62,6 +69,194 @@ func TestCat(t *testing.T) {
 	}
 }
 
+func TestCatBinary(t *testing.T) {
+	path, err := ioutil.TempDir("", "cat-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	binary := make([]byte, 3*streamBufferSize+17) // the file is bigger than the buffer and has NUL bytes
+	for i := range binary {
+		binary[i] = byte(i * 7)
+	}
+	makeTestTree(t, path, map[string]string{"binary": string(binary), "nul": "a\x00\x00", "text": "text\n"})
+
+	var tests = []struct {
+		words  []string
+		output string
+	}{
+		{[]string{"nul"}, "a\x00\x00"},
+		{[]string{"binary"}, string(binary)},
+		{[]string{"nul", "text", "binary", "nul"}, "a\x00\x00text\n" + string(binary) + "a\x00\x00"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cat test with arguments %v", test.words), func(t *testing.T) {
+			outputFile, err := ioutil.TempFile(path, "output") // the output is bigger than the buffer of pipe
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary file! - %v", err)
+			}
+			defer outputFile.Close()
+			cat := &Cat{}
+			cat.InitStopSignalCatching()
+			if err := cat.Execute(CommandProperties{path, test.words, nil, os.Stdin, outputFile, nil}); err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+			if output, _ := ioutil.ReadFile(outputFile.Name()); string(output) != test.output {
+				t.Errorf("Expected output with %d bytes, but got %d bytes", len(test.output), len(output))
+			}
+		})
+	}
+}
+
+func TestCatOptions(t *testing.T) {
+	path, err := ioutil.TempDir("", "cat-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{
+		"lines": "first\n\n\n\nsecond\tcolumn\n", "partial": "no new line", "control": "a\x01\x7f\r\n\xc3\xa9\t\n",
+	})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"lines"}, "first\n\n\n\nsecond\tcolumn\n", nil},
+		{"", []string{"-n", "lines"}, "     1\tfirst\n     2\t\n     3\t\n     4\t\n     5\tsecond\tcolumn\n", nil},
+		{"", []string{"-b", "lines"}, "     1\tfirst\n\n\n\n     2\tsecond\tcolumn\n", nil},
+		{"", []string{"-n", "-b", "lines"}, "     1\tfirst\n\n\n\n     2\tsecond\tcolumn\n", nil},
+		{"", []string{"-s", "lines"}, "first\n\nsecond\tcolumn\n", nil},
+		{"", []string{"-sn", "lines"}, "     1\tfirst\n     2\t\n     3\tsecond\tcolumn\n", nil},
+		{"", []string{"-E", "lines"}, "first$\n$\n$\n$\nsecond\tcolumn$\n", nil},
+		{"", []string{"-T", "-s", "lines"}, "first\n\nsecond^Icolumn\n", nil},
+		{"", []string{"-v", "control"}, "a^A^?^M\nM-CM-)\t\n", nil},
+		{"", []string{"-A", "control"}, "a^A^?^M$\nM-CM-)^I$\n", nil},
+		{"", []string{"-e", "control"}, "a^A^?^M$\nM-CM-)\t$\n", nil},
+		{"", []string{"-t", "control"}, "a^A^?^M\nM-CM-)^I\n", nil},
+		{"", []string{"--number", "--show-ends", "partial", "lines"},
+			"     1\tno new linefirst$\n     2\t$\n     3\t$\n     4\t$\n     5\tsecond\tcolumn$\n", nil},
+		{"input\n", []string{"-n", "partial", "-", "-"}, "     1\tno new lineinput\n", nil},
+		{"input\n", nil, "input\n", nil},
+		{"input\n", []string{"-"}, "input\n", nil},
+		{"input", []string{"partial", "-", "partial"}, "no new lineinputno new line", nil},
+		{"", []string{filepath.Join(path, "partial")}, "no new line", nil},
+		{"", []string{"missing", "partial"}, "no new line", ErrCatFileNotExist},
+		{"", []string{"-x", "lines"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cat test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			inputR, inputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			defer inputR.Close()
+			inputW.WriteString(test.input)
+			inputW.Close()
+			outputR, outputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			defer outputR.Close()
+
+			cat := &Cat{}
+			cat.InitStopSignalCatching()
+			errCat := cat.Execute(CommandProperties{path, nil, nil, inputR, outputW, test.words})
+			outputW.Close()
+			if !errors.Is(errCat, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errCat)
+			}
+			if output, _ := ioutil.ReadAll(outputR); string(output) != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+// catBenchmarkSize is the size of the file, which is read by the cat benchmarks
+const catBenchmarkSize = 1 << 30
+
+// catBenchmarkFile is the file for the cat benchmarks, made once when they are run
+var catBenchmarkFile struct {
+	once sync.Once
+	name string
+}
+
+func makeCatBenchmarkFile(b *testing.B) string {
+	catBenchmarkFile.once.Do(func() {
+		file, err := ioutil.TempFile("", "cat-benchmark")
+		if err != nil {
+			b.Fatalf("Fatal error - cannot make temporary file! - %v", err)
+		}
+		defer file.Close()
+		catBenchmarkFile.name = file.Name()
+		line := []byte(strings.Repeat("abcdefghijklmnopqrstuvwxyz", 3) + "\n")
+		block := bytes.Repeat(line, streamBufferSize/len(line)+1)[:streamBufferSize]
+		for size := 0; size < catBenchmarkSize; size += len(block) {
+			if _, err := file.Write(block); err != nil {
+				b.Fatalf("Fatal error - cannot write to temporary file! - %v", err)
+			}
+		}
+	})
+	if catBenchmarkFile.name == "" {
+		b.Skip("the benchmark file cannot be made")
+	}
+	return catBenchmarkFile.name
+}
+
+// benchmarkCat is helper for running cat on the benchmark file with output to the null device
+func benchmarkCat(b *testing.B, run func(cat *Cat, name string, outputFile *os.File) error) {
+	name := makeCatBenchmarkFile(b)
+	outputFile, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatalf("Fatal error - cannot open %s! - %v", os.DevNull, err)
+	}
+	defer outputFile.Close()
+	b.SetBytes(catBenchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cat := &Cat{}
+		cat.InitStopSignalCatching()
+		if err := run(cat, name, outputFile); err != nil {
+			b.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+}
+
+// BenchmarkCatUnbuffered measures the way cat worked before streamReader and streamWriter.
+// It read 16 bytes at once, trimming the NUL bytes, and wrote them with separate write.
+func BenchmarkCatUnbuffered(b *testing.B) {
+	benchmarkCat(b, func(cat *Cat, name string, outputFile *os.File) error {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		for {
+			if cat.IsStopSignalReceived() == true {
+				return ErrStoppedExec
+			}
+			buf := make([]byte, 1<<4)
+			if _, err := file.Read(buf); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if err := checkWrite(cat, outputFile, strings.TrimRight(string(buf), "\u0000")); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func BenchmarkCat(b *testing.B) {
+	benchmarkCat(b, func(cat *Cat, name string, outputFile *os.File) error {
+		return cat.Execute(CommandProperties{filepath.Dir(name), []string{filepath.Base(name)}, nil, os.Stdin, outputFile, nil})
+	})
+}
+
 func ExampleCat_Execute() {
 	path, _ := os.Getwd()
 	file, _ := os.OpenFile(path+string(os.PathSeparator)+"example-file", os.O_CREATE|os.O_WRONLY, 0666)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 var (
@@ -14,6 +15,10 @@ var (
 	ErrCdPathLeadsToFile = errors.New("path leads to file, not directory")
 	// ErrCdPathNotExist indicates that the path in cd command does not exist
 	ErrCdPathNotExist = errors.New("path does not exist")
+	// ErrCdHomeNotSet indicates that the home directory for cd command without arguments is not known
+	ErrCdHomeNotSet = errors.New("home directory is not set")
+	// ErrCdOldPwdNotSet indicates that OLDPWD for cd - is not set
+	ErrCdOldPwdNotSet = errors.New("OLDPWD not set")
 )
 
 // Cd is a structure for cd command, implementing ExecuteCommand interface
@@ -61,37 +66,124 @@ func (c *Cd) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of cd command
+//
+// Without argument, the path is changed to the home directory and with argument "-", it is changed to OLDPWD and written.
+// Relative names, which do not start with . or .., are searched in the directories in CDPATH first and then in the current path.
+// When the directory is found with CDPATH, the new path is written.
+//
+// With option -L, which is the default, the path is logical i.e. symbolic links are kept in it and .. removes the previous name in the path.
+// With option -P, the path is physical i.e. symbolic links are resolved before .. is applied and they are not kept in the new path.
 func (c *Cd) Execute(cp CommandProperties) error {
 	c.path = cp.Path
+	_, outputFile := cp.InputFile, cp.OutputFile
 
-	if len(cp.Arguments) == 0 {
-		c.path = getRootPath(c.path)
-		return nil
+	short := "LP"
+	_, arguments, err := parseOptions(cp, short, nil)
+	if err != nil {
+		return err
 	}
-	if len(cp.Arguments) > 1 {
+	physical := lastOption(cp, short, nil, "LP", nil) == 'P'
+	if len(arguments) > 1 {
 		return ErrCdTooManyArgs
 	}
-
-	path := cp.Arguments[0]
-	if len(path) == 0 {
-		c.path = getRootPath(c.path)
-		return nil
+	name := ""
+	if len(arguments) == 1 {
+		name = arguments[0]
 	}
 
-	tryPath := FullFileName(c.path, path)
-
-	stat, err := os.Stat(tryPath) // we use stat to check if the path is valid and leading to directory
-	if err == nil && stat.IsDir() {
-		p, err := filepath.Abs(tryPath)
+	switch name {
+	case "":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("%v - %w", err, ErrCdHomeNotSet)
+		}
+		path, err := changeDirectory(c.path, home, false, physical)
 		if err != nil {
 			return err
 		}
-		c.path = p
+		c.path = path
 		return nil
-	} else if err == nil {
-		return fmt.Errorf("%s - %w", tryPath, ErrCdPathLeadsToFile)
-	} else if os.IsNotExist(err) {
-		return fmt.Errorf("%s - %w", tryPath, ErrCdPathNotExist)
+	case "-":
+		oldPath := os.Getenv("OLDPWD")
+		if oldPath == "" {
+			return ErrCdOldPwdNotSet
+		}
+		path, err := changeDirectory(c.path, oldPath, false, physical)
+		if err != nil {
+			return err
+		}
+		c.path = path
+		return checkWrite(c, outputFile, path+"\n")
+	}
+
+	path, err := changeDirectory(c.path, name, true, physical)
+	if err != nil {
+		return err
+	}
+	if isCdpathResult(c.path, name, path, physical) == true {
+		if err := checkWrite(c, outputFile, path+"\n"); err != nil {
+			return err
+		}
 	}
-	return err
+	c.path = path
+	return nil
+}
+
+// changeDirectory function finds the absolute path of directory with name, relative to path.
+// If useCdpath is true, relative names, which do not start with . or .., are searched in the directories in CDPATH first.
+// If physical is true, the symbolic links are resolved, otherwise the path is logical, see resolveDirectory.
+func changeDirectory(path string, name string, useCdpath bool, physical bool) (string, error) {
+	if useCdpath == true && !filepath.IsAbs(name) && name != "." && name != ".." &&
+		!strings.HasPrefix(name, "."+string(os.PathSeparator)) && !strings.HasPrefix(name, ".."+string(os.PathSeparator)) {
+		for _, dir := range filepath.SplitList(os.Getenv("CDPATH")) {
+			if dir == "" { // empty directory in CDPATH is the current path
+				dir = path
+			}
+			if newPath, err := resolveDirectory(FullFileName(FullFileName(path, dir), name), physical); err == nil {
+				return newPath, nil
+			}
+		}
+	}
+	return resolveDirectory(FullFileName(path, name), physical)
+}
+
+// resolveDirectory function finds the absolute path of directory with name, checking that it is valid and leading to directory.
+//
+// The logical path is made by cleaning name, so .. after symbolic link leads back to the directory with the link, like in bash.
+// When the logical path does not exist, but the physical one does, the physical path is used.
+// The physical path is made by resolving all symbolic links in name.
+func resolveDirectory(name string, physical bool) (string, error) {
+	tryPath, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+	if physical == false {
+		stat, err := os.Stat(tryPath) // we use stat to check if the path is valid and leading to directory
+		if err == nil && stat.IsDir() {
+			return tryPath, nil
+		} else if err == nil {
+			return "", fmt.Errorf("%s - %w", name, ErrCdPathLeadsToFile)
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+
+	resolved, err := filepath.EvalSymlinks(name)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("%s - %w", name, ErrCdPathNotExist)
+	} else if err != nil {
+		return "", err
+	}
+	if stat, err := os.Stat(resolved); err != nil {
+		return "", err
+	} else if !stat.IsDir() {
+		return "", fmt.Errorf("%s - %w", name, ErrCdPathLeadsToFile)
+	}
+	return filepath.Abs(resolved)
+}
+
+// isCdpathResult function checks if the directory with name, relative to path, is found with CDPATH as newPath
+func isCdpathResult(path string, name string, newPath string, physical bool) bool {
+	relative, err := resolveDirectory(FullFileName(path, name), physical)
+	return err != nil || relative != newPath
 }
@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -13,7 +14,7 @@ func testingCd(t *testing.T, cp CommandProperties, expectedResult string, expect
 	err := cd.Execute(cp)
 	if err != nil {
 		if !errors.Is(err, expectedErr) {
-			t.Errorf("Expected %v, but got: %w\n", expectedErr, err)
+			t.Errorf("Expected %v, but got: %v\n", expectedErr, err)
 		}
 		return
 	}
@@ -28,11 +29,11 @@ func testingCd(t *testing.T, cp CommandProperties, expectedResult string, expect
 func TestCd(t *testing.T) {
 	testPath, err := os.Getwd()
 	if err != nil {
-		t.Fatal("Fatal error - cannot get current path! - %w", err)
+		t.Fatalf("Fatal error - cannot get current path! - %v", err)
 	}
-	parentPath, err2 := filepath.Abs(testPath + `\..`)
-	if err2 != nil {
-		t.Fatal("Fatal error - cannot get parent path! - %w", err)
+	parentPath, err := filepath.Abs(filepath.Join(testPath, ".."))
+	if err != nil {
+		t.Fatalf("Fatal error - cannot get parent path! - %v", err)
 	}
 
 	var tests = []struct {
@@ -44,6 +45,7 @@ func TestCd(t *testing.T) {
 		{newCp(testPath, []string{".."}, []string{}), parentPath, nil},
 		{newCp(testPath, []string{"..", "."}, []string{}), "", ErrCdTooManyArgs},
 		{newCp(testPath, []string{"/not/existing/path"}, []string{}), "", ErrCdPathNotExist},
+		{newCp(testPath, []string{"cd.go"}, []string{}), "", ErrCdPathLeadsToFile},
 	}
 
 	for _, test := range tests {
@@ -53,9 +55,138 @@ func TestCd(t *testing.T) {
 	}
 }
 
+func TestCdHomeOldPwdCdpath(t *testing.T) {
+	path, err := ioutil.TempDir("", "cd-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		t.Fatalf("Fatal error - cannot evaluate temporary directory! - %v", err)
+	}
+	makeTestTree(t, path, map[string]string{"home/": "", "old/": "", "projects/app/": "", "work/app/": "", "work/lib/": ""})
+	for _, name := range []string{"HOME", "OLDPWD", "CDPATH"} {
+		defer os.Setenv(name, os.Getenv(name))
+	}
+	os.Setenv("HOME", filepath.Join(path, "home"))
+
+	var tests = []struct {
+		oldPwd string
+		cdpath string
+		words  []string
+		result string
+		output string
+		err    error
+	}{
+		{"", "", nil, "home", "", nil},
+		{"", "", []string{""}, "home", "", nil},
+		{filepath.Join(path, "old"), "", []string{"-"}, "old", filepath.Join(path, "old") + "\n", nil},
+		{"", "", []string{"-"}, "work", "", ErrCdOldPwdNotSet},
+		{"", filepath.Join(path, "projects"), []string{"app"}, "projects/app", filepath.Join(path, "projects", "app") + "\n", nil},
+		{"", string(os.PathListSeparator) + filepath.Join(path, "projects"), []string{"app"}, "work/app", "", nil},
+		{"", filepath.Join(path, "projects"), []string{"lib"}, "work/lib", "", nil},
+		{"", filepath.Join(path, "projects"), []string{"./app"}, "work/app", "", nil},
+		{"", filepath.Join(path, "projects"), []string{"missing"}, "work", "", ErrCdPathNotExist},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cd test with words %v, OLDPWD %s and CDPATH %s", test.words, test.oldPwd, test.cdpath), func(t *testing.T) {
+			os.Setenv("OLDPWD", test.oldPwd)
+			os.Setenv("CDPATH", test.cdpath)
+			cd := &Cd{}
+			output, err := runTestCommand(t, cd, filepath.Join(path, "work"), test.words)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if expected := filepath.Join(path, filepath.FromSlash(test.result)); cd.GetPath() != expected {
+				t.Errorf("Expected path %s, but got: %s", expected, cd.GetPath())
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+func TestCdLogicalPhysical(t *testing.T) {
+	path, err := ioutil.TempDir("", "cd-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		t.Fatalf("Fatal error - cannot evaluate temporary directory! - %v", err)
+	}
+	makeTestTree(t, path, map[string]string{"real/dir/": "", "real/file": ""})
+	for link, target := range map[string]string{"link": "real/dir", "flink": "real/file"} {
+		if err := os.Symlink(filepath.Join(path, filepath.FromSlash(target)), filepath.Join(path, link)); err != nil {
+			t.Skipf("Cannot make symbolic link - %v", err)
+		}
+	}
+
+	var tests = []struct {
+		start  string
+		words  []string
+		result string
+		err    error
+	}{
+		{"", []string{"link"}, "link", nil},
+		{"", []string{"-L", "link"}, "link", nil},
+		{"", []string{"-P", "link"}, "real/dir", nil},
+		{"", []string{"link/.."}, "", nil},
+		{"", []string{"-P", "link/.."}, "real", nil},
+		{"", []string{"-L", "-P", "link/.."}, "real", nil},
+		{"", []string{"-P", "-L", "link/.."}, "", nil},
+		{"link", []string{".."}, "", nil},
+		{"link", []string{"-P", ".."}, "real", nil},
+		{"link", []string{"../../real/dir"}, "real/dir", nil},
+		{"link", []string{"../file"}, "link", ErrCdPathLeadsToFile},
+		{"link", []string{"../missing"}, "link", ErrCdPathNotExist},
+		{"", []string{"flink"}, "", ErrCdPathLeadsToFile},
+		{"", []string{"-P", "flink"}, "", ErrCdPathLeadsToFile},
+		{"", []string{"-P", "missing"}, "", ErrCdPathNotExist},
+		{"", []string{"-x", "link"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cd test with words %v from %s", test.words, test.start), func(t *testing.T) {
+			cd := &Cd{}
+			start := filepath.Join(path, filepath.FromSlash(test.start))
+			if _, err := runTestCommand(t, cd, start, test.words); !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if expected := filepath.Join(path, filepath.FromSlash(test.result)); cd.GetPath() != expected {
+				t.Errorf("Expected path %s, but got: %s", expected, cd.GetPath())
+			}
+		})
+	}
+}
+
+func TestCdDeletedPath(t *testing.T) {
+	path, err := ioutil.TempDir("", "cd-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"deleted/": ""})
+	deleted := filepath.Join(path, "deleted")
+	if err := os.Remove(deleted); err != nil {
+		t.Fatalf("Fatal error - cannot remove directory! - %v", err)
+	}
+
+	cd := &Cd{}
+	if _, err := runTestCommand(t, cd, deleted, []string{"."}); !errors.Is(err, ErrCdPathNotExist) {
+		t.Errorf("Expected error %v, but got: %v", ErrCdPathNotExist, err)
+	}
+	if _, err := runTestCommand(t, cd, deleted, []string{".."}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if cd.GetPath() != path {
+		t.Errorf("Expected path %s, but got: %s", path, cd.GetPath())
+	}
+}
+
 func ExampleCd_Execute() {
 	cd := Cd{}
-	cd.Execute(newCp("", []string{`\`}, []string{}))
+	cd.Execute(newCp("", []string{string(os.PathSeparator)}, []string{}))
 
 	path, _ := os.Getwd()
 	if cd.GetPath() == getRootPath(path) {
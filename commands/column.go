@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrColumnInvalidWidth indicates that the width given to column command with -c is not valid
+	ErrColumnInvalidWidth = errors.New("invalid width")
+)
+
+// Column is a structure for column command, implementing ExecuteCommand interface
+type Column struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// GetName is a getter for command name
+func (c *Column) GetName() string {
+	return "column"
+}
+
+// GetPath is a getter for path
+func (c *Column) GetPath() string {
+	return c.path
+}
+
+// Clone is a method for cloning column command
+func (c *Column) Clone() ExecuteCommand {
+	clone := *c
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (c *Column) InitStopSignalCatching() {
+	c.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (c *Column) SendStopSignal() {
+	c.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (c *Column) IsStopSignalReceived() bool {
+	select {
+	case <-c.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of column command
+//
+// The lines of the files in the arguments, or of the input without arguments, are written in columns, which fill the width of the terminal.
+// The columns are filled before the rows, -x fills the rows first and -c gives the width. Like in column, the columns are separated with tabs.
+//
+// With -t the lines are written as table - every line is a row, which is split to cells by the blanks or by the characters given with -s,
+// and the columns of the table are aligned. The columns are separated with two spaces or with the separator given with -o.
+// The width of the text is the number of its characters in UTF-8.
+func (c *Column) Execute(cp CommandProperties) error {
+	c.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "ts:o:xc:", []string{"table", "separator=", "output-separator=",
+		"fillrows", "output-width="})
+	if err != nil {
+		return err
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+	value := func(short string, long string) string {
+		if options.has(long) {
+			return options.value(long)
+		}
+		return options.value(short)
+	}
+	width := terminalWidth(outputFile)
+	if has("c", "output-width") {
+		if width, err = strconv.Atoi(value("c", "output-width")); err != nil || width <= 0 {
+			return fmt.Errorf("%s - %w", value("c", "output-width"), ErrColumnInvalidWidth)
+		}
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+
+	var lines []string
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range arguments {
+		file, err := openInputFile(c.path, argument, inputFile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		in := newStreamReader(c, file)
+		err = processLines(in, nil, func(line string) error {
+			if strings.TrimSpace(line) != "" { // like in column, the empty lines are skipped
+				lines = append(lines, line)
+			}
+			return nil
+		})
+		if file != inputFile {
+			file.Close()
+		}
+		if err == ErrStoppedExec {
+			return err
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+		}
+	}
+
+	var text string
+	if has("t", "table") {
+		separators, outputSeparator := " \t", "  "
+		if has("s", "separator") {
+			separators = value("s", "separator")
+		}
+		if has("o", "output-separator") {
+			outputSeparator = value("o", "output-separator")
+		}
+		text = formatTable(lines, separators, outputSeparator)
+	} else {
+		text = formatColumns(lines, width, has("x", "fillrows"))
+	}
+	if err := checkWrite(c, outputFile, text); err != nil {
+		return err
+	}
+	return joinErrors(errs)
+}
+
+// formatTable function formats the lines as table, the cells of every line are separated by the characters in separators.
+// When the separators are blanks, the blanks between cells are merged like in column -t.
+func formatTable(lines []string, separators string, outputSeparator string) string {
+	isSeparator := func(r rune) bool { return strings.ContainsRune(separators, r) }
+	merge := strings.Trim(separators, " \t") == ""
+	var rows [][]string
+	var widths []int
+	for _, line := range lines {
+		var cells []string
+		if merge == true {
+			cells = strings.FieldsFunc(line, isSeparator)
+		} else {
+			cells = splitFunc(line, isSeparator)
+		}
+		for ind, cell := range cells {
+			if ind == len(widths) {
+				widths = append(widths, 0)
+			}
+			if length := utf8.RuneCountInString(cell); length > widths[ind] {
+				widths[ind] = length
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	var result strings.Builder
+	for _, row := range rows {
+		for ind, cell := range row {
+			result.WriteString(cell)
+			if ind+1 < len(row) { // the last cell on the row is not padded
+				result.WriteString(strings.Repeat(" ", widths[ind]-utf8.RuneCountInString(cell)) + outputSeparator)
+			}
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// splitFunc function splits text at every character for which isSeparator is true, the empty parts are kept
+func splitFunc(text string, isSeparator func(r rune) bool) []string {
+	var parts []string
+	start := 0
+	for ind, r := range text {
+		if isSeparator(r) {
+			parts, start = append(parts, text[start:ind]), ind+utf8.RuneLen(r)
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// formatColumns function formats the lines in columns, which fit in width, like column command.
+// Every column is as wide as the longest line rounded up to tab stop and the lines are padded with tabs.
+func formatColumns(lines []string, width int, fillRows bool) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	const tabWidth = 8
+	longest := 0
+	for _, line := range lines {
+		if length := utf8.RuneCountInString(line); length > longest {
+			longest = length
+		}
+	}
+	columnWidth := (longest + tabWidth) / tabWidth * tabWidth
+	columns := width / columnWidth
+	if columns <= 1 {
+		return strings.Join(lines, "\n") + "\n"
+	}
+	rows := (len(lines) + columns - 1) / columns
+
+	var result strings.Builder
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			ind := column*rows + row
+			if fillRows == true {
+				ind = row*columns + column
+			}
+			if ind >= len(lines) {
+				break
+			}
+			result.WriteString(lines[ind])
+			next := ind + rows
+			if fillRows == true {
+				next = ind + 1
+			}
+			if column+1 < columns && next < len(lines) { // the last line on the row is not padded
+				for length := utf8.RuneCountInString(lines[ind]); length < columnWidth; length = (length + tabWidth) / tabWidth * tabWidth {
+					result.WriteString("\t")
+				}
+			}
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestColumn(t *testing.T) {
+	path := makeFieldFixtures(t)
+	defer os.RemoveAll(path)
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"-t", "-s,", "people.csv"}, "name   city     age\nIvan   Sofia    31\nMaria  Plovdiv  27\nJohn            45\n", nil},
+		{"", []string{"-t", "-s", ",", "-o", " | ", "people.csv"},
+			"name  | city    | age\nIvan  | Sofia   | 31\nMaria | Plovdiv | 27\nJohn  |         | 45\n", nil},
+		{"", []string{"-t", "table.tsv"}, "id  name  score\n1   ana   9.5\n2   bob   7\n3   carl\n", nil},
+		{"", []string{"-t", "-s;", "utf8"}, "ъгъл  ябълка  30\nжълт  синьо   7\n", nil},
+		{"a   b\n\nccc d\n", []string{"-t"}, "a    b\nccc  d\n", nil},
+		{"", []string{"-c", "30", "numbers"}, "1\t3\t5\n2\t4\n", nil},
+		{"", []string{"-c", "30", "-x", "numbers"}, "1\t2\t3\n4\t5\n", nil},
+		{"", []string{"-c", "10", "short"}, "a\nb\n", nil},
+		{"", []string{"-c", "80", "people.csv", "short"},
+			"name,city,age\t\tMaria,Plovdiv,27\ta\nIvan,Sofia,31\t\tJohn,,45\t\tb\n", nil},
+		{"", []string{"empty"}, "", nil},
+		{"", []string{"-t", "missing", "short"}, "a\nb\n", os.ErrNotExist},
+		{"", []string{"-c", "x"}, "", ErrColumnInvalidWidth},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Column test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Column{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
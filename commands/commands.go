@@ -6,24 +6,58 @@
 // The catching of stop signals for all commands is implemented by a field that is a channel for receiving stop signal.
 // InitStopSignalCatching initializes that channel as buffered with space for receiving one signal, SendStopSignal puts a signal in the channel and IsStopSignalReceived tries to receive from that channel.
 //
-// Stopping execution of command is implemented by having all i/o operations go through the functions checkRead and checkWrite which first check for stop signal.
+// Stopping execution of command is implemented by having all i/o operations go through the function checkWrite or the buffered streamReader and streamWriter, which first check for stop signal.
 // In this way, when a stop signal is sent to the command, the command won't communicate with the "outside world" anymore.
 // The moment it tries, these functions return error to the command and the command will know it has to stop.
+// The output of streamWriter is flushed when the command finishes, but not when it is stopped.
 package commands
 
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 )
 
 var (
 	// ErrStoppedExec indicates that the execution of command was stopped
 	ErrStoppedExec = errors.New("execution was stopped")
+	// ErrInvalidOption indicates that the command was given an option it does not support
+	ErrInvalidOption = errors.New("invalid option")
+	// ErrMissingValue indicates that option which needs value is the last word of command
+	ErrMissingValue = errors.New("option requires a value")
 )
 
+// ExitStatusError is the error of command, which ends with exit status like the programs.
+// For example, grep ends with status 1 when no lines are selected and with status 2 when there is error.
+type ExitStatusError struct {
+	Status int
+	Err    error // Err is the error, which causes the status, it is nil when the status is not because of error and nothing should be written
+}
+
+// Error is a method returning the message of the error, which causes the status
+func (e *ExitStatusError) Error() string {
+	if e.Err == nil {
+		return "exit status " + strconv.Itoa(e.Status)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap is a method returning the error, which causes the status
+func (e *ExitStatusError) Unwrap() error {
+	return e.Err
+}
+
+// IsSilentError function checks if err is only exit status, which should not be written, like the status of grep without selected lines
+func IsSilentError(err error) bool {
+	var statusErr *ExitStatusError
+	return errors.As(err, &statusErr) && statusErr.Err == nil
+}
+
 // CommandProperties is used for storing the properties of command that will be executed
 type CommandProperties struct {
 	Path       string //  Path is used for storing the current path in the terminal for this command
@@ -31,11 +65,27 @@ type CommandProperties struct {
 	Options    []string
 	InputFile  *os.File // InputFile is used for reading the input it could be stdin
 	OutputFile *os.File // OutputFile is used for reading the input it could be stdin
+	Words      []string // Words stores the options (with their leading '-') and the arguments in the order they were written
 }
 
 // Function for constructing CommandProperties object with only path, arguments and options
 func newCp(Path string, Arguments []string, Options []string) CommandProperties {
-	return CommandProperties{Path, Arguments, Options, os.Stdin, os.Stdout}
+	return CommandProperties{Path, Arguments, Options, os.Stdin, os.Stdout, nil}
+}
+
+// words is a method for getting the options and the arguments in the order they were written.
+// If Words is not set, the options are put before the arguments.
+func (cp CommandProperties) words() []string {
+	if cp.Words != nil {
+		return cp.Words
+	}
+	var words []string
+	for _, option := range cp.Options {
+		if len(option) > 0 {
+			words = append(words, "-"+option)
+		}
+	}
+	return append(words, cp.Arguments...)
 }
 
 // ExecuteCommand is interface for executing commands
@@ -56,17 +106,50 @@ type ExecuteCommand interface {
 	Execute(cp CommandProperties) error
 }
 
+// CommandLookup is a function returning clone of the registered command with name or nil if there is no such command
+type CommandLookup func(name string) ExecuteCommand
+
+// CommandRunner is interface for commands, which run other registered commands, like find with -exec
+//
+// The interpreter calls SetCommandLookup before the execution of such command.
+type CommandRunner interface {
+	SetCommandLookup(lookup CommandLookup)
+}
+
+// FileOpener is a function opening file with name relative to the path of the interpreter, flag is like in os.OpenFile
+type FileOpener func(name string, flag int) (*os.File, error)
+
+// FileOpenerUser is interface for commands, which open files with the helpers of the interpreter, like tee
+//
+// The interpreter calls SetFileOpener before the execution of such command.
+type FileOpenerUser interface {
+	SetFileOpener(open FileOpener)
+}
+
+// wordsToCp is function for constructing CommandProperties from words, which are split to options and arguments like in the parser
+func wordsToCp(path string, words []string, inputFile *os.File, outputFile *os.File) CommandProperties {
+	cp := CommandProperties{path, []string{}, []string{}, inputFile, outputFile, words}
+	for _, word := range words {
+		if len(word) > 1 && word[0] == '-' {
+			cp.Options = append(cp.Options, word[1:])
+		} else {
+			cp.Arguments = append(cp.Arguments, word)
+		}
+	}
+	return cp
+}
+
 // FullFileName function is used to construct full file name from parameters
 func FullFileName(path string, fileName string) string {
 	var fullName string
 	if runtime.GOOS == "windows" {
-		if fileName[0] == '\\' || strings.TrimPrefix(fileName, getRootPath(path)) != fileName {
+		if (fileName != "" && fileName[0] == '\\') || strings.TrimPrefix(fileName, getRootPath(path)) != fileName {
 			fullName = fileName
 		} else {
 			fullName = path + `\` + fileName
 		}
 	} else {
-		if fileName[0] == '/' {
+		if fileName != "" && fileName[0] == '/' {
 			fullName = fileName
 		} else {
 			fullName = path + "/" + fileName
@@ -75,6 +158,35 @@ func FullFileName(path string, fileName string) string {
 	return fullName
 }
 
+// openInputFile function is helper for the commands reading the files in their arguments.
+// It opens the file with name relative to path, the name - is for inputFile.
+func openInputFile(path string, name string, inputFile *os.File) (*os.File, error) {
+	if name == "-" {
+		return inputFile, nil
+	}
+	file, err := os.Open(FullFileName(path, name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s - %w", name, os.ErrNotExist)
+	} else if err != nil {
+		return nil, fmt.Errorf("%s - %w", name, err)
+	}
+	return file, nil
+}
+
+// inputFileName function returns the name of file, as it is written in headers, the name - is for the input
+func inputFileName(name string) string {
+	if name == "-" {
+		return "standard input"
+	}
+	return name
+}
+
+// isSubPath function is helper for checking if name is the same as parent or it is inside parent
+func isSubPath(parent string, name string) bool {
+	relative, err := filepath.Rel(parent, name)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(os.PathSeparator))
+}
+
 // getRootPath function is used to extract root path from a valid path
 func getRootPath(path string) string {
 	if runtime.GOOS == "windows" {
@@ -87,17 +199,189 @@ func getRootPath(path string) string {
 	return res[0]
 }
 
-// checkRead function is very important - it reads from file, checking if there is a stop signal and also checking for error in reading
-func checkRead(e ExecuteCommand, inputFile *os.File) (string, error) {
-	if e.IsStopSignalReceived() == true {
-		return "", ErrStoppedExec
+// commandOptions stores the parsed options of command, every option is mapped to the values given for it
+type commandOptions map[string][]string
+
+// has is a method for checking if option with name is given
+func (o commandOptions) has(name string) bool {
+	_, ok := o[name]
+	return ok
+}
+
+// value is a method for getting the last value of option with name, it returns empty string if the option is not given
+func (o commandOptions) value(name string) string {
+	values := o[name]
+	if len(values) == 0 {
+		return ""
 	}
-	var buf = make([]byte, 1<<4)
-	_, err := inputFile.Read(buf)
+	return values[len(values)-1]
+}
+
+// parseOptions function is helper for parsing the options of command from the ordered words in cp.
+// It returns the options and the arguments that are left in their order.
+//
+// The letters in short are the allowed short options and a letter followed by ':' is for option with value.
+// A letter followed by "::" is for option with optional value, which can only be attached, like the suffix in "-i.bak".
+// Short options can be grouped, for example "-la" is the same as "-l -a", and the value can be attached like in "-m755" or be the next word.
+// The names in long are the allowed long options, which are written with "--" on the command line.
+// Long options can have value after '=', and names ending with '=' are for options with required value, which can also be the next word.
+// The word "--" means that all words after it are arguments.
+// It returns error if some option is not allowed or value is missing.
+func parseOptions(cp CommandProperties, short string, long []string) (commandOptions, []string, error) {
+	options := make(commandOptions)
+	arguments, err := walkOptions(cp, short, long, func(name string, value string) {
+		options[name] = append(options[name], value)
+	})
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+	return options, arguments, nil
+}
+
+// walkOptions function parses the words in cp like parseOptions, calling found for every option in the order they were written.
+// It returns the arguments.
+func walkOptions(cp CommandProperties, short string, long []string, found func(name string, value string)) ([]string, error) {
+	var arguments []string
+	words := cp.words()
+	for ind := 0; ind < len(words); ind++ {
+		word := words[ind]
+		if word == "--" {
+			arguments = append(arguments, words[ind+1:]...)
+			break
+		}
+		if len(word) < 2 || word[0] != '-' {
+			arguments = append(arguments, word)
+			continue
+		}
+
+		if word[1] == '-' { // long option
+			name, value, hasValue := word[2:], "", false
+			if eq := strings.IndexByte(name, '='); eq != -1 {
+				name, value, hasValue = name[:eq], name[eq+1:], true
+			}
+			isLong, needsValue := false, false
+			for _, l := range long {
+				if l == name || l == name+"=" {
+					isLong, needsValue = true, l == name+"="
+					break
+				}
+			}
+			if isLong == false {
+				return nil, fmt.Errorf("%s - %w", word, ErrInvalidOption)
+			}
+			if needsValue == true && hasValue == false {
+				if ind+1 == len(words) {
+					return nil, fmt.Errorf("%s - %w", word, ErrMissingValue)
+				}
+				ind++
+				value = words[ind]
+			}
+			found(name, value)
+			continue
+		}
+
+		for pos := 1; pos < len(word); pos++ { // group of short options
+			char := word[pos]
+			at := strings.IndexByte(short, char)
+			if at == -1 || char == ':' {
+				return nil, fmt.Errorf("-%c - %w", char, ErrInvalidOption)
+			}
+			name := string(char)
+			if at+1 < len(short) && short[at+1] == ':' {
+				value := word[pos+1:]
+				if value == "" && at+2 < len(short) && short[at+2] == ':' { // optional value, which can only be attached
+					found(name, value)
+					break
+				}
+				if value == "" {
+					if ind+1 == len(words) {
+						return nil, fmt.Errorf("-%c - %w", char, ErrMissingValue)
+					}
+					ind++
+					value = words[ind]
+				}
+				found(name, value)
+				break
+			}
+			found(name, "")
+		}
+	}
+	return arguments, nil
+}
+
+// lastOption function is helper for finding which of the short options in letters is given last, it returns 0 if none of them is given.
+// It is used for options which override each other, like -i and -f.
+// The parameters short and long are the allowed options like in parseOptions, so that the values of options are not mistaken for options.
+// The long options in aliases are the same as the short options, to which they are mapped, like --force and -f.
+func lastOption(cp CommandProperties, short string, long []string, letters string, aliases map[string]byte) byte {
+	var last byte
+	walkOptions(cp, short, long, func(name string, value string) {
+		if len(name) == 1 && strings.IndexByte(letters, name[0]) != -1 {
+			last = name[0]
+		} else if letter, ok := aliases[name]; ok == true {
+			last = letter
+		}
+	})
+	return last
+}
+
+// joinErrors function is helper for making one error from the errors collected during the execution of command.
+// When there is only one error it is returned unchanged, so it can still be checked with errors.Is.
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	var errStrings []string
+	for _, err := range errs {
+		errStrings = append(errStrings, err.Error())
+	}
+	return errors.New(strings.Join(errStrings, "\n"))
+}
+
+// askForConfirmation function writes question to outputFile and reads one line from inputFile for the answer.
+// The answer is positive if it starts with 'y' or 'Y'. When the input ends without answer, it is negative.
+func askForConfirmation(e ExecuteCommand, inputFile *os.File, outputFile *os.File, question string) (bool, error) {
+	if err := checkWrite(e, outputFile, question); err != nil {
+		return false, err
+	}
+	answer, err := readLine(e, inputFile)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return len(answer) > 0 && (answer[0] == 'y' || answer[0] == 'Y'), nil
+}
+
+// readLine function reads from inputFile until new line or end of file, checking for stop signal.
+// It reads byte by byte, so nothing after the new line is consumed and the next read can continue from there.
+func readLine(e ExecuteCommand, inputFile *os.File) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		if e.IsStopSignalReceived() == true {
+			return "", ErrStoppedExec
+		}
+		n, err := inputFile.Read(buf)
+		if n == 1 {
+			if buf[0] == '\n' {
+				return strings.TrimRight(string(line), "\r"), nil
+			}
+			line = append(line, buf[0])
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
+
+// defaultTerminalWidth function returns the width from COLUMNS environment variable or 80 if it is not set
+func defaultTerminalWidth() int {
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
 	}
-	return strings.TrimRight(string(buf), "\u0000"), nil
+	return 80
 }
 
 // checkWrite function is very important - it writes to file, checking if there is a stop signal and also checking for error in writing
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseOptions(t *testing.T) {
+	var tests = []struct {
+		words     []string
+		options   string // options are written in the format name=value separated by spaces
+		arguments []string
+		err       error
+	}{
+		{[]string{"-la", "dir"}, "a= l=", []string{"dir"}, nil},
+		{[]string{"-n", "5", "file", "-n7"}, "n=5 n=7", []string{"file"}, nil},
+		{[]string{"-ln", "5", "-"}, "l= n=5", []string{"-"}, nil},
+		{[]string{"--time=x", "--size", "10", "--time"}, "size=10 time=x time=", nil, nil},
+		{[]string{"a", "--", "-l", "--time"}, "", []string{"a", "-l", "--time"}, nil},
+		{[]string{"-x"}, "", nil, ErrInvalidOption},
+		{[]string{"--other"}, "", nil, ErrInvalidOption},
+		{[]string{"-l", "-n"}, "", nil, ErrMissingValue},
+		{[]string{"--size"}, "", nil, ErrMissingValue},
+		{[]string{"-s", "file", "-s.bak", "-as"}, "a= s= s=.bak s=", []string{"file"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parseOptions(%v)", test.words), func(t *testing.T) {
+			options, arguments, err := parseOptions(CommandProperties{Words: test.words}, "ln:as::", []string{"time", "size="})
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+			var result string
+			for _, name := range []string{"a", "l", "n", "s", "size", "time"} {
+				for _, value := range options[name] {
+					result += " " + name + "=" + value
+				}
+			}
+			if len(result) > 0 {
+				result = result[1:]
+			}
+			if result != test.options || fmt.Sprint(arguments) != fmt.Sprint(test.arguments) {
+				t.Errorf("Expected options %s and arguments %v, but got: %s and %v", test.options, test.arguments, result, arguments)
+			}
+		})
+	}
+}
+
+func TestLastOption(t *testing.T) {
+	var tests = []struct {
+		words []string
+		last  byte
+	}{
+		{[]string{"-la", "-n", "a"}, 'a'},
+		{[]string{"-al", "dir"}, 'l'},
+		{[]string{"-a", "-nl"}, 'a'},
+		{[]string{"-a", "--size", "-l", "--", "-l"}, 'a'},
+		{[]string{"--time", "file"}, 0},
+		{[]string{"-l", "--all"}, 'a'},
+		{[]string{"--all", "-l"}, 'l'},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("lastOption(%v)", test.words), func(t *testing.T) {
+			if last := lastOption(CommandProperties{Words: test.words}, "ln:a", []string{"time", "size=", "all"}, "la", map[string]byte{"all": 'a'}); last != test.last {
+				t.Errorf("Expected %q, but got: %q", test.last, last)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	cp := newCp("", []string{"a", "b"}, []string{"l", "", "-time=iso"})
+	if result := fmt.Sprint(cp.words()); result != "[-l --time=iso a b]" {
+		t.Errorf("Expected [-l --time=iso a b], but got: %s", result)
+	}
+}
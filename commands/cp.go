@@ -1,23 +1,40 @@
 package commands
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
+	"path/filepath"
 )
 
 var (
-	// ErrCpTwoArgs indicates that the argument count is different from two
-	ErrCpTwoArgs = errors.New("Two arguments are needed")
+	// ErrCpTwoArgs indicates that the argument count is less than two
+	ErrCpTwoArgs = errors.New("At least two arguments are needed")
 	// ErrCpInvalidName indicates that source argument is not a valid name in file system
 	ErrCpInvalidName = errors.New("is not a valid name in the file system")
 	// ErrCpIsDir indicates that source argument is name of directory
 	ErrCpIsDir = errors.New("is a directory")
 	// ErrCpSame indicates that source and target are the same file
 	ErrCpSame = errors.New("Source and target file are the same")
+	// ErrCpNotDir indicates that the target is not a directory, but it should be
+	ErrCpNotDir = errors.New("is not a directory")
+	// ErrCpIntoItself indicates that directory should be copied inside itself
+	ErrCpIntoItself = errors.New("cannot copy a directory into itself")
+	// ErrCpLoop indicates that symbolic links make a loop in the copied directory tree
+	ErrCpLoop = errors.New("is a directory that is already being copied, symbolic links make a loop")
+	// ErrCpChecksum indicates that the checksum of the copy is different from the checksum of the source
+	ErrCpChecksum = errors.New("checksum of the copy is different from the checksum of the source")
+	// ErrCpNotRegular indicates that source is special file like device or named pipe which cannot be copied
+	ErrCpNotRegular = errors.New("is not a regular file, directory or symbolic link")
 )
 
+// copyChunkSize is the size of the chunks in which files are copied
+const copyChunkSize = 1 << 20
+
 // Cp is a structure for cp command, implementing ExecuteCommand interface
 type Cp struct {
 	path          string
@@ -63,42 +80,453 @@ func (c *Cp) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of cp command
+//
+// With two arguments the first one is copied to the second one or inside it if it is a directory.
+// With more arguments all of them except the last one are copied inside the last one, which should be a directory.
 func (c *Cp) Execute(cp CommandProperties) error {
 	c.path = cp.Path
 
-	if len(cp.Arguments) != 2 {
+	options, arguments, err := parseOptions(cp, "rRpniuvL", []string{"progress", "resume", "checksum"})
+	if err != nil {
+		return err
+	}
+	if len(arguments) < 2 {
 		return ErrCpTwoArgs
 	}
 
-	source := FullFileName(c.path, cp.Arguments[0])
-	dest := FullFileName(c.path, cp.Arguments[1])
-	if source == dest {
-		return ErrCpSame
+	cr := copier{command: c, inputFile: cp.InputFile, outputFile: cp.OutputFile}
+	cr.recursive = options.has("r") || options.has("R")
+	cr.preserve = options.has("p")
+	cr.noClobber = options.has("n")
+	cr.interactive = options.has("i")
+	cr.update = options.has("u")
+	cr.verbose = options.has("v")
+	cr.dereference = options.has("L")
+	cr.progress = options.has("progress")
+	cr.resume = options.has("resume")
+	cr.checksum = options.has("checksum")
+
+	sources := arguments[:len(arguments)-1]
+	dest := FullFileName(c.path, arguments[len(arguments)-1])
+	stat, err := os.Stat(dest)
+	destIsDir := err == nil && stat.IsDir()
+	if len(sources) > 1 && destIsDir == false {
+		return fmt.Errorf("%s - %w", dest, ErrCpNotDir)
 	}
 
-	stat, err := os.Stat(source)
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range sources {
+		source := FullFileName(c.path, argument)
+		target := dest
+		if destIsDir == true {
+			target = filepath.Join(dest, filepath.Base(source))
+		}
+		err := cr.copySource(source, target)
+		errs = append(errs, cr.errs...)
+		cr.errs = nil
+		if err == ErrStoppedExec {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// copier stores the settings for copying files and directory trees. It is used by cp and mv commands.
+type copier struct {
+	command     ExecuteCommand  // command is used for checking for stop signal
+	inputFile   *os.File        // inputFile is used for reading the answers to the questions of interactive mode
+	outputFile  *os.File        // outputFile is used for the questions of interactive mode and verbose output
+	recursive   bool            // recursive allows copying directories
+	preserve    bool            // preserve is for preserving mode, ownership and timestamps
+	noClobber   bool            // noClobber is for not overwriting existing files
+	interactive bool            // interactive is for asking before overwriting existing files
+	update      bool            // update is for copying only when the source is newer than the existing target
+	verbose     bool            // verbose is for writing every copied file
+	dereference bool            // dereference is for copying the files symbolic links point to instead of the links
+	progress    bool            // progress is for writing the progress of copying of every file
+	resume      bool            // resume is for continuing the copying of files which are partially copied
+	checksum    bool            // checksum is for comparing the checksums of every copied file and its copy
+	errs        []error         // errs collects the errors in the copied tree, which do not stop the copying
+	created     map[string]bool // created stores the new directories, whose modes are corrected after the copying
+}
+
+// copySource is a method for copying source, given on command line, to target
+func (c *copier) copySource(source string, target string) error {
+	if source == target {
+		return fmt.Errorf("%s - %w", source, ErrCpSame)
+	}
+	info, err := c.stat(source)
 	if os.IsNotExist(err) {
 		return fmt.Errorf("%s - %w", source, ErrCpInvalidName)
 	} else if err != nil {
 		return err
-	} else if stat.IsDir() {
-		return fmt.Errorf("%s - %w", source, ErrCpIsDir)
 	}
+	if info.IsDir() {
+		if c.recursive == false {
+			return fmt.Errorf("%s - %w", source, ErrCpIsDir)
+		}
+		if isSubPath(source, target) {
+			return fmt.Errorf("%s - %w", target, ErrCpIntoItself)
+		}
+	}
+	return c.copyEntry(source, target, info)
+}
+
+// stat is a method for getting information for file, following symbolic links only in dereference mode
+func (c *copier) stat(name string) (os.FileInfo, error) {
+	if c.dereference == true {
+		return os.Stat(name)
+	}
+	return os.Lstat(name)
+}
+
+// copyEntry is a method for copying source with information info to target.
+// Directories are copied with all files in them and the errors in the tree are collected in c.errs, so the copying can continue with the other files.
+func (c *copier) copyEntry(source string, target string, info os.FileInfo) error {
+	if info.IsDir() {
+		return c.copyTree(source, target, info)
+	}
+	return c.copyNode(source, target, info)
+}
+
+// copyTree is a method for copying the directory source with information info and all files in it to target.
+// The tree is walked in sorted order, while the directories in it are read in advance.
+func (c *copier) copyTree(source string, target string, info os.FileInfo) error {
+	if err := c.copyNode(source, target, info); err != nil {
+		return err
+	}
+
+	w := walker{command: c.command, sorted: true, followLinks: c.dereference}
+	w.visit = func(entry *walkEntry, err error) error {
+		if errors.Is(err, ErrWalkLoop) {
+			err = fmt.Errorf("%s - %w", entry.name, ErrCpLoop)
+		}
+		if err != nil && entry.depth == 0 {
+			return err
+		}
+		if err != nil {
+			c.errs = append(c.errs, err)
+			return nil
+		}
+		if entry.depth == 0 { // the start directory is already copied
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err == nil {
+			err = c.copyNode(entry.name, filepath.Join(target, entry.relative), info)
+		}
+		if err == ErrStoppedExec {
+			return err
+		}
+		if err != nil {
+			c.errs = append(c.errs, err)
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	w.leave = func(entry *walkEntry) error {
+		info, err := entry.Info()
+		if err == nil {
+			err = c.finishDir(filepath.Join(target, entry.relative), info)
+		}
+		if err != nil && entry.depth == 0 {
+			return err
+		}
+		if err != nil {
+			c.errs = append(c.errs, err)
+		}
+		return nil
+	}
+	return w.walk(source)
+}
+
+// copyNode is a method for copying source with information info to target without the files in it, if it is a directory
+func (c *copier) copyNode(source string, target string, info os.FileInfo) error {
+	if c.command.IsStopSignalReceived() == true {
+		return ErrStoppedExec
+	}
+	targetInfo, err := os.Lstat(target)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if exists && os.SameFile(info, targetInfo) {
+		return fmt.Errorf("%s - %w", target, ErrCpSame)
+	}
+
+	if info.IsDir() {
+		return c.makeDir(source, target, info, targetInfo)
+	}
+	if exists {
+		if targetInfo.IsDir() {
+			return fmt.Errorf("%s - %w", target, ErrCpIsDir)
+		}
+		skip, err := c.skipExisting(target, info, targetInfo)
+		if err != nil || skip == true {
+			return err
+		}
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(source)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+		if err := os.Symlink(link, target); err != nil {
+			return err
+		}
+	case info.Mode().IsRegular():
+		if err := c.copyFile(source, target, info); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%s - %w", source, ErrCpNotRegular)
+	}
+
+	if c.preserve == true {
+		if err := preserveAttributes(target, info); err != nil {
+			return err
+		}
+	}
+	return c.outputVerbose(source, target)
+}
+
+// makeDir is a method for making the copy target of the directory source with information info, if target with information targetInfo does not exist
+func (c *copier) makeDir(source string, target string, info os.FileInfo, targetInfo os.FileInfo) error {
+	if targetInfo == nil {
+		// we need to be able to write in the new directory, the mode is corrected by finishDir after the copying
+		if err := os.Mkdir(target, info.Mode().Perm()|0700); err != nil {
+			return err
+		}
+		if c.created == nil {
+			c.created = make(map[string]bool)
+		}
+		c.created[target] = true
+	} else if targetInfo.IsDir() == false {
+		return fmt.Errorf("%s - %w", target, ErrCpNotDir)
+	}
+	return c.outputVerbose(source, target)
+}
+
+// finishDir is a method for setting the attributes of the copy target of directory with information info, after the files in it are copied
+func (c *copier) finishDir(target string, info os.FileInfo) error {
+	if c.preserve == true {
+		return preserveAttributes(target, info)
+	}
+	if perm := info.Mode().Perm(); c.created[target] == true && perm&0700 != 0700 {
+		return os.Chmod(target, perm)
+	}
+	return nil
+}
+
+// copyFile is a method for copying the content of regular file source to target, which is created with the permissions from info if it does not exist.
+//
+// In resume mode, if target starts with the same bytes as source, only the rest of source is copied.
+// In checksum mode, the SHA-256 checksum of target is compared with the one of source after the copying.
+func (c *copier) copyFile(source string, target string, info os.FileInfo) error {
 	file, err := os.Open(source)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	copy, err := os.Create(dest)
+	var hash hash.Hash // hash is used for computing the checksum of source while copying it
+	if c.checksum == true {
+		hash = sha256.New()
+	}
+	var done int64
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if c.resume == true {
+		if done, err = c.resumeOffset(file, target, hash); err != nil {
+			return err
+		}
+		if done > 0 {
+			flags = os.O_WRONLY
+		}
+	}
+
+	copy, err := os.OpenFile(target, flags, info.Mode().Perm())
 	if err != nil {
 		return err
 	}
+	if _, err := copy.Seek(done, io.SeekStart); err != nil {
+		copy.Close()
+		return err
+	}
+	err = c.copyData(file, copy, done, info.Size(), target, hash)
+	if err == ErrStoppedExec {
+		// the copied part is written to the disk, so target can be resumed later
+		copy.Sync()
+	}
+	if errClose := copy.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil || c.checksum == false {
+		return err
+	}
+
+	targetHash := sha256.New()
+	if err := c.hashFile(target, targetHash); err != nil {
+		return err
+	}
+	if bytes.Equal(hash.Sum(nil), targetHash.Sum(nil)) == false {
+		return fmt.Errorf("%s - %w", target, ErrCpChecksum)
+	}
+	return nil
+}
+
+// copyData is a method for copying from file to copy in chunks, checking for stop signal before every chunk.
+// The copying starts with done bytes already copied out of total and every chunk is added to hash if it is not nil.
+func (c *copier) copyData(file *os.File, copy *os.File, done int64, total int64, name string, hash hash.Hash) error {
+	var p *progress
+	if c.progress == true {
+		p = newProgress(c.command, c.outputFile, name, total, done)
+	}
+	buf := make([]byte, copyChunkSize)
+	for {
+		if c.command.IsStopSignalReceived() == true {
+			return ErrStoppedExec
+		}
+		n, err := file.Read(buf)
+		if n > 0 {
+			if _, err := copy.Write(buf[:n]); err != nil {
+				return err
+			}
+			if hash != nil {
+				hash.Write(buf[:n])
+			}
+			done += int64(n)
+			if p != nil {
+				if err := p.update(done); err != nil {
+					return err
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if p != nil {
+		return p.finish(done)
+	}
+	return nil
+}
+
+// resumeOffset is a method for finding from where the copying of file to target can continue.
+// If target exists and all its bytes are the same as the first bytes of file, its size is returned and file is positioned after them.
+// Otherwise file is positioned at its start and 0 is returned, so the copying starts from the beginning.
+// The compared bytes of file are added to hash if it is not nil.
+func (c *copier) resumeOffset(file *os.File, target string, hash hash.Hash) (int64, error) {
+	restart := func() (int64, error) {
+		if hash != nil {
+			hash.Reset()
+		}
+		_, err := file.Seek(0, io.SeekStart)
+		return 0, err
+	}
+
+	copy, err := os.Open(target)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
 	defer copy.Close()
 
-	_, err = io.Copy(copy, file)
+	var done int64
+	buf, copyBuf := make([]byte, copyChunkSize), make([]byte, copyChunkSize)
+	for {
+		if c.command.IsStopSignalReceived() == true {
+			return 0, ErrStoppedExec
+		}
+		n, err := io.ReadFull(copy, copyBuf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		if n == 0 {
+			return done, nil
+		}
+		if m, err := io.ReadFull(file, buf[:n]); m != n || err != nil || !bytes.Equal(buf[:n], copyBuf[:n]) {
+			return restart() // target is longer than file or different from it
+		}
+		if hash != nil {
+			hash.Write(buf[:n])
+		}
+		done += int64(n)
+	}
+}
+
+// hashFile is a method for adding the content of file with name to hash, checking for stop signal before every chunk
+func (c *copier) hashFile(name string, hash hash.Hash) error {
+	file, err := os.Open(name)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer file.Close()
+
+	buf := make([]byte, copyChunkSize)
+	for {
+		if c.command.IsStopSignalReceived() == true {
+			return ErrStoppedExec
+		}
+		n, err := file.Read(buf)
+		hash.Write(buf[:n])
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// skipExisting is a method for deciding if the existing target should not be overwritten because of options -n, -u and -i
+func (c *copier) skipExisting(target string, info os.FileInfo, targetInfo os.FileInfo) (bool, error) {
+	if c.noClobber == true {
+		return true, nil
+	}
+	if c.update == true && !info.ModTime().After(targetInfo.ModTime()) {
+		return true, nil
+	}
+	if c.interactive == true {
+		overwrite, err := askForConfirmation(c.command, c.inputFile, c.outputFile, "overwrite '"+target+"'? ")
+		return !overwrite, err
+	}
+	return false, nil
+}
+
+// outputVerbose is a method for writing the copied source and target in verbose mode
+func (c *copier) outputVerbose(source string, target string) error {
+	if c.verbose == false {
+		return nil
+	}
+	return checkWrite(c.command, c.outputFile, "'"+source+"' -> '"+target+"'\n")
+}
+
+// preserveAttributes function is helper for setting the mode, ownership and timestamps of target to the ones from info.
+// Like in cp, the errors for changing the ownership are ignored, because only privileged users can do it.
+func preserveAttributes(target string, info os.FileInfo) error {
+	if uid, gid, ok := fileOwner(info); ok == true {
+		os.Lchown(target, uid, gid)
+	}
+	if info.Mode()&os.ModeSymlink != 0 { // mode and timestamps of symbolic links cannot be changed
+		return nil
+	}
+	if err := os.Chmod(target, info.Mode()&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	return os.Chtimes(target, fileAccessTime(info), info.ModTime())
 }
@@ -0,0 +1,28 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileOwner function is helper for getting the ids of the owner and the group of file
+func fileOwner(info os.FileInfo) (int, int, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if ok == false {
+		return 0, 0, false
+	}
+	return int(stat.Uid), int(stat.Gid), true
+}
+
+// fileAccessTime function is helper for getting the time of last access of file
+func fileAccessTime(info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if ok == false {
+		return info.ModTime()
+	}
+	return time.Unix(stat.Atim.Unix())
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+import (
+	"os"
+	"time"
+)
+
+// fileOwner function is helper for getting the ids of the owner and the group of file.
+// This information is available only on Linux.
+func fileOwner(info os.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
+
+// fileAccessTime function is helper for getting the time of last access of file.
+// Without this information the time of last modification is used.
+func fileAccessTime(info os.FileInfo) time.Time {
+	return info.ModTime()
+}
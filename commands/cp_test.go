@@ -3,8 +3,13 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func testingCp(t *testing.T, source string, arguments []string, expectedErr error) {
@@ -62,6 +67,202 @@ func TestCp(t *testing.T) {
 	}
 }
 
+// makeTestTree function is helper for making directory tree for testing in path.
+// The keys of files are file names with path relative to path and values are the contents, for names ending with / directories are made.
+func makeTestTree(t *testing.T, path string, files map[string]string) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names) // directories should be made before the files in them
+	for _, name := range names {
+		fullName := filepath.Join(path, name)
+		var err error
+		if strings.HasSuffix(name, "/") {
+			err = os.MkdirAll(fullName, 0755)
+		} else if err = os.MkdirAll(filepath.Dir(fullName), 0755); err == nil {
+			err = ioutil.WriteFile(fullName, []byte(files[name]), 0644)
+		}
+		if err != nil {
+			t.Fatalf("Fatal error - cannot make test tree! - %v", err)
+		}
+	}
+}
+
+// readTestFile function is helper for reading file in tests, it returns "<missing>" if the file cannot be read
+func readTestFile(name string) string {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "<missing>"
+	}
+	return string(data)
+}
+
+func TestCpTree(t *testing.T) {
+	var tests = []struct {
+		arguments []string
+		options   []string
+		input     string
+		output    string
+		err       error
+		expected  map[string]string // expected contents of files after the copying
+	}{
+		{[]string{"src", "dst"}, []string{}, "", "", ErrCpIsDir, map[string]string{"dst/a": "<missing>"}},
+		{[]string{"src", "dst"}, []string{"r"}, "", "", nil, map[string]string{"dst/a": "a", "dst/sub/b": "b", "dst/link": "a"}},
+		{[]string{"src", "dir"}, []string{"r"}, "", "", nil, map[string]string{"dir/src/a": "a", "dir/old": "old"}},
+		{[]string{"src", "src/sub"}, []string{"r"}, "", "", ErrCpIntoItself, nil},
+		{[]string{"src", "dst"}, []string{"rL"}, "", "", ErrCpLoop, map[string]string{"dst/link": "a", "dst/sub/b": "b", "dst/sub/up/a": "<missing>"}},
+		{[]string{"src/a", "src/sub/b", "dir"}, []string{}, "", "", nil, map[string]string{"dir/a": "a", "dir/b": "b"}},
+		{[]string{"src/a", "src/sub/b", "file"}, []string{}, "", "", ErrCpNotDir, map[string]string{"file": "file"}},
+		{[]string{"src/a", "file"}, []string{"n"}, "", "", nil, map[string]string{"file": "file"}},
+		{[]string{"src/a", "file"}, []string{"u"}, "", "", nil, map[string]string{"file": "file"}},
+		{[]string{"src/a", "file"}, []string{"i"}, "n\n", "overwrite '{}file'? ", nil, map[string]string{"file": "file"}},
+		{[]string{"src/a", "file"}, []string{"i"}, "yes\n", "overwrite '{}file'? ", nil, map[string]string{"file": "a"}},
+		{[]string{"src/a", "file"}, []string{"v"}, "", "'{}src/a' -> '{}file'\n", nil, map[string]string{"file": "a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cp test with arguments %v and options %v", test.arguments, test.options), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "cp-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"src/a": "a", "src/sub/b": "b", "dir/old": "old", "file": "file"})
+			if err := os.Symlink("a", filepath.Join(path, "src", "link")); err != nil {
+				t.Fatalf("Fatal error - cannot make symbolic link! - %v", err)
+			}
+			if err := os.Symlink("..", filepath.Join(path, "src", "sub", "up")); err != nil {
+				t.Fatalf("Fatal error - cannot make symbolic link! - %v", err)
+			}
+			// src/a is older than file for testing -u option
+			if err := os.Chtimes(filepath.Join(path, "src", "a"), time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+				t.Fatalf("Fatal error - cannot change times! - %v", err)
+			}
+
+			inputR, inputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			inputW.WriteString(test.input)
+			inputW.Close()
+			outputR, outputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+
+			cp := Cp{}
+			errCp := cp.Execute(CommandProperties{path, test.arguments, test.options, inputR, outputW, nil})
+			outputW.Close()
+			if !errors.Is(errCp, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errCp)
+			}
+			output, _ := ioutil.ReadAll(outputR)
+			if expected := strings.Replace(test.output, "{}", path+string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+			for name, content := range test.expected {
+				if result := readTestFile(filepath.Join(path, name)); result != content {
+					t.Errorf("Expected %s to contain %s, but got: %s", name, content, result)
+				}
+			}
+		})
+	}
+}
+
+func TestCpAttributes(t *testing.T) {
+	path, err := ioutil.TempDir("", "cp-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"src/a": "a", "src/exec": "exec"})
+	if err := os.Symlink("a", filepath.Join(path, "src", "link")); err != nil {
+		t.Fatalf("Fatal error - cannot make symbolic link! - %v", err)
+	}
+	if err := os.Chmod(filepath.Join(path, "src", "exec"), 0750); err != nil {
+		t.Fatalf("Fatal error - cannot change mode! - %v", err)
+	}
+	modTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(filepath.Join(path, "src", "a"), modTime, modTime); err != nil {
+		t.Fatalf("Fatal error - cannot change times! - %v", err)
+	}
+
+	cp := Cp{}
+	if err := cp.Execute(newCp(path, []string{"src", "dst"}, []string{"rp"})); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if stat, err := os.Stat(filepath.Join(path, "dst", "exec")); err != nil || stat.Mode().Perm() != 0750 {
+		t.Errorf("Expected dst/exec with mode 0750, but got: %v %v", stat, err)
+	}
+	if stat, err := os.Stat(filepath.Join(path, "dst", "a")); err != nil || !stat.ModTime().Equal(modTime) {
+		t.Errorf("Expected dst/a with modification time %v, but got: %v %v", modTime, stat, err)
+	}
+	if link, err := os.Readlink(filepath.Join(path, "dst", "link")); err != nil || link != "a" {
+		t.Errorf("Expected dst/link to be symbolic link to a, but got: %s %v", link, err)
+	}
+
+	if err := cp.Execute(newCp(path, []string{"src", "deref"}, []string{"r", "L"})); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if stat, err := os.Lstat(filepath.Join(path, "deref", "link")); err != nil || !stat.Mode().IsRegular() {
+		t.Errorf("Expected deref/link to be regular file, but got: %v %v", stat, err)
+	}
+
+	cp.InitStopSignalCatching()
+	cp.SendStopSignal()
+	if err := cp.Execute(newCp(path, []string{"src", "stopped"}, []string{"r"})); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "stopped")); !os.IsNotExist(err) {
+		t.Errorf("Expected stopped copying not to make directory stopped, but got: %v", err)
+	}
+}
+
+func TestCpCopyEngine(t *testing.T) {
+	content := strings.Repeat("0123456789", copyChunkSize/4) // the content is a few chunks long
+	var tests = []struct {
+		target  string // target is the content of the target before the copying
+		options []string
+		output  string // output is expected to be part of the output
+	}{
+		{"", []string{"-progress"}, "(100%)"},
+		{content[:copyChunkSize+5], []string{"-resume", "-checksum"}, ""},
+		{"wrong" + content[:copyChunkSize], []string{"-resume", "-checksum"}, ""},
+		{content + "longer", []string{"-resume"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cp test with options %v", test.options), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "cp-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"source": content})
+			if test.target != "" {
+				makeTestTree(t, path, map[string]string{"target": test.target})
+			}
+
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			cp := Cp{}
+			if err := cp.Execute(CommandProperties{path, []string{"source", "target"}, test.options, os.Stdin, w, nil}); err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+			w.Close()
+			output, _ := ioutil.ReadAll(r)
+			if !strings.Contains(string(output), test.output) {
+				t.Errorf("Expected output containing %s, but got: %s", test.output, output)
+			}
+			if readTestFile(filepath.Join(path, "target")) != content {
+				t.Errorf("Expected target to be the same as source")
+			}
+		})
+	}
+}
+
 func ExampleCp_Execute() {
 	path, _ := os.Getwd()
 	file, _ := os.Create(path + string(os.PathSeparator) + "not-existing-file")
@@ -0,0 +1,284 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrCutNoList indicates that none of the options -b, -c and -f is given to cut command, or more than one of them is given
+	ErrCutNoList = errors.New("you must specify one list of bytes, characters, or fields")
+	// ErrCutInvalidList indicates that the list of bytes, characters or fields of cut command is not valid
+	ErrCutInvalidList = errors.New("invalid list")
+	// ErrCutInvalidDelimiter indicates that the delimiter of cut command is not one character
+	ErrCutInvalidDelimiter = errors.New("the delimiter must be a single character")
+	// ErrCutDelimiterWithoutFields indicates that delimiter is given to cut command without -f
+	ErrCutDelimiterWithoutFields = errors.New("an input delimiter may be specified only when operating on fields")
+)
+
+// Cut is a structure for cut command, implementing ExecuteCommand interface
+type Cut struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// cutRange is range of positions in list of cut command, the positions are counted from 1 and end is 0 when there is no end
+type cutRange struct {
+	start int
+	end   int
+}
+
+// cutList is the sorted list of ranges of cut command, without overlapping ranges
+type cutList []cutRange
+
+// GetName is a getter for command name
+func (c *Cut) GetName() string {
+	return "cut"
+}
+
+// GetPath is a getter for path
+func (c *Cut) GetPath() string {
+	return c.path
+}
+
+// Clone is a method for cloning cut command
+func (c *Cut) Clone() ExecuteCommand {
+	clone := *c
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (c *Cut) InitStopSignalCatching() {
+	c.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (c *Cut) SendStopSignal() {
+	c.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (c *Cut) IsStopSignalReceived() bool {
+	select {
+	case <-c.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of cut command
+//
+// The selected parts of every line of the files in the arguments are written, - is for the input and without arguments the input is read.
+// With -b LIST the bytes are selected, with -c LIST the characters in UTF-8 and with -f LIST the fields, which are separated by tab
+// or by the delimiter given with -d. The list has positions N, ranges N-M, N- and -M, separated by commas.
+// The lines without delimiter are written whole, unless -s is given. With --complement the positions, which are not in the list, are selected.
+// The selected fields are separated by the delimiter and the option --output-delimiter changes it, for bytes and characters it is written between the ranges.
+func (c *Cut) Execute(cp CommandProperties) error {
+	c.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	short, long := "b:c:f:d:sn", []string{"bytes=", "characters=", "fields=", "delimiter=", "only-delimited", "complement", "output-delimiter="}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+	mode, value, delimiter := byte(0), "", "\t"
+	walkOptions(cp, short, long, func(name string, optionValue string) {
+		switch name {
+		case "b", "bytes", "c", "characters", "f", "fields":
+			if mode != 0 && mode != name[0] {
+				mode = '-' // more than one of the lists
+			} else {
+				mode, value = name[0], optionValue
+			}
+		case "d", "delimiter":
+			delimiter = optionValue
+		}
+	})
+	if mode == 0 || mode == '-' {
+		return ErrCutNoList
+	}
+	list, err := parseCutList(value)
+	if err != nil {
+		return err
+	}
+	if has("d", "delimiter") {
+		if mode != 'f' {
+			return ErrCutDelimiterWithoutFields
+		}
+		if utf8.RuneCountInString(delimiter) != 1 {
+			return fmt.Errorf("%s - %w", delimiter, ErrCutInvalidDelimiter)
+		}
+	}
+	outputDelimiter, hasOutputDelimiter := delimiter, options.has("output-delimiter")
+	if hasOutputDelimiter == true {
+		outputDelimiter = options.value("output-delimiter")
+	}
+	if options.has("complement") {
+		list = list.complement()
+	}
+	onlyDelimited := has("s", "only-delimited")
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+
+	out := newStreamWriter(c, outputFile)
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range arguments {
+		file, err := openInputFile(c.path, argument, inputFile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		err = processLines(newStreamReader(c, file), out, func(line string) error {
+			result, ok := "", true
+			switch mode {
+			case 'b':
+				result = list.cutBytes(line, outputDelimiter, hasOutputDelimiter)
+			case 'c':
+				result = list.cutCharacters(line, outputDelimiter, hasOutputDelimiter)
+			default:
+				result, ok = list.cutFields(line, delimiter, outputDelimiter)
+			}
+			if ok == false && onlyDelimited == true {
+				return nil
+			}
+			return out.WriteString(result + "\n")
+		})
+		if file != inputFile {
+			file.Close()
+		}
+		if err == ErrStoppedExec {
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+		}
+	}
+	return out.finish(joinErrors(errs))
+}
+
+// parseCutList function parses list of positions and ranges N, N-M, N- and -M, separated by commas.
+// The result is sorted and the overlapping ranges are joined.
+func parseCutList(value string) (cutList, error) {
+	var list cutList
+	for _, part := range strings.Split(value, ",") {
+		r, err := cutRange{1, 0}, error(nil)
+		bounds := strings.SplitN(part, "-", 2)
+		if bounds[0] != "" {
+			r.start, err = strconv.Atoi(bounds[0])
+		}
+		if len(bounds) == 1 {
+			r.end = r.start
+		} else if bounds[1] != "" && err == nil {
+			r.end, err = strconv.Atoi(bounds[1])
+		}
+		if err != nil || part == "-" || r.start < 1 || (r.end != 0 && r.end < r.start) {
+			return nil, fmt.Errorf("%s - %w", value, ErrCutInvalidList)
+		}
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].start < list[j].start })
+	joined := list[:1]
+	for _, r := range list[1:] {
+		last := &joined[len(joined)-1]
+		switch {
+		case last.end == 0:
+		case r.start <= last.end+1:
+			if r.end == 0 || r.end > last.end {
+				last.end = r.end
+			}
+		default:
+			joined = append(joined, r)
+		}
+	}
+	return joined, nil
+}
+
+// complement is a method returning the list of the positions, which are not in l
+func (l cutList) complement() cutList {
+	var result cutList
+	next := 1 // next is the first position, which is not checked
+	for _, r := range l {
+		if r.start > next {
+			result = append(result, cutRange{next, r.start - 1})
+		}
+		if r.end == 0 {
+			return result
+		}
+		next = r.end + 1
+	}
+	return append(result, cutRange{next, 0})
+}
+
+// selects is a method for checking if position is in the list
+func (l cutList) selects(position int) bool {
+	for _, r := range l {
+		if position >= r.start && (r.end == 0 || position <= r.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// cutParts is a method for joining the selected parts, the parts in different ranges are separated with delimiter, if there is such
+func (l cutList) cutParts(parts []string, delimiter string, hasDelimiter bool) string {
+	var result strings.Builder
+	for ind, r := range l {
+		if r.start > len(parts) {
+			break
+		}
+		end := r.end
+		if end == 0 || end > len(parts) {
+			end = len(parts)
+		}
+		if ind > 0 && hasDelimiter == true {
+			result.WriteString(delimiter)
+		}
+		result.WriteString(strings.Join(parts[r.start-1:end], ""))
+	}
+	return result.String()
+}
+
+// cutBytes is a method for selecting the bytes of line in the list
+func (l cutList) cutBytes(line string, delimiter string, hasDelimiter bool) string {
+	parts := make([]string, len(line))
+	for ind := 0; ind < len(line); ind++ {
+		parts[ind] = line[ind : ind+1]
+	}
+	return l.cutParts(parts, delimiter, hasDelimiter)
+}
+
+// cutCharacters is a method for selecting the characters of line in the list, the line is in UTF-8
+func (l cutList) cutCharacters(line string, delimiter string, hasDelimiter bool) string {
+	parts := make([]string, 0, len(line))
+	for len(line) > 0 {
+		_, size := utf8.DecodeRuneInString(line)
+		parts, line = append(parts, line[:size]), line[size:]
+	}
+	return l.cutParts(parts, delimiter, hasDelimiter)
+}
+
+// cutFields is a method for selecting the fields of line in the list, the result is false if the line does not have the delimiter
+func (l cutList) cutFields(line string, delimiter string, outputDelimiter string) (string, bool) {
+	if strings.Contains(line, delimiter) == false {
+		return line, false
+	}
+	var selected []string
+	for ind, field := range strings.Split(line, delimiter) {
+		if l.selects(ind + 1) {
+			selected = append(selected, field)
+		}
+	}
+	return strings.Join(selected, outputDelimiter), true
+}
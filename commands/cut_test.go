@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// fieldFixtures are the files shared by the tests of cut, tr, paste and column commands.
+// The expected outputs for the files in ASCII are the outputs of GNU coreutils, the file utf8 is for the characters outside ASCII.
+var fieldFixtures = map[string]string{
+	"people.csv": "name,city,age\nIvan,Sofia,31\nMaria,Plovdiv,27\nJohn,,45\n",
+	"table.tsv":  "id\tname\tscore\n1\tana\t9.5\n2\tbob\t7\n3\tcarl\n",
+	"plain":      "no delimiters here\n",
+	"letters":    "Hello, World!\naaa  bbb\t\tccc\n",
+	"numbers":    "1\n2\n3\n4\n5\n",
+	"short":      "a\nb\n",
+	"empty":      "",
+	"utf8":       "ъгъл;ябълка;30\nжълт;синьо;7\n",
+}
+
+// makeFieldFixtures function makes temporary directory with the files in fieldFixtures
+func makeFieldFixtures(t *testing.T) string {
+	path, err := ioutil.TempDir("", "fields-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	makeTestTree(t, path, fieldFixtures)
+	return path
+}
+
+func TestCut(t *testing.T) {
+	path := makeFieldFixtures(t)
+	defer os.RemoveAll(path)
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"-d,", "-f1", "people.csv"}, "name\nIvan\nMaria\nJohn\n", nil},
+		{"", []string{"-d", ",", "-f2,3", "people.csv"}, "city,age\nSofia,31\nPlovdiv,27\n,45\n", nil},
+		{"", []string{"-d,", "-f-2", "people.csv"}, "name,city\nIvan,Sofia\nMaria,Plovdiv\nJohn,\n", nil},
+		{"", []string{"-d,", "-f2-", "people.csv"}, "city,age\nSofia,31\nPlovdiv,27\n,45\n", nil},
+		{"", []string{"-d,", "-f3,1", "people.csv"}, "name,age\nIvan,31\nMaria,27\nJohn,45\n", nil},
+		{"", []string{"-d,", "-f1,3", "--output-delimiter=;", "people.csv"}, "name;age\nIvan;31\nMaria;27\nJohn;45\n", nil},
+		{"", []string{"-d,", "--complement", "-f2", "people.csv"}, "name,age\nIvan,31\nMaria,27\nJohn,45\n", nil},
+		{"", []string{"-f2", "table.tsv"}, "name\nana\nbob\ncarl\n", nil},
+		{"", []string{"-f3", "table.tsv"}, "score\n9.5\n7\n\n", nil},
+		{"", []string{"-f9", "table.tsv"}, "\n\n\n\n", nil},
+		{"", []string{"-f1", "table.tsv", "plain"}, "id\n1\n2\n3\nno delimiters here\n", nil},
+		{"", []string{"-s", "-f1", "table.tsv", "plain"}, "id\n1\n2\n3\n", nil},
+		{"", []string{"-c1-3", "letters"}, "Hel\naaa\n", nil},
+		{"", []string{"-c2,5-", "letters"}, "eo, World!\na bbb\t\tccc\n", nil},
+		{"", []string{"-b-4", "letters"}, "Hell\naaa \n", nil},
+		{"", []string{"-c1-2,4-5", "--output-delimiter=|", "letters"}, "He|lo\naa|  \n", nil},
+		{"", []string{"--complement", "-c2-4", "letters"}, "Ho, World!\na bbb\t\tccc\n", nil},
+		{"", []string{"-c2-3", "utf8"}, "гъ\nъл\n", nil},
+		{"", []string{"-b1-2", "utf8"}, "ъ\nж\n", nil},
+		{"", []string{"-d;", "-f2", "utf8"}, "ябълка\nсиньо\n", nil},
+		{"", []string{"-d", "ъ", "-f2", "utf8"}, "г\nлт;синьо;7\n", nil},
+		{"x:y\n", []string{"-d:", "-f2"}, "y\n", nil},
+		{"x:y\n", []string{"-d:", "-f2", "-", "short"}, "y\na\nb\n", nil},
+		{"", []string{"-f1", "missing", "short"}, "a\nb\n", os.ErrNotExist},
+		{"", []string{"short"}, "", ErrCutNoList},
+		{"", []string{"-c1", "-f1", "short"}, "", ErrCutNoList},
+		{"", []string{"-f0", "short"}, "", ErrCutInvalidList},
+		{"", []string{"-f3-1", "short"}, "", ErrCutInvalidList},
+		{"", []string{"-f", "-", "short"}, "", ErrCutInvalidList},
+		{"", []string{"-d", ",;", "-f1", "short"}, "", ErrCutInvalidDelimiter},
+		{"", []string{"-d", ",", "-c1", "short"}, "", ErrCutDelimiterWithoutFields},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Cut test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Cut{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
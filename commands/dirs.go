@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDirsEmptyStack indicates that there are no other directories in the directory stack
+	ErrDirsEmptyStack = errors.New("directory stack is empty")
+	// ErrDirsInvalidIndex indicates that the index +N or -N is not in the directory stack
+	ErrDirsInvalidIndex = errors.New("directory stack index out of range")
+	// ErrDirsTooManyArgs indicates that dirs, pushd or popd command has more than 1 argument
+	ErrDirsTooManyArgs = errors.New("Too many arguments")
+)
+
+// DirectoryStack is the stack of directories used by pushd, popd and dirs commands.
+// The top of the stack is always the current path, so only the directories below it are stored.
+// The interpreter keeps one stack for all commands, so it is safe for concurrent use.
+type DirectoryStack struct {
+	mutex sync.Mutex
+	dirs  []string
+}
+
+// DirectoryStackUser is interface for commands, which use the directory stack of the interpreter, like pushd
+//
+// The interpreter calls SetDirectoryStack before the execution of such command.
+type DirectoryStackUser interface {
+	SetDirectoryStack(stack *DirectoryStack)
+}
+
+// Clone is a method returning copy of the stack.
+// The interpreter gives copies to the commands in pipe or in background mode, because they cannot change the current path.
+func (s *DirectoryStack) Clone() *DirectoryStack {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return &DirectoryStack{dirs: append([]string(nil), s.dirs...)}
+}
+
+// entries is a method returning all directories in the stack, starting with the current path
+func (s *DirectoryStack) entries(path string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{path}, s.dirs...)
+}
+
+// set is a method for replacing the directories below the current path
+func (s *DirectoryStack) set(dirs []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dirs = dirs
+}
+
+// modify is a method for changing the stack with change, which gets all directories in it, starting with the current path.
+// The directories returned by change become the new stack, with the new current path on the top.
+// The stack is not changed if the new current path is not existing directory, because it may be removed.
+func (s *DirectoryStack) modify(path string, change func(entries []string) ([]string, error)) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entries, err := change(append([]string{path}, s.dirs...))
+	if err != nil {
+		return nil, err
+	}
+	if entries[0] != path {
+		if _, err := changeDirectory(path, entries[0], false, false); err != nil {
+			return nil, err
+		}
+	}
+	s.dirs = entries[1:]
+	return entries, nil
+}
+
+// parseStackWords function splits the words of dirs, pushd or popd command to options and arguments.
+// The words +N and -N are arguments for the indices in the stack and the letters in short are the allowed options.
+func parseStackWords(cp CommandProperties, short string) (string, []string, error) {
+	options := ""
+	var arguments []string
+	words := cp.words()
+	for ind, word := range words {
+		if word == "--" {
+			arguments = append(arguments, words[ind+1:]...)
+			break
+		}
+		if _, ok := parseStackIndex(word); ok == true || len(word) < 2 || word[0] != '-' {
+			arguments = append(arguments, word)
+			continue
+		}
+		for _, char := range word[1:] {
+			if strings.ContainsRune(short, char) == false {
+				return "", nil, fmt.Errorf("-%c - %w", char, ErrInvalidOption)
+			}
+			options += string(char)
+		}
+	}
+	return options, arguments, nil
+}
+
+// parseStackIndex function parses word +N or -N, it returns false if word is not in this format.
+// The index is negative for -N, -1 is for the last directory in the stack.
+func parseStackIndex(word string) (int, bool) {
+	if len(word) < 2 || (word[0] != '+' && word[0] != '-') {
+		return 0, false
+	}
+	index, err := strconv.Atoi(word[1:])
+	if err != nil || index < 0 || strings.IndexFunc(word[1:], func(r rune) bool { return r < '0' || r > '9' }) != -1 {
+		return 0, false
+	}
+	if word[0] == '-' {
+		return -index - 1, true
+	}
+	return index, true
+}
+
+// stackPosition function converts index from parseStackIndex to position in stack with size entries
+func stackPosition(index int, size int, word string) (int, error) {
+	if index < 0 {
+		index += size
+	}
+	if index < 0 || index >= size {
+		return 0, fmt.Errorf("%s - %w", word, ErrDirsInvalidIndex)
+	}
+	return index, nil
+}
+
+// formatStack function formats the entries of the directory stack like dirs command with options, first is the index of the first entry.
+// The home directory is written as ~, unless the options have l.
+func formatStack(entries []string, first int, options string) string {
+	home, _ := os.UserHomeDir()
+	text := ""
+	for ind, entry := range entries {
+		if strings.ContainsRune(options, 'l') == false && home != "" {
+			if entry == home {
+				entry = "~"
+			} else if isSubPath(home, entry) == true {
+				entry = "~" + string(os.PathSeparator) + strings.TrimPrefix(entry, home+string(os.PathSeparator))
+			}
+		}
+		switch {
+		case strings.ContainsRune(options, 'v'):
+			text += fmt.Sprintf("%2d  %s\n", first+ind, entry)
+		case strings.ContainsRune(options, 'p'):
+			text += entry + "\n"
+		case ind+1 < len(entries):
+			text += entry + " "
+		default:
+			text += entry + "\n"
+		}
+	}
+	return text
+}
+
+// Dirs is a structure for dirs command, implementing ExecuteCommand and DirectoryStackUser interfaces
+type Dirs struct {
+	path          string
+	stopExecution chan struct{}
+	stack         *DirectoryStack
+}
+
+// GetName is a getter for command name
+func (d *Dirs) GetName() string {
+	return "dirs"
+}
+
+// GetPath is a getter for path
+func (d *Dirs) GetPath() string {
+	return d.path
+}
+
+// Clone is a method for cloning dirs command
+func (d *Dirs) Clone() ExecuteCommand {
+	clone := *d
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (d *Dirs) InitStopSignalCatching() {
+	d.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (d *Dirs) SendStopSignal() {
+	d.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (d *Dirs) IsStopSignalReceived() bool {
+	select {
+	case <-d.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetDirectoryStack is a method for setting the directory stack, which is written
+func (d *Dirs) SetDirectoryStack(stack *DirectoryStack) {
+	d.stack = stack
+}
+
+// Execute is go implementation of dirs command
+//
+// It writes the directory stack, starting with the current path, on one line.
+// The options are -c for clearing the stack, -l for writing the home directory without ~, -p for writing every directory on separate line
+// and -v for writing every directory on separate line with its index.
+// With argument +N or -N, only the N-th directory from the top or from the bottom of the stack is written.
+func (d *Dirs) Execute(cp CommandProperties) error {
+	d.path = cp.Path
+	_, outputFile := cp.InputFile, cp.OutputFile
+	if d.stack == nil {
+		d.stack = &DirectoryStack{}
+	}
+
+	options, arguments, err := parseStackWords(cp, "clpv")
+	if err != nil {
+		return err
+	}
+	if len(arguments) > 1 {
+		return ErrDirsTooManyArgs
+	}
+	if strings.ContainsRune(options, 'c') {
+		d.stack.set(nil)
+		return nil
+	}
+
+	entries, first := d.stack.entries(filepath.Clean(d.path)), 0
+	if len(arguments) == 1 {
+		index, ok := parseStackIndex(arguments[0])
+		if ok == false {
+			return fmt.Errorf("%s - %w", arguments[0], ErrDirsInvalidIndex)
+		}
+		position, err := stackPosition(index, len(entries), arguments[0])
+		if err != nil {
+			return err
+		}
+		entries, first = entries[position:position+1], position
+	}
+	return checkWrite(d, outputFile, formatStack(entries, first, options))
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirectoryStack(t *testing.T) {
+	path, err := ioutil.TempDir("", "dirs-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		t.Fatalf("Fatal error - cannot evaluate temporary directory! - %v", err)
+	}
+	makeTestTree(t, path, map[string]string{"a/": "", "b/": "", "c/": "", "file": ""})
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", path)
+	defer os.Setenv("CDPATH", os.Getenv("CDPATH"))
+	os.Setenv("CDPATH", "")
+
+	stack := &DirectoryStack{}
+	var tests = []struct {
+		command ExecuteCommand
+		words   []string
+		result  string // result is the path after the command, relative to the temporary directory
+		output  string
+		err     error
+	}{
+		{&Dirs{}, nil, "", "~\n", nil},
+		{&Popd{}, nil, "", "", ErrDirsEmptyStack},
+		{&Pushd{}, nil, "", "", ErrDirsEmptyStack},
+		{&Pushd{}, []string{"a"}, "a", "~/a ~\n", nil},
+		{&Pushd{}, []string{"../b"}, "b", "~/b ~/a ~\n", nil},
+		{&Pushd{}, []string{"-q", "../c"}, "c", "", nil},
+		{&Dirs{}, []string{"-v"}, "c", " 0  ~/c\n 1  ~/b\n 2  ~/a\n 3  ~\n", nil},
+		{&Dirs{}, []string{"-l", "-p"}, "c", path + "/c\n" + path + "/b\n" + path + "/a\n" + path + "\n", nil},
+		{&Dirs{}, []string{"-v", "+2"}, "c", " 2  ~/a\n", nil},
+		{&Dirs{}, []string{"-0"}, "c", "~\n", nil},
+		{&Dirs{}, []string{"+4"}, "c", "", ErrDirsInvalidIndex},
+		{&Pushd{}, nil, "b", "~/b ~/c ~/a ~\n", nil},
+		{&Pushd{}, []string{"+2"}, "a", "~/a ~ ~/b ~/c\n", nil},
+		{&Pushd{}, []string{"-1"}, "b", "~/b ~/c ~/a ~\n", nil},
+		{&Pushd{}, []string{"../file"}, "b", "", ErrCdPathLeadsToFile},
+		{&Popd{}, []string{"+1"}, "b", "~/b ~/a ~\n", nil},
+		{&Popd{}, []string{"-0"}, "b", "~/b ~/a\n", nil},
+		{&Popd{}, []string{"+5"}, "b", "", ErrDirsInvalidIndex},
+		{&Popd{}, []string{"x"}, "b", "", ErrDirsInvalidIndex},
+		{&Popd{}, []string{"-x"}, "b", "", ErrInvalidOption},
+		{&Popd{}, nil, "a", "~/a\n", nil},
+		{&Popd{}, nil, "a", "", ErrDirsEmptyStack},
+		{&Pushd{}, []string{"-q", ".."}, "", "", nil},
+		{&Dirs{}, []string{"-c"}, "", "", nil},
+		{&Dirs{}, nil, "", "~\n", nil},
+	}
+	current := path
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s test with words %v", test.command.GetName(), test.words), func(t *testing.T) {
+			test.command.(DirectoryStackUser).SetDirectoryStack(stack)
+			output, err := runTestCommand(t, test.command, current, test.words)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if err == nil {
+				current = test.command.GetPath()
+			}
+			if expected := filepath.Join(path, filepath.FromSlash(test.result)); current != expected {
+				t.Errorf("Expected path %s, but got: %s", expected, current)
+			}
+			if expected := filepath.FromSlash(test.output); output != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+		})
+	}
+}
+
+func TestDirectoryStackRemovedDirectory(t *testing.T) {
+	path, err := ioutil.TempDir("", "dirs-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a/": ""})
+
+	stack := &DirectoryStack{}
+	pushd := &Pushd{stack: stack}
+	if _, err := runTestCommand(t, pushd, path, []string{"-q", "a"}); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	os.Remove(filepath.Join(path, "a"))
+	popd := &Popd{stack: stack}
+	if _, err := runTestCommand(t, popd, filepath.Join(path, "a"), []string{"-q", "+1"}); err != nil {
+		t.Errorf("Expected no error for removing other directory, but got: %v", err)
+	}
+	if _, err := runTestCommand(t, pushd, path, []string{"-q", "a"}); !errors.Is(err, ErrCdPathNotExist) {
+		t.Errorf("Expected error %v, but got: %v", ErrCdPathNotExist, err)
+	}
+	if result := fmt.Sprint(stack.entries(path)); result != fmt.Sprint([]string{path}) {
+		t.Errorf("Expected the stack not to be changed, but got: %s", result)
+	}
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrDuInvalidDepth indicates that the value of -d or --max-depth option is not a non-negative number
+	ErrDuInvalidDepth = errors.New("invalid maximum depth")
+)
+
+// Du is a structure for du command, implementing ExecuteCommand interface
+type Du struct {
+	path          string
+	stopExecution chan struct{}
+	out           *streamWriter
+	human         bool // human is for writing the sizes like 1.5K and 20M, instead of in blocks of 1024 bytes
+	apparentSize  bool // apparentSize is for using the sizes of the files, instead of the disk usage
+}
+
+// GetName is a getter for command name
+func (d *Du) GetName() string {
+	return "du"
+}
+
+// GetPath is a getter for path
+func (d *Du) GetPath() string {
+	return d.path
+}
+
+// Clone is a method for cloning du command
+func (d *Du) Clone() ExecuteCommand {
+	clone := *d
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (d *Du) InitStopSignalCatching() {
+	d.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (d *Du) SendStopSignal() {
+	d.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (d *Du) IsStopSignalReceived() bool {
+	select {
+	case <-d.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of du command
+//
+// Like in du, the disk usage of every directory is written after the ones of the directories in it.
+// With -a the usage of every file is written too, with -s only the total usage of every argument.
+// With --gitignore, the files ignored by .gitignore files and the .git directories are skipped.
+func (d *Du) Execute(cp CommandProperties) error {
+	d.path = cp.Path
+	d.out = newStreamWriter(d, cp.OutputFile)
+
+	options, arguments, err := parseOptions(cp, "ashcd:",
+		[]string{"all", "summarize", "human-readable", "total", "max-depth=", "apparent-size", "gitignore"})
+	if err != nil {
+		return err
+	}
+	all := options.has("a") || options.has("all")
+	summarize := options.has("s") || options.has("summarize")
+	d.human = options.has("h") || options.has("human-readable")
+	d.apparentSize = options.has("apparent-size")
+	maxDepth := -1
+	for _, name := range []string{"d", "max-depth"} {
+		if options.has(name) {
+			if maxDepth, err = strconv.Atoi(options.value(name)); err != nil || maxDepth < 0 {
+				return fmt.Errorf("%s - %w", options.value(name), ErrDuInvalidDepth)
+			}
+		}
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"."}
+	}
+
+	var errs []error // in slice errs we collect all the errors
+	var total int64
+	for _, argument := range arguments {
+		w := walker{command: d, gitignore: options.has("gitignore")}
+		var usage int64
+		if summarize == true {
+			usage, err = d.summarize(&w, FullFileName(d.path, argument), &errs)
+		} else {
+			usage, err = d.walk(&w, FullFileName(d.path, argument), argument, all, maxDepth, &errs)
+		}
+		if err == ErrStoppedExec {
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if summarize == true {
+			if err := d.outputUsage(usage, argument); err != nil {
+				return d.out.finish(joinErrors(append(errs, err)))
+			}
+		}
+		total += usage
+	}
+
+	if options.has("c") || options.has("total") {
+		if err := d.outputUsage(total, "total"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return d.out.finish(joinErrors(errs))
+}
+
+// walk is a method for writing the usage of the directories in root and of the files in it, if all is true.
+// Only the files with depth not more than maxDepth are written, if it is not negative.
+func (d *Du) walk(w *walker, root string, start string, all bool, maxDepth int, errs *[]error) (int64, error) {
+	var sums []int64 // sums are the usages of the walked directories
+	var total int64
+	add := func(usage int64) {
+		if len(sums) > 0 {
+			sums[len(sums)-1] += usage
+		} else {
+			total += usage
+		}
+	}
+
+	w.sorted = true
+	w.visit = func(entry *walkEntry, err error) error {
+		if err != nil {
+			*errs = append(*errs, err)
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			*errs = append(*errs, err)
+			if entry.isDir { // the directory has no usage in sums, so it is not walked and left
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.isDir {
+			sums = append(sums, d.usage(info))
+			return nil
+		}
+		add(d.usage(info))
+		if (all == true || entry.depth == 0) && (maxDepth < 0 || entry.depth <= maxDepth) {
+			return d.outputUsage(d.usage(info), joinFindPath(start, entry.relative))
+		}
+		return nil
+	}
+	w.leave = func(entry *walkEntry) error {
+		usage := sums[len(sums)-1]
+		sums = sums[:len(sums)-1]
+		add(usage)
+		if maxDepth < 0 || entry.depth <= maxDepth {
+			return d.outputUsage(usage, joinFindPath(start, entry.relative))
+		}
+		return nil
+	}
+	err := w.walk(root)
+	return total, err
+}
+
+// summarize is a method for computing the total usage of root, the files in it are visited concurrently
+func (d *Du) summarize(w *walker, root string, errs *[]error) (int64, error) {
+	var total int64
+	var mutex sync.Mutex // mutex is for collecting the errors from the concurrent visits
+	w.visit = func(entry *walkEntry, err error) error {
+		if err == nil {
+			var info os.FileInfo
+			if info, err = entry.Info(); err == nil {
+				atomic.AddInt64(&total, d.usage(info))
+				return nil
+			}
+		}
+		mutex.Lock()
+		*errs = append(*errs, err)
+		mutex.Unlock()
+		return nil
+	}
+	err := w.walk(root)
+	return total, err
+}
+
+// usage is a method returning the disk usage of file with information info or its size with --apparent-size
+func (d *Du) usage(info os.FileInfo) int64 {
+	if d.apparentSize == true {
+		return info.Size()
+	}
+	return diskUsage(info)
+}
+
+// outputUsage is a method for writing the usage of file with name
+func (d *Du) outputUsage(usage int64, name string) error {
+	size := strconv.FormatInt((usage+1023)/1024, 10)
+	if d.human == true {
+		size = formatHumanSize(usage)
+	}
+	return d.out.WriteString(size + "\t" + name + "\n")
+}
+
+// formatHumanSize function formats size like du -h - the size is rounded up and it has one digit after the decimal point, if it is less than 10
+func formatHumanSize(size int64) string {
+	if size < 1024 {
+		return strconv.FormatInt(size, 10)
+	}
+	value := float64(size)
+	var unit byte
+	for _, unit = range []byte("KMGTPE") {
+		value /= 1024
+		if math.Ceil(value) < 1024 {
+			break
+		}
+	}
+	if value < 10 && math.Ceil(value*10) < 100 {
+		return strconv.FormatFloat(math.Ceil(value*10)/10, 'f', 1, 64) + string(unit)
+	}
+	return strconv.FormatFloat(math.Ceil(value), 'f', 0, 64) + string(unit)
+}
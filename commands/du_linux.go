@@ -0,0 +1,18 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// diskUsage function is helper for getting the number of bytes, which file uses on the disk
+func diskUsage(info os.FileInfo) int64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if ok == false {
+		return info.Size()
+	}
+	return stat.Blocks * 512
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+import "os"
+
+// diskUsage function is helper for getting the number of bytes, which file uses on the disk.
+// This information is available only on Linux, so the size of the file is used.
+func diskUsage(info os.FileInfo) int64 {
+	return info.Size()
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDu(t *testing.T) {
+	path, err := ioutil.TempDir("", "du-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{
+		"dir/a": strings.Repeat("a", 1000), "dir/sub/b": strings.Repeat("b", 3000), "dir/sub/c.log": "c", "dir/.gitignore": "*.log\n", "file": "f",
+	})
+	// the sizes of the directories depend on the file system, so the expected sizes are computed
+	size := func(names ...string) string {
+		var total int64
+		for _, name := range names {
+			info, err := os.Lstat(filepath.Join(path, name))
+			if err != nil {
+				t.Fatalf("Fatal error - cannot get information for %s! - %v", name, err)
+			}
+			total += info.Size()
+		}
+		return strconv.FormatInt((total+1023)/1024, 10)
+	}
+	sub := []string{"dir/sub", "dir/sub/b", "dir/sub/c.log"}
+	dir := append([]string{"dir", "dir/a", "dir/.gitignore"}, sub...)
+
+	var tests = []struct {
+		words  []string
+		output string
+		err    error
+	}{
+		{[]string{"--apparent-size", "dir/sub"}, size(sub...) + "\tdir/sub\n", nil},
+		{[]string{"--apparent-size", "-a", "dir"}, "1\tdir/.gitignore\n1\tdir/a\n3\tdir/sub/b\n1\tdir/sub/c.log\n" +
+			size(sub...) + "\tdir/sub\n" + size(dir...) + "\tdir\n", nil},
+		{[]string{"--apparent-size", "-d", "0", "dir"}, size(dir...) + "\tdir\n", nil},
+		{[]string{"--apparent-size", "--max-depth=1", "dir", "file"}, size(sub...) + "\tdir/sub\n" + size(dir...) + "\tdir\n1\tfile\n", nil},
+		{[]string{"--apparent-size", "-s", "dir", "file", "-c"}, size(dir...) + "\tdir\n1\tfile\n" + size(append(dir, "file")...) + "\ttotal\n", nil},
+		{[]string{"--apparent-size", "-s", "dir", "--gitignore"}, size(dir[:len(dir)-1]...) + "\tdir\n", nil},
+		{[]string{"--apparent-size", "-d", "x"}, "", ErrDuInvalidDepth},
+		{[]string{"--apparent-size", "missing", "file"}, "1\tfile\n", os.ErrNotExist},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Du test with words %v", test.words), func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			du := Du{}
+			du.InitStopSignalCatching()
+			errDu := du.Execute(CommandProperties{path, nil, nil, os.Stdin, w, test.words})
+			w.Close()
+			if !errors.Is(errDu, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errDu)
+			}
+			output, _ := ioutil.ReadAll(r)
+			if expected := strings.Replace(test.output, "/", string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+		})
+	}
+}
+
+func TestDuRemovedDirectory(t *testing.T) {
+	path, err := ioutil.TempDir("", "du-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a/b": "b", "c": "c"})
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+
+	// the directory a is removed after it is read, so its information cannot be read when it is visited
+	du := &Du{apparentSize: true}
+	du.InitStopSignalCatching()
+	du.out = newStreamWriter(du, w)
+	walk := &walker{command: du}
+	walk.list = func(dir *walkEntry, entries []*walkEntry) error {
+		if dir.relative == "" {
+			return os.RemoveAll(filepath.Join(path, "a"))
+		}
+		return nil
+	}
+	var errs []error
+	if _, err := du.walk(walk, path, ".", true, -1, &errs); err != nil {
+		t.Errorf("Expected no error from the walk, but got: %v", err)
+	}
+	if err := joinErrors(errs); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected error %v for the removed directory, but got: %v", os.ErrNotExist, err)
+	}
+	du.out.finish(nil)
+	w.Close()
+	output, _ := ioutil.ReadAll(r)
+	if lines := strings.Split(string(output), "\n"); len(lines) != 3 || strings.HasPrefix(lines[0], "1\t") == false || strings.HasSuffix(lines[1], "\t.") == false {
+		t.Errorf("Expected the usages of c and of the start without a, but got: %q", output)
+	}
+}
+
+func TestFormatHumanSize(t *testing.T) {
+	var tests = []struct {
+		size   int64
+		result string
+	}{
+		{0, "0"},
+		{1023, "1023"},
+		{1024, "1.0K"},
+		{1536, "1.5K"},
+		{1537, "1.6K"},
+		{10 * 1024, "10K"},
+		{10*1024 + 1, "11K"},
+		{1023 * 1024, "1023K"},
+		{1024*1024 - 1, "1.0M"},
+		{5 << 30, "5.0G"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("formatHumanSize(%d)", test.size), func(t *testing.T) {
+			if result := formatHumanSize(test.size); result != test.result {
+				t.Errorf("Expected %s, but got: %s", test.result, result)
+			}
+		})
+	}
+}
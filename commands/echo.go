@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The sets of escapes, which are expanded by expandEscapes
+const (
+	echoEscapes     = iota // echoEscapes are the escapes of echo -e, where octal numbers are \0NNN
+	formatEscapes          // formatEscapes are the escapes of the format of printf, where octal numbers are \NNN, \" is " and \c is not special
+	argumentEscapes        // argumentEscapes are the escapes of the arguments of %b in printf, where octal numbers are \0NNN and \NNN
+)
+
+// Echo is a structure for echo command, implementing ExecuteCommand interface
+type Echo struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// GetName is a getter for command name
+func (e *Echo) GetName() string {
+	return "echo"
+}
+
+// GetPath is a getter for path
+func (e *Echo) GetPath() string {
+	return e.path
+}
+
+// Clone is a method for cloning echo command
+func (e *Echo) Clone() ExecuteCommand {
+	clone := *e
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (e *Echo) InitStopSignalCatching() {
+	e.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (e *Echo) SendStopSignal() {
+	e.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (e *Echo) IsStopSignalReceived() bool {
+	select {
+	case <-e.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of echo command
+//
+// The arguments are written separated with spaces and followed by new line, which is not written with -n.
+// With -e the escapes like \n, \t, \\, \0NNN, \xHH and \uHHHH are expanded and \c stops the output, -E turns them off.
+// Like in bash, only the first words with these options are options, all other words like -x and -- are written.
+func (e *Echo) Execute(cp CommandProperties) error {
+	e.path = cp.Path
+	outputFile := cp.OutputFile
+
+	words := cp.words()
+	newline, escapes := true, false
+	for len(words) > 0 && len(words[0]) > 1 && words[0][0] == '-' && strings.Trim(words[0][1:], "neE") == "" {
+		for _, option := range words[0][1:] {
+			switch option {
+			case 'n':
+				newline = false
+			case 'e':
+				escapes = true
+			case 'E':
+				escapes = false
+			}
+		}
+		words = words[1:]
+	}
+
+	text := strings.Join(words, " ")
+	if escapes == true {
+		var stopped bool
+		if text, stopped = expandEscapes(text, echoEscapes); stopped == true {
+			newline = false
+		}
+	}
+	if newline == true {
+		text += "\n"
+	}
+	return checkWrite(e, outputFile, text)
+}
+
+// expandEscapes function expands the escapes in text, which are in the set escapes.
+// The result is true when \c stops the output, then the text after it is not in the result.
+func expandEscapes(text string, escapes int) (string, bool) {
+	var result strings.Builder
+	for ind := 0; ind < len(text); ind++ {
+		if text[ind] != '\\' || ind+1 == len(text) {
+			result.WriteByte(text[ind])
+			continue
+		}
+		ind++
+		// number reads at most size digits of base from position ind, it returns the number and the number of digits
+		number := func(base int, size int) (int64, int) {
+			end := ind
+			for end < len(text) && end < ind+size && isDigit(text[end], base) {
+				end++
+			}
+			value, _ := strconv.ParseInt(text[ind:end], base, 64)
+			return value, end - ind
+		}
+		switch char := text[ind]; {
+		case char == 'c' && escapes != formatEscapes:
+			return result.String(), true
+		case char == '0' && escapes != formatEscapes:
+			ind++
+			value, digits := number(8, 3)
+			result.WriteByte(byte(value))
+			ind += digits - 1
+		case char >= '0' && char <= '7' && escapes != echoEscapes:
+			value, digits := number(8, 3)
+			result.WriteByte(byte(value))
+			ind += digits - 1
+		case char == 'x' || char == 'u' || char == 'U':
+			ind++
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[char]
+			value, digits := number(16, size)
+			switch {
+			case digits == 0: // like in bash, the escape without digits is not expanded
+				result.WriteString(text[ind-2 : ind])
+			case char == 'x':
+				result.WriteByte(byte(value))
+			default:
+				result.WriteRune(rune(value))
+			}
+			ind += digits - 1
+		case char == '"' && escapes == formatEscapes:
+			result.WriteByte(char)
+		case strings.IndexByte(`abefnrtv\`, char) != -1:
+			result.WriteByte("\a\b\x1b\f\n\r\t\v\\"[strings.IndexByte(`abefnrtv\`, char)])
+		default: // the other escapes are written unchanged
+			result.WriteString(text[ind-1 : ind+1])
+		}
+	}
+	return result.String(), false
+}
+
+// isDigit function checks if char is digit in base, which is 8, 10 or 16
+func isDigit(char byte, base int) bool {
+	switch base {
+	case 8:
+		return char >= '0' && char <= '7'
+	case 10:
+		return char >= '0' && char <= '9'
+	}
+	return strings.IndexByte("0123456789abcdefABCDEF", char) != -1
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestEcho(t *testing.T) {
+	var tests = []struct {
+		words  []string
+		output string
+	}{
+		{[]string{}, "\n"},
+		{[]string{"a", "b  c"}, "a b  c\n"},
+		{[]string{"-n", "a", "b"}, "a b"},
+		{[]string{"a", "-n"}, "a -n\n"},
+		{[]string{"-x", "a"}, "-x a\n"},
+		{[]string{"-nx"}, "-nx\n"},
+		{[]string{"--", "a"}, "-- a\n"},
+		{[]string{"-", "a"}, "- a\n"},
+		{[]string{`a\tb`}, "a\\tb\n"},
+		{[]string{"-e", `a\tb\cz`, "x"}, "a\tb"},
+		{[]string{"-ne", `q\n`}, "q\n"},
+		{[]string{"-e", "-n", `x\ny`}, "x\ny"},
+		{[]string{"-eE", `a\tb`}, "a\\tb\n"},
+		{[]string{"-en"}, ""},
+		{[]string{"-e", `\x41\0101\101|\z|\`}, "AA\\101|\\z|\\\n"},
+		{[]string{"-e", `\x`, `\0`, `\08`, `a\\b`}, "\\x \x00 \x008 a\\b\n"},
+		{[]string{"-e", `ъ\U0001F600\e[0m`}, "ъ😀\x1b[0m\n"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Echo test with words %q", test.words), func(t *testing.T) {
+			output, err := runTestCommand(t, &Echo{}, os.TempDir(), test.words)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
@@ -9,15 +9,36 @@ import (
 
 var (
 	// ErrFindNoArgs indicates that there were no arguments passed to find command
+	//
+	// Deprecated: like in find, the search starts from the current path, if there are no arguments.
 	ErrFindNoArgs = errors.New("At least one argument is needed")
 	// ErrFindFound indicates that the file was found in walk
+	//
+	// Deprecated: the search does not stop at the first found file anymore.
 	ErrFindFound = errors.New("found")
+	// ErrFindUnknownPredicate indicates that the expression has unknown test or action
+	ErrFindUnknownPredicate = errors.New("unknown predicate")
+	// ErrFindInvalidValue indicates that the value of test or action is not valid
+	ErrFindInvalidValue = errors.New("invalid value")
+	// ErrFindParentheses indicates that the parentheses in the expression are not balanced
+	ErrFindParentheses = errors.New("unbalanced parentheses")
+	// ErrFindMissingExpression indicates that operator is missing its expression
+	ErrFindMissingExpression = errors.New("expected expression")
+	// ErrFindExecEnd indicates that the command of -exec or -execdir does not end with ; or {} +
+	ErrFindExecEnd = errors.New("missing ; or {} + at the end of the command")
 )
 
-// Find is a structure for pwd command, implementing ExecuteCommand interface
+// Find is a structure for find command, implementing ExecuteCommand interface
 type Find struct {
 	path          string
 	stopExecution chan struct{}
+	lookup        CommandLookup // lookup is used for running the registered commands with -exec
+	inputFile     *os.File      // inputFile is the input of the commands run with -exec
+	outputFile    *os.File      // outputFile is the output of the commands run with -exec
+	out           *streamWriter // out is the buffered output of find, which is flushed before running commands with -exec
+	errStop       error         // errStop is the error, which stops the search, like the error from writing the output
+	errs          []error       // errs collects the errors, which do not stop the search, like the errors from -delete
+	batches       []*findBatch  // batches are the files collected for the commands of -exec ... {} +
 }
 
 // GetName is a getter for command name
@@ -59,53 +80,109 @@ func (f *Find) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of find command
+//
+// The arguments are start paths, followed by expression of tests like -name, -type and -size, combined with !, -a, -o and parentheses.
+// If there are no start paths, the search starts from the current path.
+// The expression can have actions like -exec, -delete and -printf, if it has no actions, every matched file is written on separate line.
 func (f *Find) Execute(cp CommandProperties) error {
 	f.path = cp.Path
-	_, outputFile := cp.InputFile, cp.OutputFile
+	f.inputFile, f.outputFile = cp.InputFile, cp.OutputFile
+	f.out = newStreamWriter(f, f.outputFile)
+	f.errStop, f.errs, f.batches = nil, nil, nil
 
-	if len(cp.Arguments) == 0 {
-		return ErrFindNoArgs
+	err := f.search(cp.words())
+	if f.errStop == ErrStoppedExec {
+		return err
 	}
+	return f.out.finish(err)
+}
 
-	var errStrings []string
-	returnFunc := func() error {
-		if len(errStrings) == 0 {
-			return nil
+// search is a method for parsing the start paths and the expression in words and evaluating the expression for the files in the start paths
+func (f *Find) search(words []string) error {
+	var starts []string
+	for len(words) > 0 && !isFindExpressionStart(words[0]) {
+		starts, words = append(starts, words[0]), words[1:]
+	}
+	if len(starts) == 0 {
+		starts = []string{"."}
+	}
+
+	p, expr, err := parseFindExpression(words, f)
+	if err != nil {
+		return err
+	}
+	evaluate := func(file *findFile) {
+		if file.depth >= p.minDepth {
+			expr(file)
 		}
-		return errors.New(strings.Join(errStrings, "\n"))
 	}
 
-	for _, argument := range cp.Arguments {
+	for _, start := range starts {
 		if f.IsStopSignalReceived() == true {
-			return returnFunc()
+			f.errStop = ErrStoppedExec
+			return joinErrors(f.errs)
 		}
 
-		err := filepath.Walk(f.path, func(path string, info os.FileInfo, err error) error {
-			if f.IsStopSignalReceived() == true {
-				return ErrStoppedExec
-			}
+		newFile := func(entry *walkEntry) *findFile {
+			return &findFile{path: joinFindPath(start, entry.relative), name: entry.name, entry: entry, depth: entry.depth}
+		}
+		w := walker{command: f, sorted: true, maxDepth: p.maxDepth}
+		w.visit = func(entry *walkEntry, err error) error {
 			if err != nil {
-				return err
+				f.errs = append(f.errs, err)
+				return nil
+			}
+			file := newFile(entry)
+			maxDepth := entry.isDir && p.maxDepth >= 0 && entry.depth >= p.maxDepth
+			if p.depthFirst == false || entry.isDir == false || maxDepth == true {
+				evaluate(file)
 			}
-			s := strings.Split(path, string(os.PathSeparator)) // we split the path so we can only check the short name
-			if len(s) > 0 && s[len(s)-1] == argument {
-				if err := checkWrite(f, outputFile, argument+" found - "+path+"\n"); err != nil {
-					return err
-				}
-				return ErrFindFound
+			if f.errStop != nil {
+				return f.errStop
+			}
+			if maxDepth == true || (file.prune == true && p.depthFirst == false) {
+				return filepath.SkipDir
 			}
 			return nil
-		})
-		if err == nil {
-			if err := checkWrite(f, outputFile, argument+" not found\n"); err != nil {
-				return err
+		}
+		if p.depthFirst == true { // in depth-first order, the directories are evaluated after the files in them
+			w.leave = func(entry *walkEntry) error {
+				evaluate(newFile(entry))
+				return f.errStop
 			}
-		} else if err == ErrStoppedExec {
-			return returnFunc()
-		} else if err != ErrFindFound {
-			errStrings = append(errStrings, err.Error())
+		}
+
+		err := w.walk(filepath.Clean(FullFileName(f.path, start)))
+		if err == ErrStoppedExec {
+			f.errStop = err
+			return joinErrors(f.errs)
+		}
+		if err != nil {
+			f.errs = append(f.errs, err)
+			return joinErrors(f.errs)
 		}
 	}
 
-	return returnFunc()
+	for _, batch := range f.batches {
+		if f.flush(batch); f.errStop == ErrStoppedExec {
+			break
+		}
+	}
+	return joinErrors(f.errs)
+}
+
+// isFindExpressionStart function checks if word is the start of find expression and not a start path
+func isFindExpressionStart(word string) bool {
+	return word == "!" || word == "(" || (len(word) > 1 && word[0] == '-')
+}
+
+// joinFindPath function joins the start path, as it is written, with the relative name of file in it
+func joinFindPath(start string, relative string) string {
+	if relative == "" {
+		return start
+	}
+	if strings.HasSuffix(start, string(os.PathSeparator)) {
+		return start + relative
+	}
+	return start + string(os.PathSeparator) + relative
 }
@@ -0,0 +1,304 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// findBatchSize is the maximum number of files given to one command of -exec ... {} +
+const findBatchSize = 1024
+
+// findBatch stores the files collected for the command of -exec ... {} + or -execdir ... {} +
+type findBatch struct {
+	words []string // words are the command and its arguments before {}
+	dir   string   // dir is the directory, in which the command is run
+	names []string
+}
+
+// SetCommandLookup is a method for setting the function, which is used for running the registered commands with -exec
+func (f *Find) SetCommandLookup(lookup CommandLookup) {
+	f.lookup = lookup
+}
+
+// parseAction is a method for parsing the actions of find, it returns false if name is not an action
+func (p *findParser) parseAction(name string) (findExpr, bool, error) {
+	switch name {
+	case "-print", "-print0", "-delete", "-printf", "-exec", "-execdir":
+		p.hasAction = true
+	case "-depth":
+		p.depthFirst = true
+		return func(file *findFile) bool { return true }, true, nil
+	default:
+		return nil, false, nil
+	}
+
+	f := p.f
+	switch name {
+	case "-print":
+		return f.print, true, nil
+	case "-print0":
+		return func(file *findFile) bool { return f.write(file.path + "\x00") }, true, nil
+	case "-delete":
+		p.depthFirst = true // like in find, the files in directory are deleted before it
+		return func(file *findFile) bool {
+			if baseName(file.path) == "." {
+				return true
+			}
+			if err := os.Remove(file.name); err != nil {
+				f.errs = append(f.errs, err)
+				return false
+			}
+			return true
+		}, true, nil
+	case "-printf":
+		format, err := p.next(name)
+		if err != nil {
+			return nil, true, err
+		}
+		return func(file *findFile) bool { return f.write(formatFindPrintf(format, file)) }, true, nil
+	}
+
+	// the command of -exec ends with ; or with {} +
+	start := p.position
+	for p.position < len(p.words) {
+		word := p.words[p.position]
+		p.position++
+		if (word == ";" || word == `\;`) && p.position-start > 1 {
+			return f.execEach(p.words[start:p.position-1], name == "-execdir"), true, nil
+		}
+		if word == "+" && p.position-start > 2 && p.words[p.position-2] == "{}" {
+			return f.execBatch(p.words[start:p.position-2], name == "-execdir"), true, nil
+		}
+	}
+	return nil, true, fmt.Errorf("%s - %w", name, ErrFindExecEnd)
+}
+
+// print is a method for writing the path of file on separate line
+func (f *Find) print(file *findFile) bool {
+	return f.write(file.path + "\n")
+}
+
+// write is a method for writing text to the output, the error stops the search
+func (f *Find) write(text string) bool {
+	if f.errStop == nil {
+		f.errStop = f.out.WriteString(text)
+	}
+	return f.errStop == nil
+}
+
+// execArgument is a method returning the directory, in which the command for file is run, and the name, which replaces {}.
+// For -execdir, the directory is the one of the file.
+func (f *Find) execArgument(file *findFile, execDir bool) (string, string) {
+	if execDir == true {
+		return filepath.Dir(file.name), "." + string(os.PathSeparator) + filepath.Base(file.name)
+	}
+	return f.path, file.path
+}
+
+// execEach is a method returning the action of -exec ... ;, which runs the command for every file
+func (f *Find) execEach(words []string, execDir bool) findExpr {
+	return func(file *findFile) bool {
+		dir, name := f.execArgument(file, execDir)
+		command := make([]string, len(words))
+		for i, word := range words {
+			command[i] = strings.Replace(word, "{}", name, -1)
+		}
+		return f.run(dir, command)
+	}
+}
+
+// execBatch is a method returning the action of -exec ... {} +, which collects the files and runs the command for many of them at once.
+// For -execdir, the command is run when the directory changes.
+func (f *Find) execBatch(words []string, execDir bool) findExpr {
+	batch := &findBatch{words: words}
+	f.batches = append(f.batches, batch)
+	return func(file *findFile) bool {
+		dir, name := f.execArgument(file, execDir)
+		if dir != batch.dir {
+			f.flush(batch)
+			batch.dir = dir
+		}
+		batch.names = append(batch.names, name)
+		if len(batch.names) >= findBatchSize {
+			f.flush(batch)
+		}
+		return true
+	}
+}
+
+// flush is a method for running the command of batch for the collected files
+func (f *Find) flush(batch *findBatch) {
+	if len(batch.names) == 0 || f.errStop != nil {
+		return
+	}
+	command := append(append([]string{}, batch.words...), batch.names...)
+	batch.names = nil
+	f.run(batch.dir, command)
+}
+
+// run is a method for running command in dir, it returns if the command was successful.
+// The errors of the registered commands are collected, the external programs write their errors themselves.
+func (f *Find) run(dir string, command []string) bool {
+	if f.errStop = f.out.flush(); f.errStop != nil { // the output of find is written before the output of the command
+		return false
+	}
+	err := f.runCommand(dir, command)
+	if err == ErrStoppedExec {
+		f.errStop = err
+		return false
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) && !IsSilentError(err) {
+		f.errs = append(f.errs, fmt.Errorf("%s - %w", command[0], err))
+	}
+	return err == nil
+}
+
+// runCommand is a method for running the registered command or the external program with name command[0].
+// When stop signal is received, it is sent to the registered command or the external program is killed.
+func (f *Find) runCommand(dir string, command []string) error {
+	if f.lookup != nil {
+		if registered := f.lookup(command[0]); registered != nil {
+			registered.InitStopSignalCatching()
+			result := make(chan error, 1)
+			go func() {
+				result <- registered.Execute(wordsToCp(dir, command[1:], f.inputFile, f.outputFile))
+			}()
+			select {
+			case err := <-result:
+				return err
+			case <-f.stopExecution:
+				registered.SendStopSignal()
+				<-result
+				return ErrStoppedExec
+			}
+		}
+	}
+
+	program := exec.Command(command[0], command[1:]...)
+	program.Dir, program.Stdin, program.Stdout, program.Stderr = dir, f.inputFile, f.outputFile, os.Stderr
+	if err := program.Start(); err != nil {
+		return err
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- program.Wait()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-f.stopExecution:
+		program.Process.Kill()
+		<-result
+		return ErrStoppedExec
+	}
+}
+
+// formatFindPrintf function formats the information for file like -printf of find.
+//
+// The directives are %p (path), %f (name), %h (directory), %s (size), %m (octal permissions), %M (symbolic permissions),
+// %d (depth), %y (type), %l (target of symbolic link), %t (modification time), %Tk (field k of modification time) and %%.
+// The escapes are \n, \t, \r, \0 and \\.
+func formatFindPrintf(format string, file *findFile) string {
+	var output strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c == '\\' && i+1 < len(format) {
+			i++
+			escapes := map[byte]string{'n': "\n", 't': "\t", 'r': "\r", '0': "\x00", '\\': `\`}
+			if escape, ok := escapes[format[i]]; ok == true {
+				output.WriteString(escape)
+			} else {
+				output.WriteString(format[i-1 : i+1])
+			}
+			continue
+		}
+		if c != '%' || i+1 == len(format) {
+			output.WriteByte(c)
+			continue
+		}
+
+		i++
+		mode := file.info().Mode()
+		switch format[i] {
+		case 'p':
+			output.WriteString(file.path)
+		case 'f':
+			output.WriteString(baseName(file.path))
+		case 'h':
+			trimmed := strings.TrimRight(file.path, string(os.PathSeparator))
+			if index := strings.LastIndex(trimmed, string(os.PathSeparator)); index >= 0 {
+				output.WriteString(trimmed[:index])
+			} else {
+				output.WriteString(".")
+			}
+		case 's':
+			output.WriteString(strconv.FormatInt(file.info().Size(), 10))
+		case 'm':
+			output.WriteString(strconv.FormatUint(uint64(fileModeToUnix(mode)), 8))
+		case 'M':
+			output.WriteString(fileModeString(mode))
+		case 'd':
+			output.WriteString(strconv.Itoa(file.depth))
+		case 'y':
+			output.WriteString(findTypeLetter(mode))
+		case 'l':
+			if mode&os.ModeSymlink != 0 {
+				target, _ := os.Readlink(file.name)
+				output.WriteString(target)
+			}
+		case 't':
+			output.WriteString(file.info().ModTime().Format("Mon Jan _2 15:04:05 2006"))
+		case 'T':
+			if i+1 < len(format) {
+				i++
+				output.WriteString(formatFindTime(file, format[i]))
+			} else {
+				output.WriteString("%T")
+			}
+		case '%':
+			output.WriteByte('%')
+		default:
+			output.WriteString(format[i-1 : i+1])
+		}
+	}
+	return output.String()
+}
+
+// formatFindTime function formats field of the modification time of file, like %T of -printf
+func formatFindTime(file *findFile, field byte) string {
+	modTime := file.info().ModTime()
+	layouts := map[byte]string{
+		'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'H': "15", 'M': "04", 'S': "05", 'b': "Jan", 'a': "Mon",
+		'T': "15:04:05", 'F': "2006-01-02", 'D': "01/02/06", '+': "2006-01-02+15:04:05",
+	}
+	if layout, ok := layouts[field]; ok == true {
+		return modTime.Format(layout)
+	}
+	if field == '@' {
+		return fmt.Sprintf("%d.%09d", modTime.Unix(), modTime.Nanosecond())
+	}
+	return "%T" + string(field)
+}
+
+// findTypeLetter function returns the letter of the type of file, like in -type of find
+func findTypeLetter(mode os.FileMode) string {
+	switch {
+	case mode.IsRegular():
+		return "f"
+	case mode&os.ModeDir != 0:
+		return "d"
+	case mode&os.ModeSymlink != 0:
+		return "l"
+	case mode&os.ModeNamedPipe != 0:
+		return "p"
+	case mode&os.ModeSocket != 0:
+		return "s"
+	}
+	return "U"
+}
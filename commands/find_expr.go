@@ -0,0 +1,366 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// findFile stores the information for the file, for which the find expression is evaluated
+type findFile struct {
+	path  string // path is the name of the file as it is written in the output - the start path joined with the relative name
+	name  string // name is the full name of the file
+	entry *walkEntry
+	depth int  // depth is the number of directories between the start path and the file
+	prune bool // prune is set by -prune, so the files in the directory are skipped
+}
+
+// info is a method returning the information for the file, which is read only when a test needs it.
+// If the file cannot be read anymore, only its name and type are known.
+func (file *findFile) info() os.FileInfo {
+	info, err := file.entry.Info()
+	if err != nil {
+		return walkInfo{file.entry.dirEntry}
+	}
+	return info
+}
+
+// findExpr is the type of the compiled find expressions, which return if the file matches
+type findExpr func(file *findFile) bool
+
+// findParser is a structure for parsing find expressions with recursive descent
+//
+// The grammar is the one of find, from the lowest priority:
+//
+//	expression = and { (-o | -or) and }
+//	and        = unary { [-a | -and] unary }
+//	unary      = (! | -not) unary | ( expression ) | primary
+type findParser struct {
+	words      []string
+	position   int
+	path       string    // path is the current path, used for the file names in the expression
+	now        time.Time // now is the time, relative to which -mtime and -mmin are computed
+	minDepth   int
+	maxDepth   int  // maxDepth is -1 if there is no -maxdepth
+	depthFirst bool // depthFirst is true with -depth and -delete, then the files in directory are processed before it
+	hasAction  bool // hasAction is true if the expression has action, so the matched files are not printed by default
+	f          *Find
+}
+
+// parseFindExpression function parses the words of the find expression.
+// If there is no action in the expression, -print is added to the matched files.
+func parseFindExpression(words []string, f *Find) (*findParser, findExpr, error) {
+	p := &findParser{words: words, path: f.path, now: time.Now(), maxDepth: -1, f: f}
+	expr := findExpr(func(file *findFile) bool { return true })
+	if len(words) > 0 {
+		var err error
+		if expr, err = p.parseOr(); err != nil {
+			return nil, nil, err
+		}
+		if p.position < len(words) { // only unmatched closing parenthesis can stop the parsing
+			return nil, nil, fmt.Errorf("%s - %w", words[p.position], ErrFindParentheses)
+		}
+	}
+	if p.hasAction == false {
+		matches := expr
+		expr = func(file *findFile) bool {
+			return matches(file) && f.print(file)
+		}
+	}
+	return p, expr, nil
+}
+
+// peek is a method returning the current word or empty string at the end
+func (p *findParser) peek() string {
+	if p.position < len(p.words) {
+		return p.words[p.position]
+	}
+	return ""
+}
+
+// next is a method returning the value of the predicate with name
+func (p *findParser) next(name string) (string, error) {
+	if p.position >= len(p.words) {
+		return "", fmt.Errorf("%s - %w", name, ErrMissingValue)
+	}
+	p.position++
+	return p.words[p.position-1], nil
+}
+
+func (p *findParser) parseOr() (findExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for word := p.peek(); word == "-o" || word == "-or"; word = p.peek() {
+		p.position++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		first := left
+		left = func(file *findFile) bool {
+			return first(file) || right(file)
+		}
+	}
+	return left, nil
+}
+
+func (p *findParser) parseAnd() (findExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for word := p.peek(); word != "" && word != "-o" && word != "-or" && word != ")"; word = p.peek() {
+		if word == "-a" || word == "-and" {
+			p.position++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		first := left
+		left = func(file *findFile) bool {
+			return first(file) && right(file)
+		}
+	}
+	return left, nil
+}
+
+func (p *findParser) parseUnary() (findExpr, error) {
+	word := p.peek()
+	switch word {
+	case "":
+		return nil, fmt.Errorf("%s - %w", p.words[len(p.words)-1], ErrFindMissingExpression)
+	case "!", "-not":
+		p.position++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(file *findFile) bool { return !expr(file) }, nil
+	case "(":
+		p.position++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("( - %w", ErrFindParentheses)
+		}
+		p.position++
+		return expr, nil
+	case ")", "-o", "-or", "-a", "-and":
+		return nil, fmt.Errorf("%s - %w", word, ErrFindMissingExpression)
+	}
+	p.position++
+	return p.parsePrimary(word)
+}
+
+// parsePrimary is a method for parsing the tests and the actions of find
+func (p *findParser) parsePrimary(name string) (findExpr, error) {
+	if expr, isAction, err := p.parseAction(name); isAction == true {
+		return expr, err
+	}
+	switch name {
+	case "-true":
+		return func(file *findFile) bool { return true }, nil
+	case "-false":
+		return func(file *findFile) bool { return false }, nil
+	case "-prune":
+		return func(file *findFile) bool {
+			file.prune = true
+			return true
+		}, nil
+	case "-empty":
+		return func(file *findFile) bool {
+			if file.info().IsDir() {
+				dir, err := os.Open(file.name)
+				if err != nil {
+					return false
+				}
+				defer dir.Close()
+				_, err = dir.Readdirnames(1)
+				return err == io.EOF
+			}
+			return file.info().Mode().IsRegular() && file.info().Size() == 0
+		}, nil
+	}
+
+	value, err := p.next(name)
+	if err != nil {
+		return nil, err
+	}
+	invalid := fmt.Errorf("%s %s - %w", name, value, ErrFindInvalidValue)
+	switch name {
+	case "-name", "-iname", "-path", "-ipath", "-wholename", "-iwholename":
+		pattern, err := globToRegexp(value, strings.HasPrefix(name, "-i"))
+		if err != nil {
+			return nil, invalid
+		}
+		if strings.HasSuffix(name, "name") && !strings.HasSuffix(name, "wholename") {
+			return func(file *findFile) bool { return pattern.MatchString(baseName(file.path)) }, nil
+		}
+		return func(file *findFile) bool { return pattern.MatchString(file.path) }, nil
+	case "-regex", "-iregex":
+		if name == "-iregex" {
+			value = "(?i)" + value
+		}
+		pattern, err := regexp.Compile("^(?:" + value + ")$") // like in find, the regular expression matches the whole path
+		if err != nil {
+			return nil, invalid
+		}
+		return func(file *findFile) bool { return pattern.MatchString(file.path) }, nil
+	case "-type":
+		if len(value) != 1 || !strings.Contains("fdlps", value) {
+			return nil, invalid
+		}
+		return func(file *findFile) bool { return findTypeLetter(file.info().Mode()) == value }, nil
+	case "-size":
+		compare, err := parseFindSize(value)
+		if err != nil {
+			return nil, invalid
+		}
+		return func(file *findFile) bool { return compare(file.info().Size()) }, nil
+	case "-mtime", "-mmin":
+		unit := 24 * time.Hour
+		if name == "-mmin" {
+			unit = time.Minute
+		}
+		sign, number, err := parseFindNumber(value)
+		if err != nil {
+			return nil, invalid
+		}
+		return func(file *findFile) bool {
+			age := int64(p.now.Sub(file.info().ModTime()) / unit) // like in find, the fractional part is ignored
+			return compareFindNumbers(sign, age, number)
+		}, nil
+	case "-newer":
+		info, err := os.Stat(FullFileName(p.path, value))
+		if err != nil {
+			return nil, err
+		}
+		return func(file *findFile) bool { return file.info().ModTime().After(info.ModTime()) }, nil
+	case "-mindepth", "-maxdepth":
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 0 {
+			return nil, invalid
+		}
+		if name == "-mindepth" {
+			p.minDepth = depth
+		} else {
+			p.maxDepth = depth
+		}
+		return func(file *findFile) bool { return true }, nil
+	}
+	p.position-- // the value is not used
+	return nil, fmt.Errorf("%s - %w", name, ErrFindUnknownPredicate)
+}
+
+// parseFindNumber function parses numbers like +N, -N and N, returning the sign separately
+func parseFindNumber(text string) (byte, int64, error) {
+	var sign byte
+	if strings.HasPrefix(text, "+") || strings.HasPrefix(text, "-") {
+		sign, text = text[0], text[1:]
+	}
+	number, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || number < 0 {
+		return 0, 0, ErrFindInvalidValue
+	}
+	return sign, number, nil
+}
+
+// compareFindNumbers function checks if value is greater than number (sign +), less than number (sign -) or equal to number
+func compareFindNumbers(sign byte, value int64, number int64) bool {
+	switch sign {
+	case '+':
+		return value > number
+	case '-':
+		return value < number
+	}
+	return value == number
+}
+
+// parseFindSize function parses the value of -size, which is number with optional unit c, w, b, k, M or G.
+// Like in find, the size of the file is rounded up to the unit, which is 512 bytes by default.
+func parseFindSize(text string) (func(size int64) bool, error) {
+	units := map[byte]int64{'c': 1, 'w': 2, 'b': 512, 'k': 1 << 10, 'M': 1 << 20, 'G': 1 << 30}
+	unit := int64(512)
+	if len(text) > 0 {
+		if u, ok := units[text[len(text)-1]]; ok == true {
+			unit, text = u, text[:len(text)-1]
+		}
+	}
+	sign, number, err := parseFindNumber(text)
+	if err != nil {
+		return nil, err
+	}
+	return func(size int64) bool {
+		return compareFindNumbers(sign, (size+unit-1)/unit, number)
+	}, nil
+}
+
+// globToRegexp function converts shell pattern to regular expression.
+// Unlike filepath.Match, * and ? match path separators too, like in -path of find.
+func globToRegexp(pattern string, foldCase bool) (*regexp.Regexp, error) {
+	expr := "^" + globExpression(pattern, "(?s:.*)", "(?s:.)") + "$"
+	if foldCase == true {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// globExpression function converts shell pattern to regular expression, in which * and ? are replaced with star and question
+func globExpression(pattern string, star string, question string) string {
+	var expr strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			expr.WriteString(star)
+		case '?':
+			expr.WriteString(question)
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := i + 1
+			if end < len(pattern) && (pattern[end] == '!' || pattern[end] == '^') {
+				end++
+			}
+			if end < len(pattern) && pattern[end] == ']' { // ] right after [ is part of the set
+				end++
+			}
+			for end < len(pattern) && pattern[end] != ']' {
+				end++
+			}
+			if end >= len(pattern) { // there is no closing bracket, so [ is matched literally
+				expr.WriteString(`\[`)
+				continue
+			}
+			set := pattern[i+1 : end]
+			if set[0] == '!' || set[0] == '^' {
+				set = "^" + set[1:]
+			}
+			expr.WriteString("[" + strings.Replace(set, `\`, `\\`, -1) + "]")
+			i = end
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return expr.String()
+}
+
+// baseName function returns the last element of path, like filepath.Base, but without cleaning it
+func baseName(path string) string {
+	trimmed := strings.TrimRight(path, string(os.PathSeparator))
+	if trimmed == "" {
+		return path
+	}
+	return trimmed[strings.LastIndex(trimmed, string(os.PathSeparator))+1:]
+}
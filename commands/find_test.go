@@ -1,62 +1,229 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestFind(t *testing.T) {
-	path, err := os.Getwd()
+	path, err := ioutil.TempDir("", "find-test")
 	if err != nil {
-		t.Fatal("Fatal error - cannot get current path! - %w", err)
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
 	}
-	pathFile := path + string(os.PathSeparator) + "new-file"
-	file, err := os.Create(pathFile)
-	if err != nil {
-		t.Fatal("Fatal error - cannot make file in new directory new-file! - %w", err)
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{
+		"a.txt": "", "B.TXT": strings.Repeat("b", 2000), "src/main.go": "package main", "src/lib/lib.go": "package lib",
+		"src/lib/notes.txt": "notes", "empty/": "", ".git/config": "",
+	})
+	if err := os.Symlink("a.txt", filepath.Join(path, "link")); err != nil {
+		t.Fatalf("Fatal error - cannot make symbolic link! - %v", err)
+	}
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	for _, name := range []string{"a.txt", "src/main.go"} {
+		if err := os.Chtimes(filepath.Join(path, name), old, old); err != nil {
+			t.Fatalf("Fatal error - cannot change times! - %v", err)
+		}
 	}
-	defer func() {
-		file.Close()
-		os.Remove(pathFile)
-	}()
 
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatal("Fatal error - cannot make pipe! - %w", err)
+	var tests = []struct {
+		words  []string
+		output string
+		err    error
+	}{
+		{[]string{"src"}, "src\nsrc/lib\nsrc/lib/lib.go\nsrc/lib/notes.txt\nsrc/main.go\n", nil},
+		{[]string{"src/", "-name", "*.go"}, "src/lib/lib.go\nsrc/main.go\n", nil},
+		{[]string{"-name", "*.txt"}, "./a.txt\n./src/lib/notes.txt\n", nil},
+		{[]string{".", "-iname", "*.txt", "-maxdepth", "1"}, "./B.TXT\n./a.txt\n", nil},
+		{[]string{"src", "-path", "src/*/n*"}, "src/lib/notes.txt\n", nil},
+		{[]string{"src", "-regex", `.*/[a-z]+\.go`}, "src/lib/lib.go\nsrc/main.go\n", nil},
+		{[]string{".", "-type", "d"}, ".\n./.git\n./empty\n./src\n./src/lib\n", nil},
+		{[]string{".", "-type", "l"}, "./link\n", nil},
+		{[]string{".", "-type", "f", "-size", "+3"}, "./B.TXT\n", nil},
+		{[]string{".", "-type", "f", "-size", "-1k"}, "./.git/config\n./a.txt\n", nil},
+		{[]string{".", "-size", "5c"}, "./link\n./src/lib/notes.txt\n", nil},
+		{[]string{".", "-type", "f", "-mtime", "+7"}, "./a.txt\n./src/main.go\n", nil},
+		{[]string{".", "-type", "f", "-mtime", "-7", "-newer", "a.txt", "-name", "*.go"}, "./src/lib/lib.go\n", nil},
+		{[]string{".", "-empty"}, "./.git/config\n./a.txt\n./empty\n", nil},
+		{[]string{".", "-mindepth", "2", "-maxdepth", "2", "-type", "f"}, "./.git/config\n./src/main.go\n", nil},
+		{[]string{".", "-name", ".git", "-prune", "-o", "-type", "f", "-print"},
+			"./B.TXT\n./a.txt\n./src/lib/lib.go\n./src/lib/notes.txt\n./src/main.go\n", nil},
+		{[]string{"src", "!", "-name", "*.go", "-a", "!", "-type", "d"}, "src/lib/notes.txt\n", nil},
+		{[]string{"src", "(", "-name", "main.go", "-o", "-name", "*.txt", ")", "-type", "f"}, "src/lib/notes.txt\nsrc/main.go\n", nil},
+		{[]string{"src", "-name", "main.go", "-o", "-name", "*.txt", "-type", "d"}, "src/main.go\n", nil},
+		{[]string{"missing", "src/main.go"}, "src/main.go\n", os.ErrNotExist},
+		{[]string{".", "-nam", "a"}, "", ErrFindUnknownPredicate},
+		{[]string{".", "-type", "x"}, "", ErrFindInvalidValue},
+		{[]string{".", "-size", "10X"}, "", ErrFindInvalidValue},
+		{[]string{".", "(", "-name", "a"}, "", ErrFindParentheses},
+		{[]string{".", "-name", "a", ")"}, "", ErrFindParentheses},
+		{[]string{".", "-name"}, "", ErrMissingValue},
+		{[]string{".", "-o", "-name", "a"}, "", ErrFindMissingExpression},
+		{[]string{".", "!"}, "", ErrFindMissingExpression},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Find test with words %v", test.words), func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			find := Find{}
+			errFind := find.Execute(CommandProperties{path, nil, nil, os.Stdin, w, test.words})
+			w.Close()
+			if !errors.Is(errFind, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errFind)
+			}
+			output, _ := ioutil.ReadAll(r)
+			if expected := strings.Replace(test.output, "/", string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+		})
 	}
+}
 
-	find := Find{}
-	if err := find.Execute(CommandProperties{path, []string{"new-file", "new-file"}, []string{}, os.Stdin, w}); err != nil {
-		t.Errorf("Expecting no error from Find function, but got: %w\n", err)
-		return
+func TestFindActions(t *testing.T) {
+	var tests = []struct {
+		words   []string
+		output  string
+		err     error
+		removed []string
+	}{
+		{[]string{".", "-name", "*.txt", "-print0"}, "./a.txt\x00./dir/b.txt\x00", nil, nil},
+		{[]string{"dir", "-type", "f", "-printf", `%f %s %m %d %y %h\n`}, "b.txt 2 644 1 f dir\nc.log 2 644 2 f dir/sub\n", nil, nil},
+		{[]string{"a.txt", "-printf", `%p %TY %%\t%x`}, "a.txt 2020 %\t%x", nil, nil},
+		{[]string{".", "-name", "*.txt", "-exec", "cat", "{}", ";"}, "A\nB\n", nil, nil},
+		{[]string{".", "-name", "*.txt", "-exec", "cat", "{}", `\;`, "-print"}, "A\n./a.txt\nB\n./dir/b.txt\n", nil, nil},
+		{[]string{".", "-type", "f", "-exec", "cat", "{}", "+"}, "A\nB\nC\n", nil, nil},
+		{[]string{"dir", "-type", "f", "-execdir", "cat", "{}", "+"}, "B\nC\n", nil, nil},
+		{[]string{"dir", "-type", "f", "-execdir", "cat", "{}", ";"}, "B\nC\n", nil, nil},
+		{[]string{".", "-name", "sub", "(", "-exec", "rm", "{}", ";", "-o", "-print", ")"}, "./dir/sub\n", ErrRmIsDir, nil},
+		{[]string{"dir", "-name", "sub", "-delete"}, "", os.ErrExist, nil},
+		{[]string{"dir", "-delete"}, "", nil, []string{"dir"}},
+		{[]string{".", "-name", "*.txt", "-delete", "-print"}, "./a.txt\n./dir/b.txt\n", nil, []string{"a.txt", "dir/b.txt"}},
+		{[]string{".", "-depth", "-name", "*.txt", "-o", "-type", "d", "-name", "sub"}, "./a.txt\n./dir/b.txt\n./dir/sub\n", nil, nil},
+		{[]string{".", "-exec", "cat", "{}"}, "", ErrFindExecEnd, nil},
+		{[]string{".", "-exec", ";"}, "", ErrFindExecEnd, nil},
+		{[]string{".", "-exec", "cat", "+"}, "", ErrFindExecEnd, nil},
+		{[]string{".", "-printf"}, "", ErrMissingValue, nil},
 	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Find test with words %v", test.words), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "find-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"a.txt": "A\n", "dir/b.txt": "B\n", "dir/sub/c.log": "C\n"})
+			modTime := time.Date(2020, 5, 1, 12, 0, 0, 0, time.Local)
+			for _, name := range []string{"a.txt", "dir/b.txt", "dir/sub/c.log"} {
+				os.Chmod(filepath.Join(path, name), 0644)
+				os.Chtimes(filepath.Join(path, name), modTime, modTime)
+			}
 
-	output := make([]byte, 1<<10)
-	if _, err := r.Read(output); err != nil {
-		t.Fatal("Fatal error - cannot read from pipe! - %w", err)
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			find := Find{}
+			find.SetCommandLookup(func(name string) ExecuteCommand {
+				switch name {
+				case "cat":
+					return &Cat{}
+				case "rm":
+					return &Rm{}
+				}
+				return nil
+			})
+			find.InitStopSignalCatching()
+			errFind := find.Execute(CommandProperties{path, nil, nil, os.Stdin, w, test.words})
+			w.Close()
+			if !errors.Is(errFind, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errFind)
+			}
+			output, _ := ioutil.ReadAll(r)
+			if expected := strings.Replace(test.output, "/", string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+			for _, name := range test.removed {
+				if _, err := os.Lstat(filepath.Join(path, name)); !os.IsNotExist(err) {
+					t.Errorf("Expected %s to be removed, but got: %v", name, err)
+				}
+			}
+		})
 	}
-	s := strings.Split(string(output), "\n")
-	if len(s) < 2 {
-		t.Errorf("Expecting 2 lines, but got: %s", output)
-		return
+}
+
+func TestFindExternalCommand(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo program is not found")
 	}
-	expectedResult := "new-file found - " + pathFile
-	if s[0] != expectedResult || s[1] != expectedResult {
-		t.Errorf("Expecting %s, but got: %s and %s", expectedResult, s[0], s[1])
-		return
+	path, err := ioutil.TempDir("", "find-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
 	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a": "", "b": ""})
 
-	if err := find.Execute(CommandProperties{path, []string{}, []string{}, os.Stdin, w}); err != ErrFindNoArgs {
-		t.Errorf("Expecting error %v, but got: %w\n", ErrFindNoArgs, err)
-		return
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	find := Find{}
+	find.InitStopSignalCatching()
+	words := []string{".", "-type", "f", "-exec", "echo", "file", "{}", ";", "-exec", "echo", "{}", "+"}
+	if err := find.Execute(CommandProperties{path, nil, nil, os.Stdin, w, words}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	w.Close()
+	output, _ := ioutil.ReadAll(r)
+	if expected := "file ./a\nfile ./b\n./a ./b\n"; string(output) != expected {
+		t.Errorf("Expected output %q, but got: %q", expected, output)
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	var tests = []struct {
+		pattern  string
+		foldCase bool
+		name     string
+		matches  bool
+	}{
+		{"*.go", false, "main.go", true},
+		{"*.go", false, "main.GO", false},
+		{"*.go", true, "main.GO", true},
+		{"src/*", false, "src/lib/lib.go", true},
+		{"?.txt", false, "ab.txt", false},
+		{"[abc]*", false, "banana", true},
+		{"[!abc]*", false, "banana", false},
+		{"[]]", false, "]", true},
+		{`\*`, false, "*", true},
+		{`\*`, false, "a", false},
+		{"a[b", false, "a[b", true},
+		{"a.b", false, "axb", false},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("globToRegexp(%s,%v) on %s", test.pattern, test.foldCase, test.name), func(t *testing.T) {
+			pattern, err := globToRegexp(test.pattern, test.foldCase)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if matches := pattern.MatchString(test.name); matches != test.matches {
+				t.Errorf("Expected match %v, but got: %v", test.matches, matches)
+			}
+		})
 	}
 }
 
 func ExampleFind_Execute() {
 	path, _ := os.Getwd()
 	find := Find{}
-	find.Execute(newCp(path, []string{"not-existing-file"}, []string{}))
+	find.Execute(CommandProperties{path, nil, nil, os.Stdin, os.Stdout, []string{".", "-maxdepth", "1", "-name", "find.go"}})
 	// Output:
-	// not-existing-file not found
+	// ./find.go
 }
@@ -0,0 +1,431 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrGrepNoPattern indicates that grep command has no pattern
+	ErrGrepNoPattern = errors.New("missing pattern")
+	// ErrGrepInvalidPattern indicates that the pattern of grep command is not valid regular expression
+	ErrGrepInvalidPattern = errors.New("invalid regular expression")
+	// ErrGrepInvalidValue indicates that the number of context lines of grep command is not valid
+	ErrGrepInvalidValue = errors.New("invalid context length")
+	// ErrGrepColor indicates that the value of --color option of grep command is not one of auto, always and never
+	ErrGrepColor = errors.New("invalid color mode, valid modes are auto, always and never")
+	// ErrGrepIsDirectory indicates that directory is given to grep command without -r
+	ErrGrepIsDirectory = errors.New("is a directory")
+)
+
+// grepQueueSize is the maximum number of files in the recursive search, which are searched before their output is written
+const grepQueueSize = 64
+
+// Grep is a structure for grep command, implementing ExecuteCommand interface
+type Grep struct {
+	path          string
+	stopExecution chan struct{}
+	search        *grepSearch
+	out           *streamWriter
+	printed       bool    // printed is true after the first output line, so that the groups of context lines are separated
+	found         bool    // found is true when there is selected line, or file without selected lines for -L
+	errs          []error // errs collects the errors of the files, which cannot be searched
+}
+
+// grepJob is a file in the recursive search of grep command, its result is sent to result
+type grepJob struct {
+	name    string // name is the full name of the file
+	display string // display is the name of the file in the output
+	result  chan grepResult
+}
+
+// grepResult is the result of searching file in the recursive search of grep command
+type grepResult struct {
+	output string
+	found  bool
+	err    error
+}
+
+// GetName is a getter for command name
+func (g *Grep) GetName() string {
+	return "grep"
+}
+
+// GetPath is a getter for path
+func (g *Grep) GetPath() string {
+	return g.path
+}
+
+// Clone is a method for cloning grep command
+func (g *Grep) Clone() ExecuteCommand {
+	clone := *g
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (g *Grep) InitStopSignalCatching() {
+	g.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (g *Grep) SendStopSignal() {
+	g.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (g *Grep) IsStopSignalReceived() bool {
+	select {
+	case <-g.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of grep command
+//
+// The first argument is the pattern, unless patterns are given with -e, and the other arguments are the searched files, - is for the input.
+// The lines of the files, which match the pattern, are written. The pattern is basic regular expression, -E is for extended
+// and -F is for fixed string. The options are like in grep: -i ignores case, -v selects the lines without match,
+// -w and -x match only whole words or lines, -n writes the line numbers, -c writes only the count of the selected lines,
+// -l and -L write only the names of the files with or without selected lines, -o writes only the matches and -q writes nothing.
+// The options -A, -B and -C write lines of context after, before or around the selected lines.
+//
+// With -r the files in directories are searched, with -R symbolic links are followed, and without files the current path is searched.
+// The files are searched concurrently, but the output is in the order of the files.
+// Binary files are skipped, unless -a is given. The matches are colored when the output is a terminal or with --color=always.
+//
+// The result is ExitStatusError with status 1 if there are no selected lines and with status 2 if there are errors, like the exit status of grep.
+func (g *Grep) Execute(cp CommandProperties) error {
+	g.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+	g.out, g.printed, g.found, g.errs = nil, false, false, nil
+
+	short := "e:ivnclLowxEFGA:B:C:rRqsHha"
+	long := []string{"regexp=", "ignore-case", "invert-match", "line-number", "count", "files-with-matches", "files-without-match",
+		"only-matching", "word-regexp", "line-regexp", "extended-regexp", "fixed-strings", "basic-regexp",
+		"after-context=", "before-context=", "context=", "recursive", "dereference-recursive",
+		"quiet", "silent", "no-messages", "with-filename", "no-filename", "text", "color", "colour"}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return &ExitStatusError{2, err}
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+
+	var patterns []string
+	syntax, withName, before, after, around := byte('G'), byte(0), -1, -1, 0 // before and after are -1 when -B and -A are not given
+	walkOptions(cp, short, long, func(name string, value string) {
+		switch name {
+		case "e", "regexp":
+			patterns = append(patterns, value)
+		case "E", "extended-regexp":
+			syntax = 'E'
+		case "F", "fixed-strings":
+			syntax = 'F'
+		case "G", "basic-regexp":
+			syntax = 'G'
+		case "H", "with-filename":
+			withName = 'H'
+		case "h", "no-filename":
+			withName = 'h'
+		case "B", "before-context", "A", "after-context", "C", "context":
+			lines, errParse := strconv.Atoi(value)
+			if errParse != nil || lines < 0 {
+				err = fmt.Errorf("%s - %w", value, ErrGrepInvalidValue)
+			}
+			switch name {
+			case "B", "before-context":
+				before = lines
+			case "A", "after-context":
+				after = lines
+			default:
+				around = lines
+			}
+		}
+	})
+	if err != nil {
+		return &ExitStatusError{2, err}
+	}
+	if before == -1 { // -A and -B override -C
+		before = around
+	}
+	if after == -1 {
+		after = around
+	}
+	if len(patterns) == 0 {
+		if len(arguments) == 0 {
+			return &ExitStatusError{2, ErrGrepNoPattern}
+		}
+		patterns, arguments = arguments[:1], arguments[1:]
+	}
+
+	recursive, followLinks := has("r", "recursive") || has("R", "dereference-recursive"), has("R", "dereference-recursive")
+	g.search = &grepSearch{
+		word:              has("w", "word-regexp") && has("x", "line-regexp") == false,
+		invert:            has("v", "invert-match"),
+		lineNumber:        has("n", "line-number"),
+		count:             has("c", "count"),
+		filesWithMatches:  has("l", "files-with-matches"),
+		filesWithoutMatch: has("L", "files-without-match"),
+		onlyMatching:      has("o", "only-matching"),
+		quiet:             has("q", "quiet") || options.has("silent"),
+		text:              has("a", "text"),
+		withName:          withName == 'H' || (withName == 0 && (len(arguments) > 1 || recursive)),
+		before:            before,
+		after:             after,
+	}
+	g.search.pattern, err = compileGrepPattern(patterns, syntax == 'F', syntax == 'E', has("i", "ignore-case"),
+		g.search.word, has("x", "line-regexp"))
+	if err != nil {
+		return &ExitStatusError{2, err}
+	}
+
+	colorOption := "color"
+	if options.has("colour") {
+		colorOption = "colour"
+	}
+	terminal := isTerminal(outputFile)
+	if options.has(colorOption) {
+		switch color := options.value(colorOption); color {
+		case "", "always", "yes", "force":
+			g.search.color = true
+		case "auto", "tty", "if-tty":
+			g.search.color = terminal
+		case "never", "no", "none":
+		default:
+			return &ExitStatusError{2, fmt.Errorf("%s - %w", color, ErrGrepColor)}
+		}
+	} else { // by default colors are used only when the output is a terminal
+		g.search.color = terminal
+	}
+
+	display := arguments
+	if len(arguments) == 0 {
+		if recursive == true {
+			arguments, display = []string{"."}, []string{""} // the names in the current path are written without ./
+		} else {
+			arguments, display = []string{"-"}, []string{"-"}
+		}
+	}
+
+	g.out = newStreamWriter(g, outputFile)
+	for ind, argument := range arguments {
+		if recursive == true && argument != "-" {
+			err = g.searchTree(FullFileName(g.path, argument), display[ind], followLinks)
+		} else {
+			err = g.searchArgument(argument, inputFile)
+		}
+		if err == ErrStoppedExec {
+			return err
+		}
+		if err != nil {
+			g.errs = append(g.errs, err)
+		}
+		if g.search.quiet == true && g.found == true {
+			break // like in grep, with -q the search stops at the first selected line
+		}
+	}
+
+	switch {
+	case g.search.quiet == true && g.found == true:
+		return g.out.finish(nil)
+	case len(g.errs) > 0 && has("s", "no-messages"):
+		return g.out.finish(&ExitStatusError{2, nil})
+	case len(g.errs) > 0:
+		return g.out.finish(&ExitStatusError{2, joinErrors(g.errs)})
+	case g.found == false:
+		return g.out.finish(&ExitStatusError{1, nil})
+	}
+	return g.out.finish(nil)
+}
+
+// grepFileName function returns the name of file in the output of grep command, the input is written as (standard input)
+func grepFileName(name string) string {
+	if name == "-" {
+		return "(standard input)"
+	}
+	return name
+}
+
+// searchArgument is a method for searching the file with name from the arguments, its output is written directly
+func (g *Grep) searchArgument(name string, inputFile *os.File) error {
+	file, err := openInputFile(g.path, name, inputFile)
+	if err != nil {
+		return err
+	}
+	if file != inputFile {
+		defer file.Close()
+		if info, err := file.Stat(); err == nil && info.IsDir() {
+			return fmt.Errorf("%s - %w", name, ErrGrepIsDirectory)
+		}
+	}
+	found, err := g.searchFile(file, grepFileName(name), g.out.WriteString, g.out.flush, &g.printed)
+	g.found = g.found || found
+	return err
+}
+
+// searchFile is a method for searching file, which is written in the output with name.
+// The output is given to write and flush is called when the next read from the file can wait for more input.
+// It returns true if the file has selected lines, or if it does not have for -L.
+func (g *Grep) searchFile(file *os.File, name string, write func(text string) error, flush func() error, printed *bool) (bool, error) {
+	selected, err := g.search.searchFile(newStreamReader(g, file), name, write, flush, printed)
+	if err == ErrStoppedExec {
+		return false, err
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s - %w", name, err)
+	}
+	if selected < 0 { // binary file
+		return false, nil
+	}
+	if err := write(g.search.formatFileResult(name, selected)); err != nil {
+		return false, err
+	}
+	if g.search.filesWithoutMatch == true {
+		return selected == 0, nil
+	}
+	return selected > 0, nil
+}
+
+// searchTree is a method for searching the files in the tree with start root, which is written as display.
+// The tree is walked in sorted order, while the files are searched by pool of goroutines.
+// Every file has channel for its result, which are queued in the order of the walk, so the output is in this order.
+func (g *Grep) searchTree(root string, display string, followLinks bool) error {
+	queue := make(chan chan grepResult, grepQueueSize)
+	jobs := make(chan grepJob)
+	done := make(chan struct{}) // done is closed when the search stops before the end of the walk
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < runtime.NumCPU(); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.result <- g.searchJob(job, done)
+			}
+		}()
+	}
+
+	var errWalk error
+	go func() {
+		defer close(queue)
+		defer close(jobs)
+		w := &walker{command: g, sorted: true, followLinks: followLinks}
+		w.visit = func(entry *walkEntry, err error) error {
+			name := joinFindPath(display, entry.relative)
+			if display == "" {
+				name = entry.relative
+			}
+			result := make(chan grepResult, 1)
+			if err != nil {
+				if errors.Is(err, ErrWalkLoop) == false {
+					err = fmt.Errorf("%s - %w", name, err)
+				}
+				result <- grepResult{err: err}
+			} else if entry.isDir == true || (entry.dirEntry != nil && entry.dirEntry.Type().IsRegular() == false &&
+				(followLinks == false || entry.dirEntry.Type()&os.ModeSymlink == 0)) {
+				return nil // only regular files are searched, and the files to which symbolic links point with -R
+			}
+			select {
+			case queue <- result:
+			case <-done:
+				return ErrStoppedExec
+			}
+			if err != nil {
+				return nil
+			}
+			select {
+			case jobs <- grepJob{entry.name, name, result}:
+			case <-done:
+				return ErrStoppedExec
+			}
+			return nil
+		}
+		if err := w.walk(root); err != nil && err != filepath.SkipDir {
+			errWalk = err
+		}
+	}()
+
+	var errStop error
+	for result := range queue {
+		if errStop != nil {
+			continue // the results are not needed anymore, the queue is read until the walk stops
+		}
+		r := <-result
+		if r.err == ErrStoppedExec {
+			errStop = r.err
+		} else if r.err != nil {
+			g.errs = append(g.errs, r.err)
+		}
+		if r.output != "" && errStop == nil {
+			if g.search.contextGroups() == true && g.printed == true {
+				r.output = g.search.colorize("--", grepColorSeparator) + "\n" + r.output
+			}
+			if err := g.out.WriteString(r.output); err != nil {
+				errStop = err
+			}
+			g.printed = true
+		}
+		g.found = g.found || r.found
+		if errStop == nil && g.search.quiet == true && g.found == true {
+			errStop = errGrepQuiet
+		}
+		if errStop != nil {
+			close(done)
+		}
+	}
+	wg.Wait()
+
+	switch {
+	case errStop == errGrepQuiet:
+		return nil
+	case errStop != nil:
+		return errStop
+	case errWalk == ErrStoppedExec:
+		return errWalk
+	case errWalk != nil:
+		return fmt.Errorf("%s - %w", display, errWalk)
+	}
+	return nil
+}
+
+// errGrepQuiet stops the recursive search of grep command with -q, when selected line is found
+var errGrepQuiet = errors.New("selected line is found")
+
+// searchJob is a method for searching the file of job in the recursive search, the output is returned in the result
+func (g *Grep) searchJob(job grepJob, done chan struct{}) grepResult {
+	select {
+	case <-done:
+		return grepResult{}
+	default:
+	}
+	file, err := os.Open(job.name)
+	if err != nil {
+		return grepResult{err: fmt.Errorf("%s - %w", job.display, errors.Unwrap(err))}
+	}
+	defer file.Close()
+
+	var output strings.Builder
+	printed := false // every file starts a new group of context lines, the groups of different files are separated when the output is written
+	write := func(text string) error {
+		select {
+		case <-done:
+			return ErrStoppedExec
+		default:
+		}
+		output.WriteString(text)
+		return nil
+	}
+	found, err := g.searchFile(file, job.display, write, nil, &printed)
+	return grepResult{output.String(), found, err}
+}
@@ -0,0 +1,300 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The colors of grep output, like the default GREP_COLORS
+const (
+	grepColorMatch     = "01;31"
+	grepColorName      = "35"
+	grepColorLine      = "32"
+	grepColorSeparator = "36"
+)
+
+// grepSearch stores the settings of grep command, which are the same for all searched files
+type grepSearch struct {
+	pattern           *regexp.Regexp // pattern has the match in its first group, see compileGrepPattern
+	word              bool           // word is for -w, the match must not be followed by letter, digit or _, the start is checked by the pattern
+	invert            bool
+	lineNumber        bool
+	count             bool
+	filesWithMatches  bool
+	filesWithoutMatch bool
+	onlyMatching      bool
+	quiet             bool
+	text              bool // text is for -a, binary files are searched like text files
+	withName          bool
+	color             bool
+	before            int // before is the number of context lines before the selected lines
+	after             int // after is the number of context lines after the selected lines
+}
+
+// grepLine is a line of searched file with its number
+type grepLine struct {
+	number int
+	text   string
+}
+
+// compileGrepPattern function makes one regular expression from the patterns of grep command, every line of a pattern is a separate pattern.
+// Without extended, the patterns are basic regular expressions and with fixed they are strings.
+// The match is the first group of the result, because with word the pattern also matches the character before it.
+func compileGrepPattern(patterns []string, fixed, extended, ignoreCase, word, line bool) (*regexp.Regexp, error) {
+	var parts []string
+	for _, pattern := range patterns {
+		for _, part := range strings.Split(pattern, "\n") {
+			switch {
+			case fixed == true:
+				part = regexp.QuoteMeta(part)
+			case extended == false:
+				part = basicToExtended(part)
+			}
+			parts = append(parts, "(?:"+part+")")
+		}
+	}
+	expression := "(" + strings.Join(parts, "|") + ")"
+	switch {
+	case line == true:
+		expression = "^" + expression + "$"
+	case word == true:
+		expression = `(?:^|[^\pL\pN_])` + expression
+	}
+	if ignoreCase == true {
+		expression = "(?i)" + expression
+	}
+	result, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("%s - %w", strings.Join(patterns, "\n"), ErrGrepInvalidPattern)
+	}
+	return result, nil
+}
+
+// basicToExtended function converts basic regular expression to extended one, which is used by regexp.
+// In basic regular expressions, the characters (){}|+? are special only after \ and * is not special at the start.
+func basicToExtended(pattern string) string {
+	var result strings.Builder
+	for ind := 0; ind < len(pattern); ind++ {
+		char := pattern[ind]
+		switch {
+		case char == '\\' && ind+1 < len(pattern):
+			ind++
+			if strings.IndexByte("(){}|+?", pattern[ind]) != -1 {
+				result.WriteByte(pattern[ind])
+			} else {
+				result.WriteString(pattern[ind-1 : ind+1])
+			}
+		case char == '[': // bracket expressions are the same, ] is part of the list if it is first
+			end := ind + 1
+			if end < len(pattern) && pattern[end] == '^' {
+				end++
+			}
+			if end < len(pattern) && pattern[end] == ']' {
+				end++
+			}
+			for end < len(pattern) && pattern[end] != ']' {
+				end++
+			}
+			if end == len(pattern) {
+				result.WriteString(regexp.QuoteMeta(pattern[ind:]))
+				return result.String()
+			}
+			result.WriteString(pattern[ind : end+1])
+			ind = end
+		case strings.IndexByte("(){}|+?", char) != -1, char == '*' && ind == 0:
+			result.WriteByte('\\')
+			result.WriteByte(char)
+		default:
+			result.WriteByte(char)
+		}
+	}
+	return result.String()
+}
+
+// isWordRune function checks if r is part of word for -w option of grep
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// matches is a method returning the start and the end of every match in line
+func (g *grepSearch) matches(line string) [][2]int {
+	var result [][2]int
+	for _, loc := range g.pattern.FindAllStringSubmatchIndex(line, -1) {
+		start, end := loc[2], loc[3]
+		if g.word == true && end < len(line) {
+			if r, _ := utf8.DecodeRuneInString(line[end:]); isWordRune(r) {
+				continue
+			}
+		}
+		result = append(result, [2]int{start, end})
+	}
+	return result
+}
+
+// selects is a method for checking if line is selected, it has match or it does not have with -v
+func (g *grepSearch) selects(line string) bool {
+	if g.word == false {
+		return g.pattern.MatchString(line) != g.invert
+	}
+	return (len(g.matches(line)) > 0) != g.invert
+}
+
+// colorize is a method for coloring text, when the output is colored
+func (g *grepSearch) colorize(text string, color string) string {
+	if g.color == false || text == "" {
+		return text
+	}
+	return "\x1b[" + color + "m\x1b[K" + text + "\x1b[m\x1b[K"
+}
+
+// prefix is a method returning the start of output line - the name of the file and the number of the line if they are written.
+// The separator is : for selected lines and - for context lines.
+func (g *grepSearch) prefix(name string, number int, separator string) string {
+	prefix := ""
+	if g.withName == true {
+		prefix += g.colorize(name, grepColorName) + g.colorize(separator, grepColorSeparator)
+	}
+	if g.lineNumber == true {
+		prefix += g.colorize(strconv.Itoa(number), grepColorLine) + g.colorize(separator, grepColorSeparator)
+	}
+	return prefix
+}
+
+// formatSelected is a method returning the output for selected line, with colored matches or only the matches for -o
+func (g *grepSearch) formatSelected(name string, line grepLine) string {
+	if g.invert == true {
+		if g.onlyMatching == true {
+			return ""
+		}
+		return g.prefix(name, line.number, ":") + line.text + "\n"
+	}
+	if g.onlyMatching == false && g.color == false {
+		return g.prefix(name, line.number, ":") + line.text + "\n"
+	}
+
+	var result strings.Builder
+	prefix := g.prefix(name, line.number, ":")
+	if g.onlyMatching == false {
+		result.WriteString(prefix)
+	}
+	last := 0
+	for _, match := range g.matches(line.text) {
+		if match[0] == match[1] {
+			continue
+		}
+		if g.onlyMatching == true {
+			result.WriteString(prefix + g.colorize(line.text[match[0]:match[1]], grepColorMatch) + "\n")
+			continue
+		}
+		result.WriteString(line.text[last:match[0]] + g.colorize(line.text[match[0]:match[1]], grepColorMatch))
+		last = match[1]
+	}
+	if g.onlyMatching == false {
+		result.WriteString(line.text[last:] + "\n")
+	}
+	return result.String()
+}
+
+// isBinary function checks if the start of file has NUL byte, like grep does for detecting binary files.
+// Only the bytes from the first read are checked, so that the search of interactive input does not wait for more input.
+func isBinary(in *streamReader) bool {
+	in.reader.Peek(1)
+	start, _ := in.reader.Peek(in.Buffered())
+	return bytes.IndexByte(start, 0) != -1
+}
+
+// contextGroups is a method for checking if the output has groups of lines with context, which are separated with "--"
+func (g *grepSearch) contextGroups() bool {
+	return g.outputLines() && (g.before > 0 || g.after > 0)
+}
+
+// outputLines is a method for checking if the selected lines are written, and not only the names of the files or the count of the lines
+func (g *grepSearch) outputLines() bool {
+	return g.quiet == false && g.count == false && g.filesWithMatches == false && g.filesWithoutMatch == false
+}
+
+// searchFile is a method for searching the lines of file with name, the output is given to write.
+// The number of selected lines is returned, it is -1 for binary files, which are skipped.
+// The value of printed is true when output was written before, so that the groups of context lines are separated with "--".
+func (g *grepSearch) searchFile(in *streamReader, name string, write func(text string) error, flush func() error, printed *bool) (int, error) {
+	if g.text == false && isBinary(in) {
+		return -1, nil
+	}
+	outputLines := g.outputLines()
+
+	selected, lastWritten, afterLeft := 0, 0, 0
+	var before []grepLine // before are the last lines, which are not written, for the context before selected line
+	for number := 1; ; number++ {
+		text, err := in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return selected, err
+		}
+		if text == "" && err == io.EOF {
+			return selected, nil
+		}
+		line := grepLine{number, strings.TrimSuffix(text, "\n")}
+
+		if g.selects(line.text) {
+			selected++
+			if outputLines == false {
+				if g.count == false {
+					return selected, nil // one selected line is enough for -l, -L and -q
+				}
+				continue
+			}
+			output := ""
+			first := number - len(before)
+			if g.contextGroups() == true && *printed == true && (lastWritten == 0 || first > lastWritten+1) {
+				output += g.colorize("--", grepColorSeparator) + "\n"
+			}
+			for _, contextLine := range before {
+				output += g.prefix(name, contextLine.number, "-") + contextLine.text + "\n"
+			}
+			output += g.formatSelected(name, line)
+			if err := write(output); err != nil {
+				return selected, err
+			}
+			*printed, lastWritten, afterLeft, before = true, number, g.after, before[:0]
+		} else if outputLines == true && afterLeft > 0 {
+			if err := write(g.prefix(name, number, "-") + line.text + "\n"); err != nil {
+				return selected, err
+			}
+			lastWritten, afterLeft = number, afterLeft-1
+		} else if outputLines == true && g.before > 0 {
+			if before = append(before, line); len(before) > g.before {
+				before = before[1:]
+			}
+		}
+
+		if in.Buffered() == 0 && flush != nil {
+			if err := flush(); err != nil {
+				return selected, err
+			}
+		}
+		if err == io.EOF {
+			return selected, nil
+		}
+	}
+}
+
+// formatFileResult is a method returning the output for file with name after it is searched, for -c, -l and -L
+func (g *grepSearch) formatFileResult(name string, selected int) string {
+	switch {
+	case g.quiet == true:
+		return ""
+	case g.count == true:
+		if g.withName == true {
+			return g.colorize(name, grepColorName) + g.colorize(":", grepColorSeparator) + strconv.Itoa(selected) + "\n"
+		}
+		return strconv.Itoa(selected) + "\n"
+	case g.filesWithMatches == true && selected > 0, g.filesWithoutMatch == true && selected == 0:
+		return g.colorize(name, grepColorName) + "\n"
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// exitStatus function returns the exit status for the error of command, it is 0 without error
+func exitStatus(err error) int {
+	var statusErr *ExitStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status
+	}
+	if err != nil {
+		return -1
+	}
+	return 0
+}
+
+func TestGrep(t *testing.T) {
+	path, err := ioutil.TempDir("", "grep-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{
+		"lines":          "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\n",
+		"words":          "foo bar\nfoobar\nFOO\nфуу foo\nфууbar\n",
+		"other":          "three\n",
+		"binary":         "three\x00four\n",
+		"dir/a":          "x three\n",
+		"dir/sub/b":      "three x\nnone\n",
+		"dir/sub/c.bin":  "\x00three\n",
+		"empty/":         "",
+		"regexp":         "a+b\naab\n(x)\n",
+		"no-end-of-line": "last three",
+	})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		status int
+		err    error
+	}{
+		{"", []string{"three", "lines"}, "three\n", 0, nil},
+		{"", []string{"zero", "lines"}, "", 1, nil},
+		{"", []string{"-n", "e$", "lines"}, "1:one\n3:three\n5:five\n", 0, nil},
+		{"", []string{"-c", "e", "lines", "other"}, "lines:5\nother:1\n", 0, nil},
+		{"", []string{"-v", "-c", "e", "lines"}, "3\n", 0, nil},
+		{"", []string{"-v", "o", "lines"}, "three\nfive\nsix\nseven\neight\n", 0, nil},
+		{"", []string{"-l", "three", "lines", "other", "words"}, "lines\nother\n", 0, nil},
+		{"", []string{"-L", "three", "lines", "other", "words"}, "words\n", 0, nil},
+		{"", []string{"-L", "three", "lines"}, "", 1, nil},
+		{"", []string{"-i", "foo", "words"}, "foo bar\nfoobar\nFOO\nфуу foo\n", 0, nil},
+		{"", []string{"-w", "foo", "words"}, "foo bar\nфуу foo\n", 0, nil},
+		{"", []string{"-w", "фуу", "words"}, "фуу foo\n", 0, nil},
+		{"", []string{"-x", "foo", "words"}, "", 1, nil},
+		{"", []string{"-x", "-i", "foo", "words"}, "FOO\n", 0, nil},
+		{"", []string{"-o", "-i", "fo*", "words"}, "foo\nfoo\nFOO\nfoo\n", 0, nil},
+		{"", []string{"-o", "-w", "foo", "words"}, "foo\nfoo\n", 0, nil},
+		{"", []string{"a+b", "regexp"}, "a+b\n", 0, nil},
+		{"", []string{"a\\+b", "regexp"}, "aab\n", 0, nil},
+		{"", []string{"-E", "a+b", "regexp"}, "aab\n", 0, nil},
+		{"", []string{"-F", "a+b", "regexp"}, "a+b\n", 0, nil},
+		{"", []string{"(x)", "regexp"}, "(x)\n", 0, nil},
+		{"", []string{"-e", "aab", "-e", "x", "regexp"}, "aab\n(x)\n", 0, nil},
+		{"", []string{"-A", "1", "three", "lines"}, "three\nfour\n", 0, nil},
+		{"", []string{"-B", "1", "-n", "three", "lines"}, "2-two\n3:three\n", 0, nil},
+		{"", []string{"-C", "1", "-e", "two", "-e", "seven", "lines"}, "one\ntwo\nthree\n--\nsix\nseven\neight\n", 0, nil},
+		{"", []string{"-C", "3", "-A", "0", "five", "lines"}, "two\nthree\nfour\nfive\n", 0, nil},
+		{"", []string{"-A", "1", "three", "lines", "other"}, "lines:three\nlines-four\n--\nother:three\n", 0, nil},
+		{"", []string{"three", "binary", "other"}, "other:three\n", 0, nil},
+		{"", []string{"-a", "-c", "three", "binary"}, "1\n", 0, nil},
+		{"", []string{"three", "no-end-of-line"}, "last three\n", 0, nil},
+		{"", []string{"-r", "three", "dir"}, "dir/a:x three\ndir/sub/b:three x\n", 0, nil},
+		{"", []string{"-r", "-h", "-n", "three", "dir"}, "1:x three\n1:three x\n", 0, nil},
+		{"", []string{"-r", "-l", "x", "dir", "other"}, "dir/a\ndir/sub/b\n", 0, nil},
+		{"", []string{"-r", "-c", "x", "dir"}, "dir/a:1\ndir/sub/b:1\n", 0, nil},
+		{"", []string{"-r", "three", "empty"}, "", 1, nil},
+		{"", []string{"-q", "three", "lines", "missing"}, "", 0, nil},
+		{"", []string{"three", "lines", "missing"}, "lines:three\n", 2, os.ErrNotExist},
+		{"", []string{"-s", "three", "missing"}, "", 2, nil},
+		{"", []string{"three", "dir"}, "", 2, ErrGrepIsDirectory},
+		{"one\nthree\n", []string{"-n", "three"}, "2:three\n", 0, nil},
+		{"one\nthree\n", []string{"-H", "three", "-"}, "(standard input):three\n", 0, nil},
+		{"", []string{"--color=always", "-n", "o", "words"},
+			"\x1b[32m\x1b[K1\x1b[m\x1b[K\x1b[36m\x1b[K:\x1b[m\x1b[K" + "f\x1b[01;31m\x1b[Ko\x1b[m\x1b[K\x1b[01;31m\x1b[Ko\x1b[m\x1b[K bar\n" +
+				"\x1b[32m\x1b[K2\x1b[m\x1b[K\x1b[36m\x1b[K:\x1b[m\x1b[K" + "f\x1b[01;31m\x1b[Ko\x1b[m\x1b[K\x1b[01;31m\x1b[Ko\x1b[m\x1b[Kbar\n" +
+				"\x1b[32m\x1b[K4\x1b[m\x1b[K\x1b[36m\x1b[K:\x1b[m\x1b[K" + "фуу f\x1b[01;31m\x1b[Ko\x1b[m\x1b[K\x1b[01;31m\x1b[Ko\x1b[m\x1b[K\n", 0, nil},
+		{"", []string{"--color=sometimes", "o", "words"}, "", 2, ErrGrepColor},
+		{"", []string{"a\\(", "regexp"}, "", 2, ErrGrepInvalidPattern},
+		{"", []string{"-A", "x", "a", "regexp"}, "", 2, ErrGrepInvalidValue},
+		{"", []string{}, "", 2, ErrGrepNoPattern},
+		{"", []string{"-y", "a", "regexp"}, "", 2, ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Grep test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Grep{}, path, test.words, test.input)
+			if status := exitStatus(err); status != test.status {
+				t.Errorf("Expected exit status %d, but got: %d (%v)", test.status, status, err)
+			}
+			if test.err != nil && !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if test.err == nil && test.status != 0 && IsSilentError(err) == false {
+				t.Errorf("Expected only exit status, but got: %v", err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+func TestGrepRecursiveCurrentPath(t *testing.T) {
+	path, err := ioutil.TempDir("", "grep-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	files := map[string]string{}
+	expected := ""
+	for i := 0; i < 200; i++ { // more files than grepQueueSize, so that the walk waits for the output
+		name := fmt.Sprintf("dir%d/file%03d", i%3, i)
+		files[name] = fmt.Sprintf("line %d\n", i)
+	}
+	for dir := 0; dir < 3; dir++ {
+		for i := dir; i < 200; i += 3 {
+			expected += fmt.Sprintf("dir%d/file%03d:line %d\n", dir, i, i)
+		}
+	}
+	makeTestTree(t, path, files)
+
+	output, err := runTestCommand(t, &Grep{}, path, []string{"-r", "line"})
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if output != expected {
+		t.Errorf("Expected output %q, but got: %q", expected, output)
+	}
+}
+
+func ExampleGrep_Execute() {
+	path, _ := os.Getwd()
+	grep := Grep{}
+	grep.Execute(CommandProperties{path, nil, nil, os.Stdin, os.Stdout, []string{"-n", "^type Grep", "grep.go"}})
+	// Output:
+	// 31:type Grep struct {
+}
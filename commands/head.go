@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+var (
+	// ErrHeadInvalidValue indicates that the number of lines or bytes of head command is not valid
+	ErrHeadInvalidValue = errors.New("invalid number")
+)
+
+// Head is a structure for head command, implementing ExecuteCommand interface
+type Head struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// GetName is a getter for command name
+func (h *Head) GetName() string {
+	return "head"
+}
+
+// GetPath is a getter for path
+func (h *Head) GetPath() string {
+	return h.path
+}
+
+// Clone is a method for cloning head command
+func (h *Head) Clone() ExecuteCommand {
+	clone := *h
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (h *Head) InitStopSignalCatching() {
+	h.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (h *Head) SendStopSignal() {
+	h.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (h *Head) IsStopSignalReceived() bool {
+	select {
+	case <-h.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of head command
+//
+// The first 10 lines of every file in the arguments are written, - is for the input and without arguments the input is read.
+// With -n N the first N lines are written and with -n -N all lines except the last N.
+// With -c N and -c -N the same is done for bytes.
+// When there are more files, the output of every file starts with header with its name, -q removes the headers and -v always writes them.
+func (h *Head) Execute(cp CommandProperties) error {
+	h.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	short, long := "n:c:qv", []string{"lines=", "bytes=", "quiet", "silent", "verbose"}
+	_, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	count, err := parseCountOption(cp, short, long, ErrHeadInvalidValue)
+	if err != nil {
+		return err
+	}
+	if count.sign == '+' {
+		count.sign = 0
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+	headers := showHeaders(cp, short, long, len(arguments))
+
+	out := newStreamWriter(h, outputFile)
+	var errs []error // in slice errs we collect all the errors
+	first := true    // first is true until the first header is written
+	for _, argument := range arguments {
+		file, err := openInputFile(h.path, argument, inputFile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if headers == true {
+			err, first = out.WriteString(fileHeader(argument, first)), false
+		}
+		if err == nil {
+			err = h.outputHead(file, out, count)
+		}
+		if file != inputFile {
+			file.Close()
+		}
+		if err == ErrStoppedExec {
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+		}
+	}
+	return out.finish(joinErrors(errs))
+}
+
+// outputHead is a method for writing the start of file, as it is given by count
+func (h *Head) outputHead(file *os.File, out *streamWriter, count countOption) error {
+	in := newStreamReader(h, file)
+	if count.bytes == true {
+		if count.sign == '-' {
+			return h.outputAllButLastBytes(in, out, count.count)
+		}
+		_, err := io.CopyN(out, in, count.count)
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	var pending []string // pending are the last lines, which are written only when more lines are read after them
+	for written := int64(0); count.sign == '-' || written < count.count; {
+		line, err := in.ReadString('\n')
+		if len(line) > 0 && count.sign == '-' {
+			pending, line = append(pending, line), ""
+			if int64(len(pending)) > count.count {
+				line, pending = pending[0], pending[1:]
+			}
+		}
+		if len(line) > 0 {
+			if err := out.WriteString(line); err != nil {
+				return err
+			}
+			written++
+		}
+		if err := out.flushIfWaiting(in); err != nil {
+			return err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputAllButLastBytes is a method for writing all bytes from in, except the last count
+func (h *Head) outputAllButLastBytes(in *streamReader, out *streamWriter, count int64) error {
+	var pending []byte
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := in.Read(buf)
+		if pending = append(pending, buf[:n]...); int64(len(pending)) > count {
+			if _, err := out.Write(pending[:int64(len(pending))-count]); err != nil {
+				return err
+			}
+			pending = append(pending[:0], pending[int64(len(pending))-count:]...)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// countOption stores the number of lines or bytes, given to head or tail command with -n or -c
+type countOption struct {
+	bytes bool  // bytes is true for -c, when the count is of bytes instead of lines
+	count int64 // count is the number of lines or bytes
+	sign  byte  // sign is + or - when the number starts with it, otherwise 0
+}
+
+// parseCountOption function finds the last of the options -n and -c, like in head and tail commands.
+// Without them, the count is 10 lines. The error errInvalid is returned for invalid numbers.
+func parseCountOption(cp CommandProperties, short string, long []string, errInvalid error) (countOption, error) {
+	result := countOption{count: 10}
+	var err error
+	walkOptions(cp, short, long, func(name string, value string) {
+		if name != "n" && name != "lines" && name != "c" && name != "bytes" {
+			return
+		}
+		result = countOption{bytes: name == "c" || name == "bytes"}
+		number := value
+		if len(number) > 0 && (number[0] == '+' || number[0] == '-') {
+			result.sign, number = number[0], number[1:]
+		}
+		if count, errParse := strconv.ParseInt(number, 10, 64); errParse != nil || count < 0 {
+			err = fmt.Errorf("%s - %w", value, errInvalid)
+		} else {
+			result.count = count
+		}
+	})
+	return result, err
+}
+
+// showHeaders function checks if head or tail command writes headers with the names of files
+func showHeaders(cp CommandProperties, short string, long []string, files int) bool {
+	headers := files > 1
+	walkOptions(cp, short, long, func(name string, value string) {
+		switch name {
+		case "q", "quiet", "silent":
+			headers = false
+		case "v", "verbose":
+			headers = true
+		}
+	})
+	return headers
+}
+
+// fileHeader function returns the header, which is written before the output for file with name in head and tail commands
+func fileHeader(name string, first bool) string {
+	header := "==> " + inputFileName(name) + " <==\n"
+	if first == false {
+		header = "\n" + header
+	}
+	return header
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+// runTestCommandWithInput is a helper function for running command in path with words and input, returning its output and error
+func runTestCommandWithInput(t *testing.T, command ExecuteCommand, path string, words []string, input string) (string, error) {
+	inputR, inputW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	defer inputR.Close()
+	go func() {
+		inputW.WriteString(input)
+		inputW.Close()
+	}()
+	outputR, outputW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	defer outputR.Close()
+
+	result := make(chan []byte)
+	go func() {
+		output, _ := ioutil.ReadAll(outputR)
+		result <- output
+	}()
+	command.InitStopSignalCatching()
+	errCommand := command.Execute(CommandProperties{path, nil, nil, inputR, outputW, words})
+	outputW.Close()
+	return string(<-result), errCommand
+}
+
+func TestHead(t *testing.T) {
+	path, err := ioutil.TempDir("", "head-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	var numbers []string
+	for i := 1; i <= 12; i++ {
+		numbers = append(numbers, fmt.Sprint(i))
+	}
+	makeTestTree(t, path, map[string]string{"numbers": strings.Join(numbers, "\n") + "\n", "short": "a\nb", "empty": ""})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"numbers"}, "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", nil},
+		{"", []string{"-n", "3", "numbers"}, "1\n2\n3\n", nil},
+		{"", []string{"-n3", "-n", "2", "numbers"}, "1\n2\n", nil},
+		{"", []string{"--lines=+2", "numbers"}, "1\n2\n", nil},
+		{"", []string{"-n", "-9", "numbers"}, "1\n2\n3\n", nil},
+		{"", []string{"-n", "-20", "numbers"}, "", nil},
+		{"", []string{"-n", "0", "numbers"}, "", nil},
+		{"", []string{"-n", "5", "short"}, "a\nb", nil},
+		{"", []string{"-n", "-1", "short"}, "a\n", nil},
+		{"", []string{"-c", "3", "numbers"}, "1\n2", nil},
+		{"", []string{"-n", "1", "-c", "3", "numbers"}, "1\n2", nil},
+		{"", []string{"-c", "3", "-n", "1", "numbers"}, "1\n", nil},
+		{"", []string{"--bytes=-23", "numbers"}, "1\n2\n", nil},
+		{"", []string{"-c", "-10", "short"}, "", nil},
+		{"", []string{"-n", "1", "numbers", "short"}, "==> numbers <==\n1\n\n==> short <==\na\n", nil},
+		{"", []string{"-n", "1", "-q", "numbers", "short"}, "1\na\n", nil},
+		{"", []string{"-n", "1", "-v", "short"}, "==> short <==\na\n", nil},
+		{"input\nlines\n", []string{"-n", "1", "-", "short"}, "==> standard input <==\ninput\n\n==> short <==\na\n", nil},
+		{"input\nlines\n", []string{"-n", "-1"}, "input\n", nil},
+		{"", []string{"-n", "1", "missing", "short"}, "==> short <==\na\n", os.ErrNotExist},
+		{"", []string{"empty"}, "", nil},
+		{"", []string{"-n", "x", "numbers"}, "", ErrHeadInvalidValue},
+		{"", []string{"-c", "--", "numbers"}, "", ErrHeadInvalidValue},
+		{"", []string{"-n"}, "", ErrMissingValue},
+		{"", []string{"-x", "numbers"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Head test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Head{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+func ExampleHead_Execute() {
+	path, _ := os.Getwd()
+	head := Head{}
+	head.Execute(CommandProperties{path, nil, nil, os.Stdin, os.Stdout, []string{"-n", "1", "head.go"}})
+	// Output:
+	// package commands
+}
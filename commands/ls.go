@@ -1,9 +1,21 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
+)
+
+var (
+	// ErrLsTimeStyle indicates that the value of --time-style option is not supported
+	ErrLsTimeStyle = errors.New("invalid time style, valid styles are locale, iso, long-iso and full-iso")
+	// ErrLsColor indicates that the value of --color option is not one of auto, always and never
+	ErrLsColor = errors.New("invalid color mode, valid modes are auto, always and never")
 )
 
 // Ls is a structure for ls command, implementing ExecuteCommand interface
@@ -55,6 +67,59 @@ func (l *Ls) Execute(cp CommandProperties) error {
 	l.path = cp.Path
 	_, outputFile := cp.InputFile, cp.OutputFile
 
+	options, _, err := parseOptions(cp, "lnR", []string{"time-style=", "color", "recursive"})
+	if err != nil {
+		return err
+	}
+	timeStyle := "locale"
+	if options.has("time-style") {
+		timeStyle = options.value("time-style")
+	}
+	if isValidTimeStyle(timeStyle) == false {
+		return fmt.Errorf("%s - %w", timeStyle, ErrLsTimeStyle)
+	}
+	terminal := isTerminal(outputFile)
+	var colors *lsColors // colors is nil when the output should not be colored
+	if options.has("color") {
+		switch color := options.value("color"); color {
+		case "", "always", "yes", "force":
+			c := getLsColors()
+			colors = &c
+		case "auto", "tty", "if-tty":
+			if terminal == true {
+				c := getLsColors()
+				colors = &c
+			}
+		case "never", "no", "none":
+		default:
+			return fmt.Errorf("%s - %w", color, ErrLsColor)
+		}
+	} else if terminal == true { // by default colors are used only when the output is a terminal
+		c := getLsColors()
+		colors = &c
+	}
+
+	out := newStreamWriter(l, outputFile)
+	output := func(dir string, files []os.FileInfo) error { // dir is the directory, in which are the files
+		// -n is the same as -l but with numeric ids of owner and group
+		if options.has("l") || options.has("n") {
+			return l.outputLongFormat(out, dir, files, options.has("n"), timeStyle, colors)
+		}
+
+		if terminal == false { // when the output is a pipe or a file, every name is on separate line
+			for _, file := range files {
+				if err := out.WriteString(l.outputName(file, colors) + "\n"); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return l.outputColumns(out, files, terminalWidth(outputFile), colors)
+	}
+	if options.has("R") || options.has("recursive") {
+		return out.finish(l.outputRecursive(out, output))
+	}
+
 	path, err := os.Open(l.path)
 	if err != nil {
 		return err
@@ -64,82 +129,249 @@ func (l *Ls) Execute(cp CommandProperties) error {
 		return err
 	}
 	path.Close()
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name() < files[j].Name()
+	})
+	return out.finish(output(l.path, files))
+}
 
-	lOption := false
-	for _, option := range cp.Options {
-		if option == "l" {
-			lOption = true
+// outputRecursive is a method for writing the files in the current path and in all directories in it, like ls -R.
+// Before the files in every directory, its name is written.
+func (l *Ls) outputRecursive(out *streamWriter, output func(dir string, files []os.FileInfo) error) error {
+	var errs []error // in slice errs we collect the errors for the directories, which cannot be read
+	w := walker{command: l, sorted: true}
+	w.visit = func(entry *walkEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
 		}
+		return nil
 	}
-	if lOption == false {
-		for _, file := range files {
-			if err := checkWrite(l, outputFile, file.Name()); err != nil {
-				return err
-			}
-			if file.IsDir() {
-				if err := checkWrite(l, outputFile, string(os.PathSeparator)); err != nil {
-					return err
-				}
-			}
-			if err := checkWrite(l, outputFile, "    "); err != nil {
-				return err
+	w.list = func(dir *walkEntry, entries []*walkEntry) error {
+		header := joinFindPath(".", dir.relative) + ":\n"
+		if dir.depth > 0 {
+			header = "\n" + header
+		}
+		if err := out.WriteString(header); err != nil {
+			return err
+		}
+		files := make([]os.FileInfo, 0, len(entries))
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				errs = append(errs, err)
+				continue
 			}
+			files = append(files, info)
 		}
-		return nil
+		return output(dir.name, files)
 	}
 
-	var maxNumberOfDigs int // we count the maximum number of digits so column with file size will be "aligned" right
-	for _, file := range files {
-		if numberOfDigs := len(strconv.Itoa(int(file.Size()))); maxNumberOfDigs < numberOfDigs {
-			maxNumberOfDigs = numberOfDigs
+	if err := w.walk(l.path); err != nil && err != ErrStoppedExec {
+		errs = append(errs, err)
+	}
+	return joinErrors(errs)
+}
+
+// outputName is a method for getting the name of file as it is written by ls
+// Names of directories end with path separator and if colors is not nil, the name is colored
+func (l *Ls) outputName(file os.FileInfo, colors *lsColors) string {
+	name := file.Name()
+	if colors != nil {
+		name = colors.colorize(file, name)
+	}
+	if file.IsDir() {
+		name += string(os.PathSeparator)
+	}
+	return name
+}
+
+// outputColumns is a method for writing names of files in columns that fit in width
+//
+// Like in ls -C, the names are sorted down the columns and we choose the largest number of columns for which the rows fit in width.
+// Every column is as wide as its longest name and columns are separated by two spaces.
+func (l *Ls) outputColumns(out *streamWriter, files []os.FileInfo, width int, colors *lsColors) error {
+	if len(files) == 0 {
+		return nil
+	}
+	const separator = 2
+	lengths := make([]int, len(files)) // the number of visible characters of every name
+	for ind, file := range files {
+		lengths[ind] = utf8.RuneCountInString(file.Name())
+		if file.IsDir() {
+			lengths[ind]++
 		}
 	}
-	for _, file := range files {
-		if err := checkWrite(l, outputFile, file.Mode().String()); err != nil { // we write file mode
-			return err
+
+	maxColumns := (width + separator) / (1 + separator) // every column takes at least one character and the separator
+	if maxColumns > len(files) {
+		maxColumns = len(files)
+	}
+	var columnWidths []int
+	for columns := maxColumns; columns >= 1; columns-- {
+		rows := (len(files) + columns - 1) / columns
+		if (len(files)+rows-1)/rows != columns { // with this number of rows there would be empty columns
+			continue
 		}
-		if err := checkWrite(l, outputFile, " "); err != nil {
-			return err
+		columnWidths = make([]int, columns)
+		total := separator * (columns - 1)
+		for ind, length := range lengths {
+			if column := ind / rows; columnWidths[column] < length {
+				total += length - columnWidths[column]
+				columnWidths[column] = length
+			}
+		}
+		if total <= width || columns == 1 {
+			break
 		}
+	}
 
-		fileSize := strconv.Itoa(int(file.Size()))
-		for i := 0; i < (maxNumberOfDigs - len(fileSize)); i++ {
-			if err := checkWrite(l, outputFile, " "); err != nil {
-				return err
+	columns := len(columnWidths)
+	rows := (len(files) + columns - 1) / columns
+	for row := 0; row < rows; row++ {
+		var line string
+		for column := 0; column < columns; column++ {
+			ind := column*rows + row
+			if ind >= len(files) {
+				break
+			}
+			line += l.outputName(files[ind], colors)
+			if next := ind + rows; next < len(files) { // the last name on the row is not padded
+				line += strings.Repeat(" ", columnWidths[column]-lengths[ind]+separator)
 			}
 		}
-		if err := checkWrite(l, outputFile, strconv.Itoa(int(file.Size()))); err != nil { // we write file size
+		if err := out.WriteString(line + "\n"); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		if err := checkWrite(l, outputFile, " "); err != nil {
-			return err
+// outputLongFormat is a method for writing one row for every file with columns for mode, hard links count, owner, group, size, time and name.
+// The targets of the symbolic links are read from dir, which is the directory of the files.
+func (l *Ls) outputLongFormat(out *streamWriter, dir string, files []os.FileInfo, numericIds bool, timeStyle string, colors *lsColors) error {
+	type row struct {
+		mode, links, owner, group, size, time, name string
+	}
+	var rows []row
+	var maxLinks, maxOwner, maxGroup, maxSize int // we find the maximum width of every column so they will be aligned
+	names := make(map[string]string)              // cache for the names of owners and groups
+	now := time.Now()
+	for _, file := range files {
+		var r row
+		r.mode = fileModeString(file.Mode())
+		r.links, r.owner, r.group = fileOwnership(file, numericIds, names)
+		r.size = strconv.FormatInt(file.Size(), 10)
+		r.time = outputTime(file.ModTime(), now, timeStyle)
+		r.name = l.outputName(file, colors)
+		if file.Mode()&os.ModeSymlink != 0 {
+			if target, err := os.Readlink(FullFileName(dir, file.Name())); err == nil {
+				r.name += " -> " + target
+			}
 		}
-		if err := checkWrite(l, outputFile, outputTime(file.ModTime())); err != nil { // we write the data and time of last modification
-			return err
+
+		if maxLinks < len(r.links) {
+			maxLinks = len(r.links)
 		}
-		if err := checkWrite(l, outputFile, " "); err != nil {
-			return err
+		if maxOwner < len(r.owner) {
+			maxOwner = len(r.owner)
 		}
+		if maxGroup < len(r.group) {
+			maxGroup = len(r.group)
+		}
+		if maxSize < len(r.size) {
+			maxSize = len(r.size)
+		}
+		rows = append(rows, r)
+	}
 
-		if err := checkWrite(l, outputFile, file.Name()); err != nil { // lastly in row we write file name
+	for _, r := range rows {
+		line := r.mode + " " + strings.Repeat(" ", maxLinks-len(r.links)) + r.links + " "
+		line += r.owner + strings.Repeat(" ", maxOwner-len(r.owner)) + " "
+		line += r.group + strings.Repeat(" ", maxGroup-len(r.group)) + " "
+		line += strings.Repeat(" ", maxSize-len(r.size)) + r.size + " " // file size is aligned right
+		line += r.time + " " + r.name + "\n"
+		if err := out.WriteString(line); err != nil {
 			return err
 		}
-		if file.IsDir() {
-			if err := checkWrite(l, outputFile, string(os.PathSeparator)); err != nil {
-				return err
-			}
+	}
+	return nil
+}
+
+// fileModeString function is helper for writing file mode in the way ls does it, for example drwxr-xr-x or -rwsr-xr-x
+func fileModeString(mode os.FileMode) string {
+	var output []byte
+	switch {
+	case mode&os.ModeDir != 0:
+		output = append(output, 'd')
+	case mode&os.ModeSymlink != 0:
+		output = append(output, 'l')
+	case mode&os.ModeNamedPipe != 0:
+		output = append(output, 'p')
+	case mode&os.ModeSocket != 0:
+		output = append(output, 's')
+	case mode&os.ModeCharDevice != 0:
+		output = append(output, 'c')
+	case mode&os.ModeDevice != 0:
+		output = append(output, 'b')
+	default:
+		output = append(output, '-')
+	}
+
+	const rwx = "rwxrwxrwx"
+	for i := 0; i < 9; i++ {
+		if mode&(1<<uint(8-i)) != 0 {
+			output = append(output, rwx[i])
+		} else {
+			output = append(output, '-')
 		}
-		if err := checkWrite(l, outputFile, "\n"); err != nil {
-			return err
+	}
+	// special bits replace the execute bits - small letter when execute bit is set, capital letter otherwise
+	special := func(ind int, char byte) {
+		if output[ind] == 'x' {
+			output[ind] = char
+		} else {
+			output[ind] = char - 'a' + 'A'
 		}
 	}
+	if mode&os.ModeSetuid != 0 {
+		special(3, 's')
+	}
+	if mode&os.ModeSetgid != 0 {
+		special(6, 's')
+	}
+	if mode&os.ModeSticky != 0 {
+		special(9, 't')
+	}
+	return string(output)
+}
 
-	return nil
+// isValidTimeStyle function is helper for checking if style is one of the supported values of --time-style option
+func isValidTimeStyle(style string) bool {
+	switch style {
+	case "locale", "iso", "long-iso", "full-iso":
+		return true
+	}
+	return false
 }
 
-// outputTime function is helper for writing time and date in format hh:mm dd mmm
-func outputTime(t time.Time) string {
+// outputTime function is helper for writing time and date in format hh:mm dd mmm or in one of the iso formats, depending on style
+// Like in ls, for times older than six months or in the future the year is written instead of hh:mm
+func outputTime(t time.Time, now time.Time, style string) string {
+	const sixMonths = time.Duration(365*24*time.Hour+6*time.Hour) / 2 // half of the average year
+	recent := t.After(now.Add(-sixMonths)) && !t.After(now)
+
+	switch style {
+	case "full-iso":
+		return t.Format("2006-01-02 15:04:05.000000000 -0700")
+	case "long-iso":
+		return t.Format("2006-01-02 15:04")
+	case "iso":
+		if recent {
+			return t.Format("01-02 15:04")
+		}
+		return t.Format("2006-01-02 ")
+	}
+
 	outputNumber := func(num string) string { // another helper function for writing one-digit number with leading zero
 		var output string
 		if len(num) == 1 {
@@ -149,7 +381,13 @@ func outputTime(t time.Time) string {
 		return output
 	}
 
-	output := outputNumber(strconv.Itoa(t.Hour())) + ":" + outputNumber(strconv.Itoa(t.Minute())) + " "
+	var output string
+	if recent {
+		output = outputNumber(strconv.Itoa(t.Hour())) + ":" + outputNumber(strconv.Itoa(t.Minute())) + " "
+	} else {
+		year := strconv.Itoa(t.Year())
+		output = strings.Repeat(" ", 5-len(year)) + year + " " // the year takes the place of hh:mm
+	}
 	output += outputNumber(strconv.Itoa(t.Day())) + " "
 	month := t.Month().String()
 	output += month[:3]
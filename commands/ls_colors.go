@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"os"
+	"strings"
+)
+
+// defaultLsColors is used when LS_COLORS environment variable is not set, the format is the same as the one of LS_COLORS
+const defaultLsColors = "di=01;34:ln=01;36:pi=40;33:so=01;35:bd=40;33;01:cd=40;33;01:ex=01;32:" +
+	"*.tar=01;31:*.tgz=01;31:*.zip=01;31:*.gz=01;31:*.bz2=01;31:*.xz=01;31:*.zst=01;31:" +
+	"*.7z=01;31:*.rar=01;31:*.jar=01;31:*.deb=01;31:*.rpm=01;31"
+
+// lsColors stores the colors for file types and for file name suffixes
+type lsColors struct {
+	types    map[string]string // keys are the two-letter file types like di, ln and ex
+	suffixes map[string]string // keys are the suffixes from patterns like *.tar
+}
+
+// parseLsColors function parses value in the format of LS_COLORS environment variable.
+// The value is a list of key=color separated by ':', where key is either file type or pattern *suffix.
+// Entries with unknown format are skipped.
+func parseLsColors(value string) lsColors {
+	colors := lsColors{make(map[string]string), make(map[string]string)}
+	for _, entry := range strings.Split(value, ":") {
+		ind := strings.IndexByte(entry, '=')
+		if ind == -1 {
+			continue
+		}
+		key, color := entry[:ind], entry[ind+1:]
+		if len(key) > 1 && key[0] == '*' {
+			colors.suffixes[key[1:]] = color
+		} else {
+			colors.types[key] = color
+		}
+	}
+	return colors
+}
+
+// getLsColors function returns the colors from LS_COLORS environment variable or the default colors if it is not set
+func getLsColors() lsColors {
+	if value, ok := os.LookupEnv("LS_COLORS"); ok == true {
+		return parseLsColors(value)
+	}
+	return parseLsColors(defaultLsColors)
+}
+
+// colorize is a method for surrounding name with the escape sequences for the color of file.
+// If there is no color for the file, name is returned unchanged.
+func (c lsColors) colorize(file os.FileInfo, name string) string {
+	var fileType string
+	mode := file.Mode()
+	switch {
+	case mode&os.ModeDir != 0:
+		fileType = "di"
+	case mode&os.ModeSymlink != 0:
+		fileType = "ln"
+	case mode&os.ModeNamedPipe != 0:
+		fileType = "pi"
+	case mode&os.ModeSocket != 0:
+		fileType = "so"
+	case mode&os.ModeCharDevice != 0:
+		fileType = "cd"
+	case mode&os.ModeDevice != 0:
+		fileType = "bd"
+	case mode&0111 != 0:
+		fileType = "ex"
+	default:
+		fileType = "fi"
+	}
+
+	color := c.types[fileType]
+	if fileType == "fi" {
+		longest := 0 // the longest matching suffix wins
+		for suffix, suffixColor := range c.suffixes {
+			if len(suffix) > longest && strings.HasSuffix(file.Name(), suffix) {
+				color, longest = suffixColor, len(suffix)
+			}
+		}
+	}
+	if color == "" {
+		return name
+	}
+	return "\x1b[" + color + "m" + name + "\x1b[0m"
+}
@@ -0,0 +1,41 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// fileOwnership function is helper for getting the number of hard links, the owner and the group of file.
+// The ids of owner and group are resolved to names unless numericIds is true or the lookup fails.
+// The names map is used as cache for the lookups, because usually most files have the same owner.
+func fileOwnership(file os.FileInfo, numericIds bool, names map[string]string) (string, string, string) {
+	stat, ok := file.Sys().(*syscall.Stat_t)
+	if ok == false {
+		return "?", "?", "?"
+	}
+	links := strconv.FormatUint(uint64(stat.Nlink), 10)
+	uid := strconv.FormatUint(uint64(stat.Uid), 10)
+	gid := strconv.FormatUint(uint64(stat.Gid), 10)
+	if numericIds == true {
+		return links, uid, gid
+	}
+
+	if _, ok := names["u"+uid]; ok == false {
+		names["u"+uid] = uid
+		if u, err := user.LookupId(uid); err == nil {
+			names["u"+uid] = u.Username
+		}
+	}
+	if _, ok := names["g"+gid]; ok == false {
+		names["g"+gid] = gid
+		if g, err := user.LookupGroupId(gid); err == nil {
+			names["g"+gid] = g.Name
+		}
+	}
+	return links, names["u"+uid], names["g"+gid]
+}
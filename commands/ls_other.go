@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+import "os"
+
+// fileOwnership function is helper for getting the number of hard links, the owner and the group of file.
+// This information is available only on Linux, so here it is unknown.
+func fileOwnership(file os.FileInfo, numericIds bool, names map[string]string) (string, string, string) {
+	return "?", "?", "?"
+}
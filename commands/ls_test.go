@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLs(t *testing.T) {
@@ -25,6 +31,9 @@ func TestLs(t *testing.T) {
 		os.Remove(pathFile)
 		os.Remove(path)
 	}()
+	if err := os.Chmod(pathFile, 0666); err != nil { // we don't want the result to depend on umask
+		t.Fatal("Fatal error - cannot change mode of new-file! - %w", err)
+	}
 
 	r, w, err := os.Pipe()
 	if err != nil {
@@ -32,8 +41,8 @@ func TestLs(t *testing.T) {
 	}
 
 	ls := Ls{}
-	if err := ls.Execute(CommandProperties{path, []string{}, []string{"l"}, os.Stdin, w}); err != nil {
-		t.Errorf("Expecting no error from Ls function, but got: %w\n", err)
+	if err := ls.Execute(CommandProperties{path, []string{}, []string{"l"}, os.Stdin, w, nil}); err != nil {
+		t.Errorf("Expecting no error from Ls function, but got: %v\n", err)
 		return
 	}
 
@@ -41,29 +50,273 @@ func TestLs(t *testing.T) {
 	if _, err := r.Read(output); err != nil {
 		t.Fatal("Fatal error - cannot read from pipe! - %w", err)
 	}
-	parts := strings.Split(strings.SplitN(string(output), "\n", 2)[0], " ")
-	if len(parts) != 6 { // format of ls -l should have 6 columns
-		t.Errorf("Expecting 6 columns, but got: %s", output)
+	parts := strings.Fields(strings.SplitN(string(output), "\n", 2)[0])
+	if len(parts) != 9 { // format of ls -l should have 9 columns
+		t.Errorf("Expecting 9 columns, but got: %s", output)
 		return
 	}
-	if parts[0] != "-rw-rw-rw-" { // default open mode for file is -rw-rw-rw-
+	if parts[0] != "-rw-rw-rw-" {
 		t.Errorf("Expecting -rw-rw-rw, but got: %s", parts[0])
 		return
 	}
-	if parts[1] != "0" {
-		t.Errorf("Expecting empty file, but file is with size %s", parts[1])
+	if parts[1] != "1" {
+		t.Errorf("Expecting 1 hard link, but got: %s", parts[1])
+		return
+	}
+	if parts[4] != "0" {
+		t.Errorf("Expecting empty file, but file is with size %s", parts[4])
 		return
 	}
-	// we only check the length of parts[2:4] which should be respectively in format hh:mm dd mmm
-	if len(parts[2]) != 5 || len(parts[3]) != 2 || len(parts[4]) != 3 {
-		t.Errorf("Expecting hh:mm dd mmm format of time and data, but got: %s %s %s", parts[2], parts[3], parts[4])
+	// we only check the length of parts[5:8] which should be respectively in format hh:mm dd mmm
+	if len(parts[5]) != 5 || len(parts[6]) != 2 || len(parts[7]) != 3 {
+		t.Errorf("Expecting hh:mm dd mmm format of time and data, but got: %s %s %s", parts[5], parts[6], parts[7])
 		return
 	}
-	if parts[5] != "new-file" {
-		t.Errorf("Expecting file name to be new-file, but got: %s", parts[5])
+	if parts[8] != "new-file" {
+		t.Errorf("Expecting file name to be new-file, but got: %s", parts[8])
+	}
+}
+
+func TestLsLongFormat(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		t.Fatal("Fatal error - cannot get current path! - %w", err)
+	}
+	path += string(os.PathSeparator) + "example-dir"
+	if err := os.Mkdir(path, 0777); err != nil {
+		t.Fatal("Fatal error - cannot make directory in current path! - %w", err)
+	}
+	defer os.RemoveAll(path)
+	lsColors, lsColorsSet := os.LookupEnv("LS_COLORS") // we use the default colors
+	os.Unsetenv("LS_COLORS")
+	defer func() {
+		if lsColorsSet == true {
+			os.Setenv("LS_COLORS", lsColors)
+		}
+	}()
+	pathLink := path + string(os.PathSeparator) + "link"
+	if err := os.Symlink("target", pathLink); err != nil {
+		t.Fatal("Fatal error - cannot make symbolic link! - %w", err)
+	}
+
+	var tests = []struct {
+		options []string
+		fields  []int // indices of the fields of the row that are checked
+		result  []string
+		err     error
+	}{
+		{[]string{"l"}, []int{0, 8, 9, 10}, []string{"lrwxrwxrwx", "link", "->", "target"}, nil},
+		{[]string{"n"}, []int{2, 3}, []string{strconv.Itoa(os.Getuid()), strconv.Itoa(os.Getgid())}, nil},
+		{[]string{"l", "-time-style=long-iso"}, []int{5}, []string{time.Now().Format("2006-01-02")}, nil},
+		{[]string{"l", "-time-style=unknown"}, nil, nil, ErrLsTimeStyle},
+		{[]string{"l", "-color=always"}, []int{8}, []string{"\x1b[01;36mlink\x1b[0m"}, nil},
+		{[]string{"l", "-color=auto"}, []int{8}, []string{"link"}, nil},
+		{[]string{"-color=sometimes"}, nil, nil, ErrLsColor},
+		{[]string{"lz"}, nil, nil, ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Ls test with options %v", test.options), func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal("Fatal error - cannot make pipe! - %w", err)
+			}
+			defer r.Close()
+
+			ls := Ls{}
+			errLs := ls.Execute(CommandProperties{path, []string{}, test.options, os.Stdin, w, nil})
+			w.Close()
+			if errLs != nil || test.err != nil {
+				if !errors.Is(errLs, test.err) {
+					t.Errorf("Expecting error %v, but got: %v", test.err, errLs)
+				}
+				return
+			}
+
+			output, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatal("Fatal error - cannot read from pipe! - %w", err)
+			}
+			parts := strings.Fields(string(output))
+			for ind, field := range test.fields {
+				if field >= len(parts) || parts[field] != test.result[ind] {
+					t.Errorf("Expecting %s in column %d, but got: %s", test.result[ind], field, output)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputTime(t *testing.T) {
+	now := time.Date(2021, time.February, 10, 12, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		time   time.Time
+		style  string
+		result string
+	}{
+		{time.Date(2021, time.February, 3, 9, 5, 0, 0, time.UTC), "locale", "09:05 03 Feb"},
+		{time.Date(2020, time.March, 3, 9, 5, 0, 0, time.UTC), "locale", " 2020 03 Mar"},
+		{time.Date(2021, time.March, 3, 9, 5, 0, 0, time.UTC), "locale", " 2021 03 Mar"},
+		{time.Date(2021, time.February, 3, 9, 5, 0, 0, time.UTC), "iso", "02-03 09:05"},
+		{time.Date(2020, time.March, 3, 9, 5, 0, 0, time.UTC), "iso", "2020-03-03 "},
+		{time.Date(2020, time.March, 3, 9, 5, 0, 0, time.UTC), "long-iso", "2020-03-03 09:05"},
+		{time.Date(2020, time.March, 3, 9, 5, 0, 0, time.UTC), "full-iso", "2020-03-03 09:05:00.000000000 +0000"},
+	}
+	for _, test := range tests {
+		if result := outputTime(test.time, now, test.style); result != test.result {
+			t.Errorf("Expecting %q for %v with style %s, but got: %q", test.result, test.time, test.style, result)
+		}
 	}
 }
 
+func TestFileModeString(t *testing.T) {
+	var tests = []struct {
+		mode   os.FileMode
+		result string
+	}{
+		{0644, "-rw-r--r--"},
+		{os.ModeDir | 0755, "drwxr-xr-x"},
+		{os.ModeSymlink | 0777, "lrwxrwxrwx"},
+		{os.ModeSetuid | 0755, "-rwsr-xr-x"},
+		{os.ModeSetgid | 0644, "-rw-r-Sr--"},
+		{os.ModeDir | os.ModeSticky | 0777, "drwxrwxrwt"},
+	}
+	for _, test := range tests {
+		if result := fileModeString(test.mode); result != test.result {
+			t.Errorf("Expecting %s, but got: %s", test.result, result)
+		}
+	}
+}
+
+func TestLsColumns(t *testing.T) {
+	var tests = []struct {
+		names  []string
+		width  int
+		result string
+	}{
+		{[]string{"a", "b", "c"}, 80, "a  b  c\n"},
+		{[]string{"a", "b", "c"}, 4, "a  c\nb\n"},
+		{[]string{"a", "b", "c"}, 2, "a\nb\nc\n"},
+		{[]string{"long-name", "b", "c", "d", "e"}, 14, "long-name  d\nb          e\nc\n"},
+		{[]string{"ъгъл", "b"}, 7, "ъгъл  b\n"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Ls columns test with names %v and width %d", test.names, test.width), func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal("Fatal error - cannot make pipe! - %w", err)
+			}
+			defer r.Close()
+
+			var files []os.FileInfo
+			for _, name := range test.names {
+				files = append(files, testFileInfo{name, 0644})
+			}
+			ls := Ls{}
+			out := newStreamWriter(&ls, w)
+			if err := out.finish(ls.outputColumns(out, files, test.width, nil)); err != nil {
+				t.Errorf("Expecting no error, but got: %v", err)
+			}
+			w.Close()
+			output, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatal("Fatal error - cannot read from pipe! - %w", err)
+			}
+			if string(output) != test.result {
+				t.Errorf("Expecting %q, but got: %q", test.result, output)
+			}
+		})
+	}
+}
+
+func TestLsRecursive(t *testing.T) {
+	path, err := ioutil.TempDir("", "ls-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a": "", "b/c": "", "b/d/e": "", "f/": ""})
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	ls := Ls{}
+	ls.InitStopSignalCatching()
+	if err := ls.Execute(CommandProperties{path, nil, nil, os.Stdin, w, []string{"-R"}}); err != nil {
+		t.Errorf("Expecting no error, but got: %v", err)
+	}
+	w.Close()
+	output, _ := ioutil.ReadAll(r)
+	expected := ".:\na\nb/\nf/\n\n./b:\nc\nd/\n\n./b/d:\ne\n\n./f:\n"
+	if expected = strings.Replace(expected, "/", string(os.PathSeparator), -1); string(output) != expected {
+		t.Errorf("Expecting %q, but got: %q", expected, output)
+	}
+}
+
+func TestLsRecursiveLinks(t *testing.T) {
+	path, err := ioutil.TempDir("", "ls-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"dir/": ""})
+	for link, target := range map[string]string{"top": "top-target", "dir/inner": "inner-target"} {
+		if err := os.Symlink(target, filepath.Join(path, link)); err != nil {
+			t.Fatalf("Fatal error - cannot make symbolic link! - %v", err)
+		}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	ls := Ls{}
+	ls.InitStopSignalCatching()
+	if err := ls.Execute(CommandProperties{path, nil, nil, os.Stdin, w, []string{"-lR"}}); err != nil {
+		t.Errorf("Expecting no error, but got: %v", err)
+	}
+	w.Close()
+	output, _ := ioutil.ReadAll(r)
+	for _, expected := range []string{" top -> top-target\n", " inner -> inner-target\n"} {
+		if strings.Contains(string(output), expected) == false {
+			t.Errorf("Expecting output with %q, but got: %q", expected, output)
+		}
+	}
+}
+
+func TestLsColors(t *testing.T) {
+	colors := parseLsColors("di=01;34:ex=01;32:*.tar=01;31:*.tar.gz=00;33:invalid")
+	var tests = []struct {
+		file   os.FileInfo
+		result string
+	}{
+		{testFileInfo{"dir", os.ModeDir | 0755}, "\x1b[01;34mdir\x1b[0m"},
+		{testFileInfo{"script", 0755}, "\x1b[01;32mscript\x1b[0m"},
+		{testFileInfo{"a.tar", 0644}, "\x1b[01;31ma.tar\x1b[0m"},
+		{testFileInfo{"a.tar.gz", 0644}, "\x1b[00;33ma.tar.gz\x1b[0m"},
+		{testFileInfo{"file", 0644}, "file"},
+		{testFileInfo{"link", os.ModeSymlink | 0777}, "link"},
+	}
+	for _, test := range tests {
+		if result := colors.colorize(test.file, test.file.Name()); result != test.result {
+			t.Errorf("Expecting %q, but got: %q", test.result, result)
+		}
+	}
+}
+
+// testFileInfo is implementation of os.FileInfo used for testing functions which only need name and mode of file
+type testFileInfo struct {
+	name string
+	mode os.FileMode
+}
+
+func (f testFileInfo) Name() string       { return f.name }
+func (f testFileInfo) Size() int64        { return 0 }
+func (f testFileInfo) Mode() os.FileMode  { return f.mode }
+func (f testFileInfo) ModTime() time.Time { return time.Time{} }
+func (f testFileInfo) IsDir() bool        { return f.mode.IsDir() }
+func (f testFileInfo) Sys() interface{}   { return nil }
+
 func ExampleLs_Execute() {
 	path, _ := os.Getwd()
 	path += string(os.PathSeparator) + "example-dir"
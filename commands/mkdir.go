@@ -4,14 +4,22 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 var (
 	// ErrMkdirNoArgs indicates that there were no arguments passed to mkdir command
 	ErrMkdirNoArgs = errors.New("At least one argument is needed")
-	// ErrMkdirExists indicates that argument is name of file
+	// ErrMkdirExists indicates that argument is name of existing file or directory
 	ErrMkdirExists = errors.New("exists")
+	// ErrMkdirNotDir indicates that argument or one of its parents is name of file, which is not a directory
+	ErrMkdirNotDir = errors.New("exists, but it is not a directory")
+	// ErrMkdirNoParent indicates that the parent directory of argument does not exist, the option -p makes it
+	ErrMkdirNoParent = errors.New("parent directory does not exist")
+	// ErrMkdirInvalidMode indicates that the mode given with -m is not valid octal or symbolic mode
+	ErrMkdirInvalidMode = errors.New("invalid mode")
 )
 
 // Mkdir is a structure for mkdir command, implementing ExecuteCommand interface
@@ -61,38 +69,235 @@ func (m *Mkdir) IsStopSignalReceived() bool {
 // Execute is go implementation of mkdir command
 func (m *Mkdir) Execute(cp CommandProperties) error {
 	m.path = cp.Path
+	_, outputFile := cp.InputFile, cp.OutputFile
 
-	if len(cp.Arguments) == 0 {
-		return ErrRmNoArgs
+	options, arguments, err := parseOptions(cp, "pvm:", []string{"parents", "verbose", "mode="})
+	if err != nil {
+		return err
+	}
+	if len(arguments) == 0 {
+		return ErrMkdirNoArgs
+	}
+	parents := options.has("p") || options.has("parents")
+	verbose := options.has("v") || options.has("verbose")
+
+	umask := getUmask()
+	var mode os.FileMode
+	modeText := options.value("m")
+	if options.has("mode") {
+		modeText = options.value("mode")
+	}
+	if modeText != "" {
+		if mode, err = parseMode(modeText, 0777, umask, true); err != nil {
+			return err
+		}
 	}
 
-	var errStrings []string // in slice errStrings we collect all the errors
-	for _, argument := range cp.Arguments {
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range arguments {
 		if m.IsStopSignalReceived() == true {
-			if len(errStrings) > 0 {
-				if len(errStrings) > 0 {
-					return errors.New(strings.Join(errStrings, "\n"))
+			break
+		}
+
+		fullName := FullFileName(m.path, argument)
+		var created []string // created stores the names of all made directories
+		var err error        // every argument has its own error, so the errors of the previous arguments do not stop it
+		if parents == true {
+			created, err = m.makeParents(fullName, umask)
+		}
+		if err == nil {
+			err = m.makeDir(fullName, parents, mode, modeText != "")
+			if err == nil {
+				created = append(created, fullName)
+			} else if parents == true && errors.Is(err, ErrMkdirExists) {
+				err = nil // with -p it is not an error if the directory exists
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if verbose == true {
+			for _, name := range created {
+				if err := checkWrite(m, outputFile, "created directory '"+name+"'\n"); err != nil {
+					return err
 				}
-				return nil
 			}
 		}
+	}
 
-		fullName := FullFileName(m.path, argument)
-		_, err := os.Stat(fullName)
+	return joinErrors(errs)
+}
 
-		if os.IsNotExist(err) {
-			if err := os.Mkdir(fullName, 0666); err != nil {
-				errStrings = append(errStrings, err.Error())
+// makeParents is a method for making the missing parent directories of fullName, it returns their names.
+// Like in mkdir, the parent directories have the default mode with added write and execute permissions for the owner.
+func (m *Mkdir) makeParents(fullName string, umask os.FileMode) ([]string, error) {
+	var missing []string
+	for dir := filepath.Dir(fullName); ; dir = filepath.Dir(dir) {
+		stat, err := os.Stat(dir)
+		if err == nil {
+			if stat.IsDir() == false {
+				return nil, fmt.Errorf("%s - %w", dir, ErrMkdirNotDir)
 			}
-		} else if err != nil {
-			errStrings = append(errStrings, err.Error())
-		} else {
-			errStrings = append(errStrings, fmt.Errorf("%s - %w", fullName, ErrMkdirExists).Error())
+			break
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		missing = append(missing, dir)
+		if dir == filepath.Dir(dir) {
+			break
 		}
 	}
 
-	if len(errStrings) > 0 {
-		return errors.New(strings.Join(errStrings, "\n"))
+	var created []string
+	for ind := len(missing) - 1; ind >= 0; ind-- {
+		dir := missing[ind]
+		if err := os.Mkdir(dir, 0777); err != nil {
+			if os.IsExist(err) { // the directory may be made by another command in the meantime
+				continue
+			}
+			return created, err
+		}
+		if umask&0300 != 0 {
+			if err := os.Chmod(dir, (0777&^umask)|0300); err != nil {
+				return created, err
+			}
+		}
+		created = append(created, dir)
+	}
+	return created, nil
+}
+
+// makeDir is a method for making directory with fullName, which gets mode if modeSet is true, otherwise the default mode
+func (m *Mkdir) makeDir(fullName string, parents bool, mode os.FileMode, modeSet bool) error {
+	stat, err := os.Stat(fullName)
+	if err == nil {
+		if stat.IsDir() == false {
+			return fmt.Errorf("%s - %w", fullName, ErrMkdirNotDir)
+		}
+		return fmt.Errorf("%s - %w", fullName, ErrMkdirExists)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Mkdir(fullName, 0777); err != nil { // the mode is masked by umask
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s - %w", fullName, ErrMkdirNoParent)
+		}
+		return err
+	}
+	if modeSet == true { // the mode given with -m is not masked by umask
+		return os.Chmod(fullName, mode)
 	}
 	return nil
 }
+
+// parseMode function is helper for parsing mode given in octal or symbolic format like in chmod.
+//
+// The symbolic format is list of clauses separated by ',' like "u=rwx,g+w,o-r".
+// Every clause has who letters from "ugoa" and one or more operations, which are one of "+-=" followed by permissions from "rwxXst" or one of "ugo" for copying permissions.
+// The operations are applied to base. When there are no who letters, the operations are for all, but the bits in umask are not changed.
+func parseMode(text string, base os.FileMode, umask os.FileMode, isDir bool) (os.FileMode, error) {
+	invalid := fmt.Errorf("%s - %w", text, ErrMkdirInvalidMode)
+	if text == "" {
+		return 0, invalid
+	}
+	if value, err := strconv.ParseUint(text, 8, 32); err == nil {
+		if value > 07777 {
+			return 0, invalid
+		}
+		return unixToFileMode(uint32(value)), nil
+	}
+
+	mode, mask := fileModeToUnix(base), fileModeToUnix(umask)
+	for _, clause := range strings.Split(text, ",") {
+		ind := 0
+		var who uint32
+		for ; ind < len(clause) && strings.IndexByte("ugoa", clause[ind]) != -1; ind++ {
+			who |= map[byte]uint32{'u': 04700, 'g': 02070, 'o': 01007, 'a': 07777}[clause[ind]]
+		}
+		affected := who // bits that can be changed by the operations in the clause
+		if who == 0 {
+			who, affected = 07777, 07777&^mask
+		}
+		if ind == len(clause) {
+			return 0, invalid
+		}
+
+		for ind < len(clause) {
+			op := clause[ind]
+			if strings.IndexByte("+-=", op) == -1 {
+				return 0, invalid
+			}
+			ind++
+
+			var perm uint32
+			if ind < len(clause) && strings.IndexByte("ugo", clause[ind]) != -1 {
+				shift := map[byte]uint{'u': 6, 'g': 3, 'o': 0}[clause[ind]]
+				perm = (mode >> shift & 7) * 0111 // the permissions of one class are copied to all classes
+				ind++
+			} else {
+				for ; ind < len(clause) && strings.IndexByte("rwxXst", clause[ind]) != -1; ind++ {
+					switch clause[ind] {
+					case 'r':
+						perm |= 0444
+					case 'w':
+						perm |= 0222
+					case 'x':
+						perm |= 0111
+					case 'X': // execute only for directories or files which have execute permission for someone
+						if isDir == true || mode&0111 != 0 {
+							perm |= 0111
+						}
+					case 's':
+						perm |= 06000
+					case 't':
+						perm |= 01000
+					}
+				}
+			}
+
+			switch op {
+			case '+':
+				mode |= perm & affected
+			case '-':
+				mode &^= perm & affected
+			case '=':
+				mode = mode&^who | perm&affected
+			}
+		}
+	}
+	return unixToFileMode(mode), nil
+}
+
+// unixToFileMode function is helper for converting Unix permission bits to os.FileMode
+func unixToFileMode(bits uint32) os.FileMode {
+	mode := os.FileMode(bits & 0777)
+	if bits&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if bits&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if bits&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+// fileModeToUnix function is helper for converting os.FileMode to Unix permission bits
+func fileModeToUnix(mode os.FileMode) uint32 {
+	bits := uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		bits |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		bits |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		bits |= 01000
+	}
+	return bits
+}
@@ -0,0 +1,32 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// getUmask function is helper for getting the file mode creation mask of the process.
+// It is read from /proc/self/status, because changing the mask to read it would affect other commands running at the same time.
+// If it cannot be read, the mask is read by setting it and restoring it immediately.
+func getUmask() os.FileMode {
+	if file, err := os.Open("/proc/self/status"); err == nil {
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if line := scanner.Text(); strings.HasPrefix(line, "Umask:") {
+				if mask, err := strconv.ParseUint(strings.TrimSpace(line[len("Umask:"):]), 8, 32); err == nil {
+					return os.FileMode(mask)
+				}
+			}
+		}
+	}
+	mask := syscall.Umask(0)
+	syscall.Umask(mask)
+	return os.FileMode(mask)
+}
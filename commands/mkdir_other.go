@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+import "os"
+
+// getUmask function is helper for getting the file mode creation mask of the process.
+// On systems without umask nothing is masked.
+func getUmask() os.FileMode {
+	return 0
+}
@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -50,6 +54,92 @@ func TestMkdir(t *testing.T) {
 	}
 }
 
+func TestMkdirOptions(t *testing.T) {
+	umask := getUmask()
+	var tests = []struct {
+		words  []string // words are the options and the arguments in the order they are written
+		output string
+		err    error
+		modes  map[string]os.FileMode // expected modes of made directories
+	}{
+		{[]string{"a/b"}, "", ErrMkdirNoParent, nil},
+		{[]string{"-p", "a/b/c"}, "", nil, map[string]os.FileMode{"a/b/c": 0777 &^ umask}},
+		{[]string{"-pv", "a/b", "a"}, "created directory '{}a'\ncreated directory '{}a/b'\n", nil, nil},
+		{[]string{"-p", "file/a"}, "", ErrMkdirNotDir, nil},
+		{[]string{"-p", "file"}, "", ErrMkdirNotDir, nil},
+		{[]string{"-v", "file", "a"}, "created directory '{}a'\n", ErrMkdirNotDir, map[string]os.FileMode{"a": 0777 &^ umask}},
+		{[]string{"-m", "700", "a"}, "", nil, map[string]os.FileMode{"a": 0700}},
+		{[]string{"a", "-m777"}, "", nil, map[string]os.FileMode{"a": 0777}},
+		{[]string{"--mode=u=rwx,go=rx", "a"}, "", nil, map[string]os.FileMode{"a": 0755}},
+		{[]string{"-pm", "g-w", "a/b"}, "", nil, map[string]os.FileMode{"a/b": 0757}},
+		{[]string{"-m", "9", "a"}, "", ErrMkdirInvalidMode, nil},
+		{[]string{"a", "-m"}, "", ErrMissingValue, nil},
+		{[]string{"-x", "a"}, "", ErrInvalidOption, nil},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Mkdir test with words %v", test.words), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "mkdir-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"file": ""})
+
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			mkdir := Mkdir{}
+			errMkdir := mkdir.Execute(CommandProperties{path, nil, nil, os.Stdin, w, test.words})
+			w.Close()
+			if !errors.Is(errMkdir, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errMkdir)
+			}
+			output, _ := ioutil.ReadAll(r)
+			if expected := strings.Replace(test.output, "{}", path+string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+			for name, mode := range test.modes {
+				if stat, err := os.Stat(filepath.Join(path, name)); err != nil || stat.Mode().Perm() != mode {
+					t.Errorf("Expected %s with mode %v, but got: %v %v", name, mode, stat, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	var tests = []struct {
+		text   string
+		base   os.FileMode
+		umask  os.FileMode
+		isDir  bool
+		result os.FileMode
+		err    error
+	}{
+		{"755", 0777, 022, true, 0755, nil},
+		{"1777", 0777, 022, true, os.ModeSticky | 0777, nil},
+		{"17777", 0777, 022, true, 0, ErrMkdirInvalidMode},
+		{"u=rwx,g=rx,o=", 0777, 022, true, 0750, nil},
+		{"go-w", 0777, 022, true, 0755, nil},
+		{"-w", 0777, 022, true, 0577, nil},
+		{"=rw", 0777, 022, false, 0644, nil},
+		{"a=r,u+w", 0777, 0, false, 0644, nil},
+		{"g=u", 0700, 0, false, 0770, nil},
+		{"a-x,a+X", 0744, 0, true, 0755, nil},
+		{"a=r,a+X", 0700, 0, false, 0444, nil},
+		{"u+s,+t", 0755, 0, true, os.ModeSetuid | os.ModeSticky | 0755, nil},
+		{"u", 0777, 0, true, 0, ErrMkdirInvalidMode},
+		{"u*x", 0777, 0, true, 0, ErrMkdirInvalidMode},
+	}
+	for _, test := range tests {
+		result, err := parseMode(test.text, test.base, test.umask, test.isDir)
+		if !errors.Is(err, test.err) || result != test.result {
+			t.Errorf("Expecting %v and error %v for mode %s, but got: %v and %v", test.result, test.err, test.text, result, err)
+		}
+	}
+}
+
 func ExampleMkdir_Execute() {
 	path, _ := os.Getwd()
 	mkdir := Mkdir{}
@@ -3,16 +3,27 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 )
 
 var (
-	// ErrMvTwoArgs indicates that the argument count is different from two
-	ErrMvTwoArgs = errors.New("Two arguments are needed")
+	// ErrMvTwoArgs indicates that the argument count is less than two
+	ErrMvTwoArgs = errors.New("At least two arguments are needed")
 	// ErrMvInvalidName indicates that source argument is not a valid name in file system
 	ErrMvInvalidName = errors.New("is not a valid name in the file system")
-	// ErrMvIsDir indicates that source argument is name of directory
+	// ErrMvIsDir indicates that file should overwrite directory
 	ErrMvIsDir = errors.New("is a directory")
+	// ErrMvNotDir indicates that the target is not a directory, but it should be
+	ErrMvNotDir = errors.New("is not a directory")
+	// ErrMvIntoItself indicates that directory should be moved inside itself
+	ErrMvIntoItself = errors.New("cannot move a directory into itself")
+	// ErrMvBackup indicates that the value of --backup option is not valid
+	ErrMvBackup = errors.New("invalid backup type, valid types are none, numbered, existing and simple")
 	// ErrMvSame indicates that source and target are the same file
 	ErrMvSame = errors.New("Source and target file are the same")
 )
@@ -21,6 +32,14 @@ var (
 type Mv struct {
 	path          string
 	stopExecution chan struct{}
+	inputFile     *os.File // inputFile is used for reading the answers to the questions of interactive mode
+	outputFile    *os.File // outputFile is used for the questions of interactive mode and verbose output
+	overwrite     byte     // overwrite is the last of the options n, i and f, which decides what to do with existing targets
+	verbose       bool
+	backup        string // backup is the type of backups of overwritten files - none, numbered, existing or simple
+	suffix        string // suffix is used for the names of simple backups
+	progress      bool   // progress is for writing the progress of copying when moving between file systems
+	checksum      bool   // checksum is for comparing the checksums of copied files when moving between file systems
 }
 
 // GetName is a getter for command name
@@ -62,31 +81,204 @@ func (m *Mv) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of mv command
+//
+// With two arguments the first one is moved to the second one or inside it if it is a directory.
+// With more arguments all of them except the last one are moved inside the last one, which should be a directory.
+// When the target is on another file system, the source is copied with its mode, ownership and timestamps and then removed.
 func (m *Mv) Execute(cp CommandProperties) error {
 	m.path = cp.Path
+	m.inputFile, m.outputFile = cp.InputFile, cp.OutputFile
 
-	if len(cp.Arguments) != 2 {
+	short, long := "nifvb", []string{"backup", "suffix=", "progress", "checksum"}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	if len(arguments) < 2 {
 		return ErrMvTwoArgs
 	}
+	m.overwrite = lastOption(cp, short, long, "nif", nil) // like in mv, only the last of -n, -i and -f is used
+	if m.overwrite == 0 {
+		m.overwrite = 'f'
+	}
+	m.verbose = options.has("v")
+	m.progress = options.has("progress")
+	m.checksum = options.has("checksum")
+	m.backup = "none"
+	if options.has("b") || options.has("backup") { // like in mv, -b and --backup without value use the type from VERSION_CONTROL
+		if m.backup, err = backupControl(options.value("backup")); err != nil {
+			return err
+		}
+	}
+	m.suffix = "~"
+	if suffix := os.Getenv("SIMPLE_BACKUP_SUFFIX"); suffix != "" {
+		m.suffix = suffix
+	}
+	if suffix := options.value("suffix"); suffix != "" {
+		m.suffix = suffix
+	}
+
+	sources := arguments[:len(arguments)-1]
+	dest := FullFileName(m.path, arguments[len(arguments)-1])
+	stat, err := os.Stat(dest)
+	destIsDir := err == nil && stat.IsDir()
+	if len(sources) > 1 && destIsDir == false {
+		return fmt.Errorf("%s - %w", dest, ErrMvNotDir)
+	}
 
-	source := FullFileName(m.path, cp.Arguments[0])
-	dest := FullFileName(m.path, cp.Arguments[1])
-	if source == dest {
-		return ErrMvSame
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range sources {
+		if m.IsStopSignalReceived() == true {
+			break
+		}
+		source := FullFileName(m.path, argument)
+		target := dest
+		if destIsDir == true {
+			target = filepath.Join(dest, filepath.Base(source))
+		}
+		err := m.move(source, target)
+		if err == ErrStoppedExec {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return joinErrors(errs)
+}
 
-	stat, err := os.Stat(source)
+// move is a method for moving source to target, following the options for existing targets
+func (m *Mv) move(source string, target string) error {
+	if source == target {
+		return fmt.Errorf("%s - %w", source, ErrMvSame)
+	}
+	info, err := os.Lstat(source)
 	if os.IsNotExist(err) {
 		return fmt.Errorf("%s - %w", source, ErrMvInvalidName)
 	} else if err != nil {
 		return err
-	} else if stat.IsDir() {
-		return fmt.Errorf("%s - %w", source, ErrMvIsDir)
+	}
+	if info.IsDir() {
+		if isSubPath(source, target) {
+			return fmt.Errorf("%s - %w", target, ErrMvIntoItself)
+		}
+	}
+
+	var backupName string
+	targetInfo, err := os.Lstat(target)
+	if err == nil {
+		if os.SameFile(info, targetInfo) {
+			return fmt.Errorf("%s - %w", source, ErrMvSame)
+		}
+		if info.IsDir() && !targetInfo.IsDir() {
+			return fmt.Errorf("%s - %w", target, ErrMvNotDir)
+		}
+		if !info.IsDir() && targetInfo.IsDir() {
+			return fmt.Errorf("%s - %w", target, ErrMvIsDir)
+		}
+		switch m.overwrite {
+		case 'n':
+			return nil
+		case 'i':
+			overwrite, err := askForConfirmation(m, m.inputFile, m.outputFile, "overwrite '"+target+"'? ")
+			if err != nil || overwrite == false {
+				return err
+			}
+		}
+		if m.backup != "none" {
+			if backupName, err = backupFileName(target, m.backup, m.suffix); err != nil {
+				return err
+			}
+			if err := os.Rename(target, backupName); err != nil {
+				return err
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
 	}
 
-	if err := os.Rename(source, dest); err != nil {
+	err = os.Rename(source, target)
+	if errors.Is(err, syscall.EXDEV) { // rename does not work between different file systems
+		err = m.moveByCopying(source, target, info)
+	}
+	if err != nil {
 		return err
 	}
 
+	if m.verbose == true {
+		output := "renamed '" + source + "' -> '" + target + "'"
+		if backupName != "" {
+			output += " (backup: '" + backupName + "')"
+		}
+		return checkWrite(m, m.outputFile, output+"\n")
+	}
 	return nil
 }
+
+// moveByCopying is a method for moving source with information info to target by copying it with its attributes and then removing it.
+// If the copying is not successful, source is not removed.
+func (m *Mv) moveByCopying(source string, target string, info os.FileInfo) error {
+	cr := copier{
+		command:    m,
+		inputFile:  m.inputFile,
+		outputFile: m.outputFile,
+		recursive:  true,
+		preserve:   true,
+		progress:   m.progress,
+		checksum:   m.checksum,
+	}
+	if err := cr.copyEntry(source, target, info); err != nil {
+		return err
+	}
+	if len(cr.errs) > 0 {
+		return joinErrors(cr.errs)
+	}
+	return os.RemoveAll(source)
+}
+
+// backupControl function is helper for checking the value of --backup option and converting it to one of none, numbered, existing and simple
+func backupControl(value string) (string, error) {
+	if value == "" {
+		value = os.Getenv("VERSION_CONTROL")
+	}
+	switch value {
+	case "none", "off":
+		return "none", nil
+	case "numbered", "t":
+		return "numbered", nil
+	case "", "existing", "nil":
+		return "existing", nil
+	case "simple", "never":
+		return "simple", nil
+	}
+	return "", fmt.Errorf("%s - %w", value, ErrMvBackup)
+}
+
+// backupFileName function is helper for getting the name of the backup of file name.
+// Simple backups have the name with suffix and numbered backups have the name with suffix .~N~ where N is one more than the largest existing number.
+// For control existing, numbered backup is made only if there are already numbered backups.
+func backupFileName(name string, control string, suffix string) (string, error) {
+	if control == "simple" {
+		return name + suffix, nil
+	}
+
+	base := filepath.Base(name) + ".~"
+	files, err := ioutil.ReadDir(filepath.Dir(name))
+	if err != nil {
+		return "", err
+	}
+	largest := 0
+	for _, file := range files {
+		fileName := file.Name()
+		if !strings.HasPrefix(fileName, base) || !strings.HasSuffix(fileName, "~") || len(fileName) <= len(base)+1 {
+			continue
+		}
+		if number, err := strconv.Atoi(fileName[len(base) : len(fileName)-1]); err == nil && number > largest {
+			largest = number
+		}
+	}
+	if control == "existing" && largest == 0 {
+		return name + suffix, nil
+	}
+	return name + ".~" + strconv.Itoa(largest+1) + "~", nil
+}
@@ -3,8 +3,12 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func testingMv(t *testing.T, source string, arguments []string, expectedErr error) {
@@ -52,7 +56,7 @@ func TestMv(t *testing.T) {
 		{"", []string{"not-existing-file", "test2"}, ErrMvInvalidName},
 		{"", []string{"test3", "test3"}, ErrMvSame},
 		{"test4", []string{"test4", "test4'"}, nil},
-		{"", []string{getRootPath(path), "test5"}, ErrMvIsDir},
+		{"", []string{getRootPath(path), "test5"}, ErrMvIntoItself},
 	}
 
 	for _, test := range tests {
@@ -62,6 +66,175 @@ func TestMv(t *testing.T) {
 	}
 }
 
+func TestMvTree(t *testing.T) {
+	var tests = []struct {
+		arguments []string
+		options   []string
+		input     string
+		output    string
+		err       error
+		expected  map[string]string // expected contents of files after the moving
+	}{
+		{[]string{"src", "dst"}, []string{}, "", "", nil, map[string]string{"dst/a": "a", "dst/sub/b": "b", "src/a": "<missing>"}},
+		{[]string{"src", "dir"}, []string{}, "", "", nil, map[string]string{"dir/src/a": "a", "dir/old": "old"}},
+		{[]string{"src", "src/sub"}, []string{}, "", "", ErrMvIntoItself, map[string]string{"src/a": "a"}},
+		{[]string{"src", "file"}, []string{}, "", "", ErrMvNotDir, map[string]string{"src/a": "a"}},
+		{[]string{"file", "dir/old", "src"}, []string{}, "", "", nil, map[string]string{"src/file": "file", "src/old": "old"}},
+		{[]string{"file", "dir/old", "other"}, []string{}, "", "", ErrMvNotDir, map[string]string{"file": "file"}},
+		{[]string{"file", "dir/old"}, []string{"n"}, "", "", nil, map[string]string{"file": "file", "dir/old": "old"}},
+		{[]string{"file", "dir/old"}, []string{"i"}, "n\n", "overwrite '{}dir/old'? ", nil, map[string]string{"file": "file", "dir/old": "old"}},
+		{[]string{"file", "dir/old"}, []string{"n", "i"}, "y\n", "overwrite '{}dir/old'? ", nil, map[string]string{"file": "<missing>", "dir/old": "file"}},
+		{[]string{"file", "dir/old"}, []string{"if"}, "", "", nil, map[string]string{"dir/old": "file"}},
+		{[]string{"file", "dir/old"}, []string{"b", "v"}, "", "renamed '{}file' -> '{}dir/old' (backup: '{}dir/old~')\n", nil,
+			map[string]string{"dir/old": "file", "dir/old~": "old"}},
+		{[]string{"file", "dir/old"}, []string{"-backup=numbered"}, "", "", nil, map[string]string{"dir/old": "file", "dir/old.~1~": "old"}},
+		{[]string{"file", "dir/old"}, []string{"b", "-suffix=.bak"}, "", "", nil, map[string]string{"dir/old": "file", "dir/old.bak": "old"}},
+		{[]string{"file", "dir/old"}, []string{"-backup=other"}, "", "", ErrMvBackup, map[string]string{"file": "file"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Mv test with arguments %v and options %v", test.arguments, test.options), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "mv-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"src/a": "a", "src/sub/b": "b", "dir/old": "old", "file": "file"})
+
+			inputR, inputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			inputW.WriteString(test.input)
+			inputW.Close()
+			outputR, outputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+
+			mv := Mv{}
+			errMv := mv.Execute(CommandProperties{path, test.arguments, test.options, inputR, outputW, nil})
+			outputW.Close()
+			if !errors.Is(errMv, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errMv)
+			}
+			output, _ := ioutil.ReadAll(outputR)
+			if expected := strings.Replace(test.output, "{}", path+string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+			for name, content := range test.expected {
+				if result := readTestFile(filepath.Join(path, name)); result != content {
+					t.Errorf("Expected %s to contain %s, but got: %s", name, content, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMvVersionControl(t *testing.T) {
+	versionControl, versionControlSet := os.LookupEnv("VERSION_CONTROL")
+	defer func() {
+		if versionControlSet == true {
+			os.Setenv("VERSION_CONTROL", versionControl)
+		} else {
+			os.Unsetenv("VERSION_CONTROL")
+		}
+	}()
+
+	var tests = []struct {
+		versionControl string
+		words          []string
+		err            error
+		backup         string // backup is the name of the backup of dir/old
+	}{
+		{"", []string{"-b"}, nil, "dir/old~"},
+		{"numbered", []string{"-b"}, nil, "dir/old.~1~"},
+		{"t", []string{"--backup"}, nil, "dir/old.~1~"},
+		{"simple", []string{"--backup=numbered"}, nil, "dir/old.~1~"},
+		{"none", []string{"-b"}, nil, ""},
+		{"other", []string{"-b"}, ErrMvBackup, ""},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Mv test with VERSION_CONTROL %s and words %v", test.versionControl, test.words), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "mv-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"dir/old": "old", "file": "file"})
+			os.Setenv("VERSION_CONTROL", test.versionControl)
+
+			_, err = runTestCommand(t, &Mv{}, path, append(test.words, "file", "dir/old"))
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if files, _ := ioutil.ReadDir(filepath.Join(path, "dir")); test.backup == "" && len(files) != 1 {
+				t.Errorf("Expected no backup, but got %d files", len(files))
+			}
+			if result := readTestFile(filepath.Join(path, test.backup)); test.backup != "" && result != "old" {
+				t.Errorf("Expected backup %s with content %q, but got: %q", test.backup, "old", result)
+			}
+		})
+	}
+}
+
+func TestMvByCopying(t *testing.T) {
+	path, err := ioutil.TempDir("", "mv-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"src/a": "a", "src/sub/b": "b"})
+	modTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(filepath.Join(path, "src", "a"), modTime, modTime); err != nil {
+		t.Fatalf("Fatal error - cannot change times! - %v", err)
+	}
+
+	source := filepath.Join(path, "src")
+	info, err := os.Lstat(source)
+	if err != nil {
+		t.Fatalf("Fatal error - cannot get information for src! - %v", err)
+	}
+	mv := Mv{}
+	if err := mv.moveByCopying(source, filepath.Join(path, "dst"), info); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if _, err := os.Stat(source); !os.IsNotExist(err) {
+		t.Errorf("Expected src to be removed, but got: %v", err)
+	}
+	if stat, err := os.Stat(filepath.Join(path, "dst", "a")); err != nil || !stat.ModTime().Equal(modTime) {
+		t.Errorf("Expected dst/a with modification time %v, but got: %v %v", modTime, stat, err)
+	}
+	if content := readTestFile(filepath.Join(path, "dst", "sub", "b")); content != "b" {
+		t.Errorf("Expected dst/sub/b to contain b, but got: %s", content)
+	}
+}
+
+func TestBackupFileName(t *testing.T) {
+	path, err := ioutil.TempDir("", "mv-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a": "", "b": "", "b.~1~": "", "b.~7~": "", "b.~x~": ""})
+
+	var tests = []struct {
+		name, control, result string
+	}{
+		{"a", "simple", "a~"},
+		{"a", "existing", "a~"},
+		{"a", "numbered", "a.~1~"},
+		{"b", "existing", "b.~8~"},
+		{"b", "numbered", "b.~8~"},
+	}
+	for _, test := range tests {
+		result, err := backupFileName(filepath.Join(path, test.name), test.control, "~")
+		if err != nil || result != filepath.Join(path, test.result) {
+			t.Errorf("Expected %s for %s with control %s, but got: %s %v", test.result, test.name, test.control, result, err)
+		}
+	}
+}
+
 func ExampleMv_Execute() {
 	path, _ := os.Getwd()
 	file, _ := os.Create(path + string(os.PathSeparator) + "not-existing-file")
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Paste is a structure for paste command, implementing ExecuteCommand interface
+type Paste struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// GetName is a getter for command name
+func (p *Paste) GetName() string {
+	return "paste"
+}
+
+// GetPath is a getter for path
+func (p *Paste) GetPath() string {
+	return p.path
+}
+
+// Clone is a method for cloning paste command
+func (p *Paste) Clone() ExecuteCommand {
+	clone := *p
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (p *Paste) InitStopSignalCatching() {
+	p.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (p *Paste) SendStopSignal() {
+	p.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (p *Paste) IsStopSignalReceived() bool {
+	select {
+	case <-p.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of paste command
+//
+// The lines with the same number of the files in the arguments are written on one line, separated by tabs.
+// The input is read for - and without arguments, when - is given more than once, the lines of the input are read one after another.
+// The option -d gives list of delimiters, which are used in turn instead of tab, it can have \n, \t, \\ and \0 for no delimiter.
+// With -s the lines of every file are written on one line.
+func (p *Paste) Execute(cp CommandProperties) error {
+	p.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "d:s", []string{"delimiters=", "serial"})
+	if err != nil {
+		return err
+	}
+	delimiters := []string{"\t"}
+	for _, name := range []string{"d", "delimiters"} {
+		if options.has(name) {
+			delimiters = parsePasteDelimiters(options.value(name))
+		}
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+
+	var readers []*streamReader
+	var input *streamReader // input is the reader of the input, which is shared by all arguments -
+	for _, argument := range arguments {
+		file, err := openInputFile(p.path, argument, inputFile)
+		if err != nil {
+			return err
+		}
+		if file == inputFile {
+			if input == nil {
+				input = newStreamReader(p, inputFile)
+			}
+			readers = append(readers, input)
+			continue
+		}
+		defer file.Close()
+		readers = append(readers, newStreamReader(p, file))
+	}
+
+	out := newStreamWriter(p, outputFile)
+	if options.has("s") || options.has("serial") {
+		for ind, reader := range readers {
+			if err := p.pasteSerial(reader, out, delimiters); err != nil {
+				if err != ErrStoppedExec {
+					err = fmt.Errorf("%s - %w", arguments[ind], err)
+				}
+				return out.finish(err)
+			}
+		}
+		return out.finish(nil)
+	}
+	return out.finish(p.pasteParallel(readers, arguments, out, delimiters))
+}
+
+// parsePasteDelimiters function parses the list of delimiters of paste command, the escape \0 is for empty delimiter
+func parsePasteDelimiters(value string) []string {
+	var delimiters []string
+	for text := []rune(value); len(text) > 0; text = text[1:] {
+		if text[0] != '\\' || len(text) == 1 {
+			delimiters = append(delimiters, string(text[0]))
+			continue
+		}
+		text = text[1:]
+		switch text[0] {
+		case 'n':
+			delimiters = append(delimiters, "\n")
+		case 't':
+			delimiters = append(delimiters, "\t")
+		case '0':
+			delimiters = append(delimiters, "")
+		default:
+			delimiters = append(delimiters, string(text[0]))
+		}
+	}
+	if len(delimiters) == 0 {
+		return []string{""}
+	}
+	return delimiters
+}
+
+// readPasteLine function reads line from in without its new line, the result is false at the end of the input
+func readPasteLine(in *streamReader) (string, bool, error) {
+	line, err := in.ReadString('\n')
+	if err == io.EOF {
+		return line, line != "", nil
+	}
+	return strings.TrimSuffix(line, "\n"), true, err
+}
+
+// pasteSerial is a method for writing all lines of in on one line, separated by the delimiters
+func (p *Paste) pasteSerial(in *streamReader, out *streamWriter, delimiters []string) error {
+	var line strings.Builder
+	for count := 0; ; count++ {
+		text, ok, err := readPasteLine(in)
+		if err != nil {
+			return err
+		}
+		if ok == false { // like in paste, empty file is written as empty line
+			return out.WriteString(line.String() + "\n")
+		}
+		if count > 0 {
+			line.WriteString(delimiters[(count-1)%len(delimiters)])
+		}
+		line.WriteString(text)
+	}
+}
+
+// pasteParallel is a method for writing the lines with the same number of all readers on one line, separated by the delimiters.
+// The files, which end before the others, have empty lines.
+func (p *Paste) pasteParallel(readers []*streamReader, names []string, out *streamWriter, delimiters []string) error {
+	ended := make([]bool, len(readers))
+	for {
+		var line strings.Builder
+		active := 0
+		for ind, reader := range readers {
+			if ind > 0 {
+				line.WriteString(delimiters[(ind-1)%len(delimiters)])
+			}
+			if ended[ind] == true {
+				continue
+			}
+			text, ok, err := readPasteLine(reader)
+			if err == ErrStoppedExec {
+				return err
+			}
+			if err != nil {
+				return fmt.Errorf("%s - %w", names[ind], err)
+			}
+			if ok == false {
+				ended[ind] = true
+				continue
+			}
+			line.WriteString(text)
+			active++
+		}
+		if active == 0 {
+			return nil
+		}
+		if err := out.WriteString(line.String() + "\n"); err != nil {
+			return err
+		}
+		for ind, reader := range readers {
+			if ended[ind] == false {
+				if err := out.flushIfWaiting(reader); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
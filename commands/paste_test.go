@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestPaste(t *testing.T) {
+	path := makeFieldFixtures(t)
+	defer os.RemoveAll(path)
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"numbers", "short"}, "1\ta\n2\tb\n3\t\n4\t\n5\t\n", nil},
+		{"", []string{"-d,", "numbers", "short"}, "1,a\n2,b\n3,\n4,\n5,\n", nil},
+		{"", []string{"-d", ",;", "numbers", "short", "numbers"}, "1,a;1\n2,b;2\n3,;3\n4,;4\n5,;5\n", nil},
+		{"", []string{"-d", "\\t|", "short", "short", "short"}, "a\ta|a\nb\tb|b\n", nil},
+		{"", []string{"-s", "numbers", "short"}, "1\t2\t3\t4\t5\na\tb\n", nil},
+		{"", []string{"-s", "-d", "\\n,", "numbers"}, "1\n2,3\n4,5\n", nil},
+		{"", []string{"-s", "-d", "\\0", "numbers"}, "12345\n", nil},
+		{"", []string{"-s", "plain", "numbers"}, "no delimiters here\n1\t2\t3\t4\t5\n", nil},
+		{"", []string{"-s", "empty", "short"}, "\na\tb\n", nil},
+		{"", []string{"empty", "short"}, "\ta\n\tb\n", nil},
+		{fieldFixtures["numbers"], []string{"-", "-"}, "1\t2\n3\t4\n5\t\n", nil},
+		{fieldFixtures["numbers"], []string{"short", "-"}, "a\t1\nb\t2\n\t3\n\t4\n\t5\n", nil},
+		{"ж\n", []string{"-d", "ъ", "-", "short"}, "жъa\nъb\n", nil},
+		{"x\ny", []string{"-s"}, "x\ty\n", nil},
+		{"", []string{"short", "missing"}, "", os.ErrNotExist},
+		{"", []string{"-x"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Paste test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Paste{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
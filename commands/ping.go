@@ -1,27 +1,41 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 var (
 	// ErrPingOneArg indicates that the ping command has different argument count from 1
+	//
+	// Deprecated: ping accepts many hosts, ErrPingNoHosts is returned when there are no hosts.
 	ErrPingOneArg = errors.New("One argument is needed")
+	// ErrPingNoHosts indicates that there are no hosts in the arguments or in the input of ping command
+	ErrPingNoHosts = errors.New("At least one host is needed")
 	// ErrPingDial indicates that dial returned error and suggests possible reasons
 	ErrPingDial = errors.New("possible reasons are Internet connectivity problem or host unreachability")
+	// ErrPingInvalidValue indicates that the value of option is not valid number of pings, number of seconds or port
+	ErrPingInvalidValue = errors.New("invalid value")
 )
 
 // Ping is a structure for ping command, implementing ExecuteCommand interface
 type Ping struct {
 	path          string
 	stopExecution chan struct{}
-	host          string
-	connection    net.Conn
+	json          bool // json is true when the output is in JSON lines
+	quiet         bool // quiet is true when only the statistics are written
+	multiple      bool // multiple is true when many hosts are pinged
 }
 
 // GetName is a getter for command name
@@ -63,151 +77,590 @@ func (p *Ping) IsStopSignalReceived() bool {
 }
 
 const (
-	// PingRepetitions stores the number of pings that are made
+	// PingRepetitions stores the default number of pings that are made
 	PingRepetitions = 4
 	// DefaultDialTimeOut stores the timeout for dial
+	//
+	// Deprecated: the dial uses the timeout for each ping, which can be changed with -W option.
 	DefaultDialTimeOut = time.Duration(6 * time.Second)
-	// DefaultTimeOut stores the timeout for each ping
+	// DefaultTimeOut stores the default timeout for each ping
 	DefaultTimeOut = time.Duration(5 * time.Second)
+	// DefaultInterval stores the default time between the starts of two pings
+	DefaultInterval = time.Duration(time.Second)
+	// DefaultPort stores the default port, to which the connections are made
+	DefaultPort = "80"
+	// DefaultPayloadSize stores the default size of the data in ICMP echo request
+	DefaultPayloadSize = 56
+	// DefaultConcurrency stores the default number of hosts, which are pinged at the same time
+	DefaultConcurrency = 32
 )
 
 // Execute is go implementation of ping command
+//
+// The arguments are the hosts, which are pinged, and if there are no arguments, the hosts are read from the input, separated by whitespace.
+// The options are -c for the number of pings, -t for pinging until stopped, -i for the interval and -W for the timeout in seconds,
+// -s for the size of the ICMP payload, -p for the port of TCP pings and -4 or -6 for using only IPv4 or IPv6 addresses.
+// The option --mode chooses how the pings are made - with ICMP datagram socket (dgram), ICMP raw socket (raw) or by TCP connect (tcp).
+// The default mode (auto) tries the modes in this order.
+// Many hosts are pinged concurrently, at most --concurrency at the same time, and at the end a table with their statistics is written.
+// With -q, only the statistics are written.
+// With --json, every ping is written as JSON record on separate line, followed by record with the statistics for every host.
+// When the command is stopped, the statistics for the made pings are still written.
 func (p *Ping) Execute(cp CommandProperties) error {
 	p.path = cp.Path
-	_, outputFile := cp.InputFile, cp.OutputFile
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
 
-	if len(cp.Arguments) != 1 {
-		return ErrPingOneArg
+	short, long := "c:i:W:p:s:46tq", []string{"mode=", "json", "concurrency="}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	p.json, p.quiet = options.has("json"), options.has("q")
+	settings := pingSettings{count: PingRepetitions, interval: DefaultInterval, timeout: DefaultTimeOut,
+		size: DefaultPayloadSize, port: DefaultPort, mode: "auto", network: "ip"}
+	if options.has("c") {
+		if settings.count, err = strconv.Atoi(options.value("c")); err != nil || settings.count <= 0 {
+			return fmt.Errorf("-c %s - %w", options.value("c"), ErrPingInvalidValue)
+		}
+	}
+	if options.has("t") {
+		settings.count = 0
+	}
+	if options.has("i") {
+		if settings.interval, err = parseSeconds(options.value("i")); err != nil {
+			return fmt.Errorf("-i %s - %w", options.value("i"), ErrPingInvalidValue)
+		}
+	}
+	if options.has("W") {
+		if settings.timeout, err = parseSeconds(options.value("W")); err != nil || settings.timeout == 0 {
+			return fmt.Errorf("-W %s - %w", options.value("W"), ErrPingInvalidValue)
+		}
+	}
+	if options.has("s") {
+		if settings.size, err = strconv.Atoi(options.value("s")); err != nil || settings.size < 0 || settings.size > maxPayloadSize {
+			return fmt.Errorf("-s %s - %w", options.value("s"), ErrPingInvalidValue)
+		}
+	}
+	if options.has("p") {
+		number, err := net.LookupPort("tcp", options.value("p"))
+		if err != nil || number == 0 {
+			return fmt.Errorf("-p %s - %w", options.value("p"), ErrPingInvalidValue)
+		}
+		settings.port = strconv.Itoa(number)
+	}
+	if options.has("mode") {
+		settings.mode = options.value("mode")
+		if settings.mode != "auto" && settings.mode != "dgram" && settings.mode != "raw" && settings.mode != "tcp" {
+			return fmt.Errorf("--mode %s - %w", settings.mode, ErrPingInvalidValue)
+		}
+	}
+	concurrency := DefaultConcurrency
+	if options.has("concurrency") {
+		if concurrency, err = strconv.Atoi(options.value("concurrency")); err != nil || concurrency <= 0 {
+			return fmt.Errorf("--concurrency %s - %w", options.value("concurrency"), ErrPingInvalidValue)
+		}
+	}
+	switch lastOption(cp, short, long, "46", nil) {
+	case '4':
+		settings.network = "ip4"
+	case '6':
+		settings.network = "ip6"
 	}
 
-	p.host = cp.Arguments[0]
-	port := "80"
+	hosts := arguments
+	if len(hosts) == 0 {
+		if hosts, err = p.readHosts(inputFile); err != nil {
+			return err
+		}
+	}
+	if len(hosts) == 0 {
+		return ErrPingNoHosts
+	}
+	p.multiple = len(hosts) > 1
+	targets := make([]*pingTarget, len(hosts))
+	for ind, host := range hosts {
+		// IPv6 addresses can be written in brackets like in URLs
+		targets[ind] = &pingTarget{host: strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")}
+	}
 
-	if err := checkWrite(p, outputFile, "Pinging "+p.host); err != nil {
-		return err
+	// the stop signal is received only here and all pings are stopped by closing done
+	done, finished := make(chan struct{}), make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-p.stopExecution:
+			close(done)
+		case <-finished:
+		}
+	}()
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+start:
+	for _, target := range targets {
+		select {
+		case semaphore <- struct{}{}:
+		case <-done:
+			break start
+		}
+		target.started = true
+		wg.Add(1)
+		go func(target *pingTarget) {
+			defer wg.Done()
+			target.errOutput = p.pingHost(outputFile, target, settings, done)
+			<-semaphore
+		}(target)
 	}
+	wg.Wait()
 
-	outputIP := func(connection net.Conn) error {
-		address := connection.RemoteAddr().String()
-		if err := checkWrite(p, outputFile, " ["+address+"]\n"); err != nil {
+	var errs []error
+	for _, target := range targets {
+		if target.errOutput != nil {
+			return target.errOutput
+		}
+		if target.err != nil {
+			errs = append(errs, target.err)
+		}
+	}
+	if p.multiple == true && p.json == false {
+		if err := p.outputTable(outputFile, targets); err != nil {
 			return err
 		}
+	}
+	return joinErrors(errs)
+}
+
+// pingSettings stores the options of ping, which are the same for all hosts
+type pingSettings struct {
+	count    int // count 0 means pinging until the command is stopped
+	interval time.Duration
+	timeout  time.Duration
+	size     int
+	port     string
+	mode     string
+	network  string
+}
+
+// pingTarget stores host, which is pinged, with the results of its pings
+type pingTarget struct {
+	host      string
+	address   string
+	started   bool // started is false for the hosts, which are not pinged because the command is stopped
+	stats     pingStatistics
+	err       error // err is the reason, because of which the host cannot be pinged or all of the pings failed
+	errOutput error // errOutput is the error from writing the output, which stops the command
+}
+
+// readHosts is a method for reading the hosts from inputFile, every line can have many hosts and the lines starting with # are skipped
+func (p *Ping) readHosts(inputFile *os.File) ([]string, error) {
+	var hosts []string
+	for {
+		line, err := readLine(p, inputFile)
+		if strings.HasPrefix(strings.TrimSpace(line), "#") == false {
+			hosts = append(hosts, strings.Fields(line)...)
+		}
+		if err == io.EOF {
+			return hosts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// pingHost is a method for pinging one host with settings until done is closed, writing the results in outputFile.
+// The results are stored in target and it returns only the errors from writing.
+func (p *Ping) pingHost(outputFile *os.File, target *pingTarget, settings pingSettings, done <-chan struct{}) error {
+	if p.multiple == false {
+		if err := p.outputInfo(outputFile, "Pinging "+target.host); err != nil {
+			return err
+		}
+	}
+	ip, err := resolveHost(target.host, settings.network, settings.timeout)
+	if err != nil {
+		target.err = fmt.Errorf("%v, %w", err, ErrPingDial)
+	}
+	var pinger pinger
+	if err == nil {
+		if pinger, target.address, err = newPinger(settings.mode, ip, settings.port, settings.size); err != nil {
+			target.err = err
+		}
+	}
+	if err != nil {
+		if p.multiple == false {
+			return p.outputInfo(outputFile, "\n")
+		}
 		return nil
 	}
+	defer pinger.close()
+	if p.multiple == false {
+		err = p.outputInfo(outputFile, " ["+target.address+"]\n")
+	} else {
+		err = p.outputInfo(outputFile, "Pinging "+target.host+" ["+target.address+"]\n")
+	}
+	if err != nil {
+		return err
+	}
+
+	stats := &target.stats
+	var errDial error   // errDial is the last error from ping, which is not timeout
+	var start time.Time // start is the time when the last ping is started
+probes:
+	for i := 0; settings.count == 0 || i < settings.count; i++ {
+		if i > 0 { // the next ping starts interval after the start of the last one, or at once if it took longer
+			select {
+			case <-time.After(time.Until(start.Add(settings.interval))):
+			case <-done:
+				break probes
+			}
+		}
+		start = time.Now()
 
-	var times []time.Duration
-	for i := 0; i < PingRepetitions; i++ {
 		ch := make(chan struct {
-			net.Conn
+			pingReply
 			error
 		}, 1) // channel for collecting possible error
-		startTime := time.Now()
-		go func() {
-			connection, err := net.DialTimeout("tcp", p.host+":"+port, DefaultDialTimeOut)
+		go func(seq int) {
+			reply, err := pinger.ping(seq, settings.timeout)
 			ch <- struct {
-				net.Conn
+				pingReply
 				error
-			}{connection, err}
-		}()
-		endTime := startTime // default value for endTime
+			}{reply, err}
+		}(i + 1)
 
+		var result struct {
+			pingReply
+			error
+		}
 		select {
-		case result := <-ch:
-			endTime = time.Now()
-			if result.error != nil {
-				if i == 0 && result.Conn != nil {
-					if err := outputIP(result.Conn); err != nil {
-						return err
-					}
-				}
-				if err := checkWrite(p, outputFile, "\n"); err != nil {
-					return err
-				}
-				return fmt.Errorf("%v, %w", result.error, ErrPingDial)
-			}
-			if p.connection == nil {
-				p.connection = result.Conn
-			}
-		case <-time.After(DefaultTimeOut):
-			if i == 0 {
-				if err := checkWrite(p, outputFile, "\n"); err != nil {
-					return err
-				}
+		case result = <-ch:
+		case <-done: // the ping, which is not finished, is not counted
+			break probes
+		}
+		stats.sent++
 
+		// the late and duplicate replies are received while waiting for the current reply
+		for _, reply := range pinger.extraReplies() {
+			if reply.duplicate == true {
+				stats.duplicates++
+			} else {
+				stats.outOfOrder++
 			}
-			if err := checkWrite(p, outputFile, "Request timed out.\n"); err != nil {
+			if err := p.outputReply(outputFile, target, reply); err != nil {
 				return err
 			}
 		}
 
-		if endTime != startTime {
-			if i == 0 {
-				if err := outputIP(p.connection); err != nil {
-					return err
-				}
-			}
-			time := endTime.Sub(startTime)
-			if err := checkWrite(p, outputFile, "Reply from "+p.connection.RemoteAddr().String()+
-				": time = "+time.String()+"\n"); err != nil {
-				return err
-			}
-			times = append(times, time)
+		record := pingRecord{Host: target.host, Address: target.address, Seq: i + 1}
+		var err error
+		if netErr, ok := result.error.(net.Error); ok == true && netErr.Timeout() {
+			record.Type = "timeout"
+			err = p.outputProbe(outputFile, target, "Request timed out.\n", record)
+		} else if result.error != nil {
+			errDial = result.error
+			record.Type, record.Error = "error", result.error.Error()
+			err = p.outputProbe(outputFile, target, "Request failed: "+result.error.Error()+"\n", record)
+		} else {
+			stats.times = append(stats.times, result.time)
+			err = p.outputReply(outputFile, target, result.pingReply)
+		}
+		if err != nil {
+			return err
 		}
 	}
 
-	if err := p.outputStatistics(outputFile, len(times), times); err != nil {
-		return err
+	if len(stats.times) == 0 && errDial != nil {
+		target.err = fmt.Errorf("%v, %w", errDial, ErrPingDial)
+	}
+	if p.multiple == false || p.json == true {
+		return p.outputStatistics(outputFile, target)
 	}
 	return nil
 }
 
-// outputStatistics function is helper for writing number of sent, received, lost packets, minTime, maxTime and averageTime of pings
-func (p *Ping) outputStatistics(outputFile *os.File, cntReceived int, times []time.Duration) error {
-	if err := checkWrite(p, outputFile, "Ping statistics for "); err != nil {
-		return err
-	}
-	if p.connection != nil {
-		if err := checkWrite(p, outputFile, p.connection.RemoteAddr().String()+":\n"); err != nil {
-			return err
+// output is a method for writing text or, in JSON mode, record as one line of JSON.
+// Records that are nil are not written in JSON mode.
+//
+// The stop signal is received by Execute, so the output does not check for it and the statistics can be written after it.
+func (p *Ping) output(outputFile *os.File, text string, record interface{}) error {
+	if p.json == true {
+		if record == nil {
+			return nil
 		}
-	} else {
-		if err := checkWrite(p, outputFile, p.host+":\n"); err != nil {
+		line, err := json.Marshal(record)
+		if err != nil {
 			return err
 		}
+		text = string(line) + "\n"
 	}
-	lost := PingRepetitions - cntReceived
-	if err := checkWrite(p, outputFile, "    Packets: Sent = "+strconv.Itoa(PingRepetitions)); err != nil {
-		return err
+	_, err := outputFile.WriteString(text) // every output is one write, so the lines of the hosts are not mixed
+	return err
+}
+
+// outputInfo is a method for writing text, which is not written in quiet or JSON mode
+func (p *Ping) outputInfo(outputFile *os.File, text string) error {
+	if p.quiet == true {
+		return nil
 	}
-	if err := checkWrite(p, outputFile, ", Received = "+strconv.Itoa(cntReceived)); err != nil {
-		return err
+	return p.output(outputFile, text, nil)
+}
+
+// outputProbe is a method for writing the result of one ping to target, which is not written in quiet mode.
+// When many hosts are pinged, text starts with the host.
+func (p *Ping) outputProbe(outputFile *os.File, target *pingTarget, text string, record pingRecord) error {
+	if p.quiet == true {
+		return nil
 	}
-	if err := checkWrite(p, outputFile, ", Lost = "+strconv.Itoa(lost)); err != nil {
-		return err
+	if p.multiple == true {
+		text = target.host + ": " + text
 	}
-	if err := checkWrite(p, outputFile, " ("+strconv.Itoa(lost*100/PingRepetitions)+"% loss)\n"); err != nil {
-		return err
+	return p.output(outputFile, text, record)
+}
+
+// outputReply is a method for writing the information for reply from target
+func (p *Ping) outputReply(outputFile *os.File, target *pingTarget, reply pingReply) error {
+	record := pingRecord{
+		Type: "reply", Host: target.host, Address: target.address, Seq: reply.seq, Time: floatPointer(milliseconds(reply.time)),
+		Duplicate: reply.duplicate, Late: !reply.duplicate && reply.late,
 	}
+	if reply.ttl >= 0 {
+		record.TTL = &reply.ttl
+	}
+	if reply.size >= 0 {
+		record.Bytes = &reply.size
+	}
+	return p.outputProbe(outputFile, target, "Reply from "+target.address+": "+reply.String()+"\n", record)
+}
 
-	if cntReceived == 0 {
-		return nil
+// outputTable is a method for writing table with the statistics of the pinged targets, with aligned columns
+func (p *Ping) outputTable(outputFile *os.File, targets []*pingTarget) error {
+	rows := [][]string{{"Host", "Address", "Sent", "Received", "Loss", "Average"}}
+	for _, target := range targets {
+		if target.started == false {
+			continue
+		}
+		address, loss, average := target.address, "-", "-"
+		if address == "" {
+			address = "-"
+		}
+		if target.stats.sent > 0 {
+			loss = strconv.Itoa((target.stats.sent-len(target.stats.times))*100/target.stats.sent) + "%"
+		}
+		if len(target.stats.times) > 0 {
+			average = formatMilliseconds(averageTime(target.stats.times))
+		}
+		rows = append(rows, []string{
+			target.host, address, strconv.Itoa(target.stats.sent), strconv.Itoa(len(target.stats.times)), loss, average,
+		})
 	}
-	if err := checkWrite(p, outputFile, "Approximate round trip times in milli-seconds:\n"); err != nil {
-		return err
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for column, value := range row {
+			if widths[column] < utf8.RuneCountInString(value) {
+				widths[column] = utf8.RuneCountInString(value)
+			}
+		}
 	}
-	if err := checkWrite(p, outputFile, "    Minimum = "+minimumTime(times).String()); err != nil {
-		return err
+	text := ""
+	for _, row := range rows {
+		line := ""
+		for column, value := range row {
+			padding := strings.Repeat(" ", widths[column]-utf8.RuneCountInString(value))
+			if column < 2 { // the host and the address are aligned left and the numbers are aligned right
+				line += value + padding
+			} else {
+				line += padding + value
+			}
+			if column+1 < len(row) {
+				line += "  "
+			}
+		}
+		text += strings.TrimRight(line, " ") + "\n"
 	}
-	if err := checkWrite(p, outputFile, ", Maximum = "+maximumTime(times).String()); err != nil {
-		return err
+	return p.output(outputFile, text, nil)
+}
+
+// pingRecord is the JSON record for one ping or reply, its type is reply, timeout or error
+type pingRecord struct {
+	Type      string   `json:"type"`
+	Host      string   `json:"host"`
+	Address   string   `json:"address"`
+	Seq       int      `json:"seq"`
+	TTL       *int     `json:"ttl,omitempty"`
+	Bytes     *int     `json:"bytes,omitempty"`
+	Time      *float64 `json:"time_ms,omitempty"`
+	Duplicate bool     `json:"duplicate,omitempty"`
+	Late      bool     `json:"late,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// pingSummary is the JSON record for the statistics of the pings, the round trip times are missing when there are no replies
+type pingSummary struct {
+	Type        string   `json:"type"`
+	Host        string   `json:"host"`
+	Address     string   `json:"address"`
+	Sent        int      `json:"sent"`
+	Received    int      `json:"received"`
+	Lost        int      `json:"lost"`
+	LossPercent float64  `json:"loss_percent"`
+	Duplicates  int      `json:"duplicates"`
+	OutOfOrder  int      `json:"out_of_order"`
+	Min         *float64 `json:"min_ms,omitempty"`
+	Avg         *float64 `json:"avg_ms,omitempty"`
+	Max         *float64 `json:"max_ms,omitempty"`
+	Mdev        *float64 `json:"mdev_ms,omitempty"`
+	Jitter      *float64 `json:"jitter_ms,omitempty"`
+}
+
+// pingStatistics collects the results of the pings to one host
+type pingStatistics struct {
+	sent       int
+	times      []time.Duration // times are the round trip times of the received replies
+	duplicates int
+	outOfOrder int // outOfOrder is the number of replies received after the next ping is made
+}
+
+// pinger is interface for the different ways of making pings
+type pinger interface {
+	// ping makes the ping with sequence number seq, waiting at most timeout for the reply
+	ping(seq int, timeout time.Duration) (pingReply, error)
+	// extraReplies returns the late and duplicate replies received by the last ping
+	extraReplies() []pingReply
+	close() error
+}
+
+// pingReply stores the information for reply of ping, ttl and size are -1 when they are unknown
+type pingReply struct {
+	seq       int
+	ttl       int
+	size      int
+	time      time.Duration
+	duplicate bool // duplicate is true for reply, which is received again
+	late      bool // late is true for reply, which is received after its ping timed out
+}
+
+// String is a method for formatting the information of the reply
+func (r pingReply) String() string {
+	text := ""
+	if r.size >= 0 {
+		text += "seq = " + strconv.Itoa(r.seq) + ", bytes = " + strconv.Itoa(r.size) + ", "
 	}
-	if err := checkWrite(p, outputFile, ", Average = "+averageTime(times).String()); err != nil {
-		return err
+	if r.ttl >= 0 {
+		text += "ttl = " + strconv.Itoa(r.ttl) + ", "
+	}
+	text += "time = " + formatMilliseconds(r.time)
+	if r.duplicate == true {
+		text += " (duplicate)"
+	} else if r.late == true {
+		text += " (late)"
+	}
+	return text
+}
+
+// newPinger function makes pinger for ip with mode, it returns the pinger and the address, which it pings.
+// In auto mode, the ICMP sockets are tried first, because they may not be allowed.
+func newPinger(mode string, ip net.IP, port string, size int) (pinger, string, error) {
+	if mode == "auto" || mode == "dgram" {
+		pinger, err := newICMPPinger(ip, false, size)
+		if err == nil || mode == "dgram" {
+			return pinger, ip.String(), err
+		}
 	}
+	if mode == "auto" || mode == "raw" {
+		pinger, err := newICMPPinger(ip, true, size)
+		if err == nil || mode == "raw" {
+			return pinger, ip.String(), err
+		}
+	}
+	address := net.JoinHostPort(ip.String(), port)
+	return &tcpPinger{address}, address, nil
+}
+
+// tcpPinger is pinger, which measures the time for making TCP connection
+type tcpPinger struct {
+	address string
+}
+
+func (t *tcpPinger) ping(seq int, timeout time.Duration) (pingReply, error) {
+	startTime := time.Now()
+	connection, err := net.DialTimeout("tcp", t.address, timeout)
+	if err != nil {
+		return pingReply{}, err
+	}
+	elapsed := time.Since(startTime)
+	if err := connection.Close(); err != nil {
+		return pingReply{}, err
+	}
+	return pingReply{seq: seq, ttl: -1, size: -1, time: elapsed}, nil
+}
+
+func (t *tcpPinger) extraReplies() []pingReply {
+	return nil
+}
 
+func (t *tcpPinger) close() error {
 	return nil
 }
 
+// resolveHost function is helper for finding the IP address of host, network is ip, ip4 or ip6 for the allowed addresses
+func resolveHost(host string, network string, timeout time.Duration) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupIP(ctx, network, host)
+	if err != nil {
+		return nil, err
+	}
+	return ips[0], nil
+}
+
+// parseSeconds function is helper for parsing non-negative number of seconds, which can have fractional part
+func parseSeconds(text string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(text, 64)
+	if err != nil || seconds < 0 || seconds > math.MaxInt64/float64(time.Second) {
+		return 0, ErrPingInvalidValue
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// outputStatistics is a method for writing number of sent, received, lost packets and the statistics of round trip times
+func (p *Ping) outputStatistics(outputFile *os.File, target *pingTarget) error {
+	stats, address := target.stats, target.address
+	received := len(stats.times)
+	lost := stats.sent - received
+	summary := pingSummary{
+		Type: "summary", Host: target.host, Address: address, Sent: stats.sent, Received: received, Lost: lost,
+		Duplicates: stats.duplicates, OutOfOrder: stats.outOfOrder,
+	}
+	loss := 0
+	if stats.sent > 0 {
+		loss = lost * 100 / stats.sent
+		summary.LossPercent = math.Round(float64(lost)*10000/float64(stats.sent)) / 100
+	}
+	text := "Ping statistics for " + address + ":\n"
+	text += "    Packets: Sent = " + strconv.Itoa(stats.sent) + ", Received = " + strconv.Itoa(received)
+	text += ", Lost = " + strconv.Itoa(lost) + " (" + strconv.Itoa(loss) + "% loss)"
+	if stats.duplicates > 0 {
+		text += ", Duplicates = " + strconv.Itoa(stats.duplicates)
+	}
+	if stats.outOfOrder > 0 {
+		text += ", Out of order = " + strconv.Itoa(stats.outOfOrder)
+	}
+	text += "\n"
+
+	if received > 0 {
+		min, max, avg := minimumTime(stats.times), maximumTime(stats.times), averageTime(stats.times)
+		mdev, jitter := mdevTime(stats.times), jitterTime(stats.times)
+		text += "Approximate round trip times in milli-seconds:\n"
+		text += "    Minimum = " + formatMilliseconds(min) + ", Maximum = " + formatMilliseconds(max)
+		text += ", Average = " + formatMilliseconds(avg) + ", Mdev = " + formatMilliseconds(mdev)
+		text += ", Jitter = " + formatMilliseconds(jitter)
+		summary.Min, summary.Max, summary.Avg = floatPointer(milliseconds(min)), floatPointer(milliseconds(max)), floatPointer(milliseconds(avg))
+		summary.Mdev, summary.Jitter = floatPointer(milliseconds(mdev)), floatPointer(milliseconds(jitter))
+	}
+	return p.output(outputFile, text, summary)
+}
+
 // minimumTime function is helper for finding minimum time in the slice parameter
 func minimumTime(times []time.Duration) time.Duration {
 	min := times[0]
@@ -236,5 +689,47 @@ func averageTime(times []time.Duration) time.Duration {
 	for _, time := range times[1:] {
 		sum += time
 	}
-	return time.Duration(int(sum.Milliseconds())/len(times)) * time.Millisecond
+	return sum / time.Duration(len(times))
+}
+
+// mdevTime function is helper for finding the standard deviation of the times in the slice parameter, like mdev of ping
+func mdevTime(times []time.Duration) time.Duration {
+	var sum, squares float64
+	for _, time := range times {
+		sum += float64(time)
+		squares += float64(time) * float64(time)
+	}
+	average := sum / float64(len(times))
+	return time.Duration(math.Sqrt(math.Max(squares/float64(len(times))-average*average, 0)))
+}
+
+// jitterTime function is helper for finding the average difference between consecutive times in the slice parameter
+func jitterTime(times []time.Duration) time.Duration {
+	if len(times) < 2 {
+		return 0
+	}
+	var sum time.Duration
+	for ind := 1; ind < len(times); ind++ {
+		if times[ind] > times[ind-1] {
+			sum += times[ind] - times[ind-1]
+		} else {
+			sum += times[ind-1] - times[ind]
+		}
+	}
+	return sum / time.Duration(len(times)-1)
+}
+
+// milliseconds function is helper for converting duration to milliseconds with microsecond precision
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Round(time.Microsecond)/time.Microsecond) / 1000
+}
+
+// formatMilliseconds function is helper for formatting duration in milliseconds with microsecond precision
+func formatMilliseconds(d time.Duration) string {
+	return strconv.FormatFloat(milliseconds(d), 'f', 3, 64) + "ms"
+}
+
+// floatPointer function is helper for getting pointer to value, for the optional fields of JSON records
+func floatPointer(value float64) *float64 {
+	return &value
 }
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"errors"
+	"net"
+	"os"
+	"time"
+)
+
+// These constants are the types of ICMP messages used by ping
+const (
+	icmpEchoReply     = 0
+	icmpEchoRequest   = 8
+	icmpv6EchoRequest = 128
+	icmpv6EchoReply   = 129
+)
+
+// maxPayloadSize is the maximum size of the data in ICMP echo request, so that the packet fits in one IPv4 datagram
+const maxPayloadSize = 65507
+
+var (
+	// ErrPingUnsupported indicates that ICMP sockets are not supported on this platform
+	ErrPingUnsupported = errors.New("ICMP sockets are not supported")
+)
+
+// icmpPinger is pinger, which sends ICMP echo requests.
+//
+// With datagram socket, the kernel sets the identifier of the requests and gives only the replies for this socket.
+// With raw socket, all ICMP messages are received, so the replies are matched by the identifier and the address of the host,
+// because all raw sockets of the process, for example when many hosts are pinged, use the same identifier.
+type icmpPinger struct {
+	connection net.PacketConn
+	ip         net.IP
+	raw        bool
+	id         int
+	size       int
+	sentTimes  map[int]time.Time // sentTimes stores when the requests with the sequence numbers are sent
+	received   map[int]bool      // received stores the sequence numbers of the received replies
+	extra      []pingReply       // extra are the late and duplicate replies received by the last ping
+}
+
+// newICMPPinger function opens datagram or raw ICMP socket for pinging ip
+func newICMPPinger(ip net.IP, raw bool, size int) (*icmpPinger, error) {
+	connection, err := openICMP(ip.To4() == nil, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &icmpPinger{
+		connection: connection, ip: ip, raw: raw, id: os.Getpid() & 0xffff, size: size,
+		sentTimes: make(map[int]time.Time), received: make(map[int]bool),
+	}, nil
+}
+
+func (i *icmpPinger) ping(seq int, timeout time.Duration) (pingReply, error) {
+	ipv6 := i.ip.To4() == nil
+	request := makeEchoRequest(ipv6, i.id, seq, i.size)
+	var address net.Addr = &net.UDPAddr{IP: i.ip}
+	if i.raw == true {
+		address = &net.IPAddr{IP: i.ip}
+	}
+
+	i.extra = nil
+	delete(i.received, seq&0xffff) // the sequence numbers start again from 0 after 65535
+	startTime := time.Now()
+	if err := i.connection.SetDeadline(startTime.Add(timeout)); err != nil {
+		return pingReply{}, err
+	}
+	i.sentTimes[seq&0xffff] = startTime
+	if _, err := i.connection.WriteTo(request, address); err != nil {
+		return pingReply{}, err
+	}
+	buf, oob := make([]byte, i.size+128), make([]byte, 128)
+	for {
+		n, oobn, source, err := readICMP(i.connection, buf, oob)
+		if err != nil {
+			return pingReply{}, err
+		}
+		receiveTime := time.Now()
+		if sourceIP(source).Equal(i.ip) == false { // the message is for another pinger
+			continue
+		}
+
+		message, ttl := buf[:n], -1
+		if i.raw == true && ipv6 == false { // raw IPv4 socket receives the IP header too
+			if len(message) < 20 || len(message) < int(message[0]&0x0f)*4 {
+				continue
+			}
+			message, ttl = message[int(message[0]&0x0f)*4:], int(message[8])
+		} else {
+			ttl = icmpTTL(oob[:oobn])
+		}
+		replyType := byte(icmpEchoReply)
+		if ipv6 == true {
+			replyType = icmpv6EchoReply
+		}
+		if len(message) < 8 || message[0] != replyType {
+			continue
+		}
+		if i.raw == true && int(message[4])<<8|int(message[5]) != i.id {
+			continue
+		}
+		reply := pingReply{seq: int(message[6])<<8 | int(message[7]), ttl: ttl, size: len(message) - 8}
+		sentTime, ok := i.sentTimes[reply.seq]
+		if ok == false { // the reply is not for request sent by this pinger
+			continue
+		}
+		reply.time = receiveTime.Sub(sentTime)
+		switch {
+		case i.received[reply.seq] == true:
+			reply.duplicate = true
+		case reply.seq != seq&0xffff: // the reply for earlier ping, which timed out
+			reply.late = true
+		default:
+			i.received[reply.seq] = true
+			reply.seq = seq
+			return reply, nil
+		}
+		i.received[reply.seq] = true
+		i.extra = append(i.extra, reply)
+	}
+}
+
+func (i *icmpPinger) extraReplies() []pingReply {
+	return i.extra
+}
+
+func (i *icmpPinger) close() error {
+	return i.connection.Close()
+}
+
+// readICMP function reads one message from connection with its control messages and the address of its sender
+func readICMP(connection net.PacketConn, buf []byte, oob []byte) (int, int, net.Addr, error) {
+	switch c := connection.(type) {
+	case *net.UDPConn:
+		n, oobn, _, source, err := c.ReadMsgUDP(buf, oob)
+		return n, oobn, source, err
+	case *net.IPConn:
+		n, oobn, _, source, err := c.ReadMsgIP(buf, oob)
+		return n, oobn, source, err
+	}
+	n, source, err := connection.ReadFrom(buf)
+	return n, 0, source, err
+}
+
+// sourceIP function returns the IP of address returned by readICMP, it is nil for unknown address
+func sourceIP(address net.Addr) net.IP {
+	switch a := address.(type) {
+	case *net.UDPAddr:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	}
+	return nil
+}
+
+// makeEchoRequest function makes ICMP or ICMPv6 echo request with identifier id, sequence number seq and size bytes of data
+func makeEchoRequest(ipv6 bool, id int, seq int, size int) []byte {
+	message := make([]byte, 8+size)
+	message[0] = icmpEchoRequest
+	if ipv6 == true {
+		message[0] = icmpv6EchoRequest
+	}
+	message[4], message[5] = byte(id>>8), byte(id)
+	message[6], message[7] = byte(seq>>8), byte(seq)
+	for ind := 8; ind < len(message); ind++ {
+		message[ind] = byte(ind - 8)
+	}
+	if ipv6 == false { // the checksum of ICMPv6 includes IPv6 header, so it is computed by the kernel
+		sum := icmpChecksum(message)
+		message[2], message[3] = byte(sum>>8), byte(sum)
+	}
+	return message
+}
+
+// icmpChecksum function computes the internet checksum of message
+func icmpChecksum(message []byte) uint16 {
+	var sum uint32
+	for ind := 0; ind+1 < len(message); ind += 2 {
+		sum += uint32(message[ind])<<8 | uint32(message[ind+1])
+	}
+	if len(message)%2 == 1 {
+		sum += uint32(message[len(message)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,70 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// openICMP function opens ICMP socket for IPv4 or IPv6, which receives the TTL of the messages.
+// Datagram sockets are allowed for the groups in net.ipv4.ping_group_range and raw sockets need privileges.
+func openICMP(ipv6 bool, raw bool) (net.PacketConn, error) {
+	family, protocol, level, option := syscall.AF_INET, syscall.IPPROTO_ICMP, syscall.IPPROTO_IP, syscall.IP_RECVTTL
+	var address syscall.Sockaddr = &syscall.SockaddrInet4{}
+	if ipv6 == true {
+		family, protocol, level, option = syscall.AF_INET6, syscall.IPPROTO_ICMPV6, syscall.IPPROTO_IPV6, syscall.IPV6_RECVHOPLIMIT
+		address = &syscall.SockaddrInet6{}
+	}
+	socketType := syscall.SOCK_DGRAM
+	if raw == true {
+		socketType = syscall.SOCK_RAW
+	}
+
+	fd, err := syscall.Socket(family, socketType|syscall.SOCK_CLOEXEC, protocol)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+	if err := syscall.SetsockoptInt(fd, level, option, 1); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("setsockopt", err)
+	}
+	if err := syscall.Bind(fd, address); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+	file := os.NewFile(uintptr(fd), "icmp")
+	defer file.Close() // FilePacketConn makes its own copy of the descriptor
+	return net.FilePacketConn(file)
+}
+
+// icmpTTL function finds the TTL or the hop limit in the control messages oob, it returns -1 if they are not there
+func icmpTTL(oob []byte) int {
+	messages, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return -1
+	}
+	for _, message := range messages {
+		if len(message.Data) < 4 {
+			continue
+		}
+		if (message.Header.Level == syscall.IPPROTO_IP && message.Header.Type == syscall.IP_TTL) ||
+			(message.Header.Level == syscall.IPPROTO_IPV6 && message.Header.Type == syscall.IPV6_HOPLIMIT) {
+			return int(nativeEndian().Uint32(message.Data))
+		}
+	}
+	return -1
+}
+
+// nativeEndian function returns the byte order of the machine, in which the control messages are written
+func nativeEndian() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
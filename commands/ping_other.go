@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+import "net"
+
+// openICMP function returns ErrPingUnsupported, because the ICMP sockets are implemented only on Linux
+func openICMP(ipv6 bool, raw bool) (net.PacketConn, error) {
+	return nil, ErrPingUnsupported
+}
+
+// icmpTTL function returns -1, because the TTL is read from the control messages only on Linux
+func icmpTTL(oob []byte) int {
+	return -1
+}
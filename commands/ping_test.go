@@ -1,26 +1,38 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func testingPing(t *testing.T, arguments []string, expectedResult string, expectedErr error) {
 	r, w, err := os.Pipe()
 	if err != nil {
-		t.Fatal("Fatal error - cannot make pipe! - %w", err)
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
 	}
 
+	input, inputW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	inputW.Close()
+	defer input.Close()
+
 	ping := Ping{}
-	errPing := ping.Execute(CommandProperties{"", arguments, []string{""}, os.Stdin, w})
+	errPing := ping.Execute(CommandProperties{"", arguments, []string{""}, input, w, nil})
 
 	takeResult := func() string {
 		output := make([]byte, 1<<10)
 		if _, err := r.Read(output); err != nil {
-			t.Fatal("Fatal error - cannot read from pipe! - %w", err)
+			t.Fatalf("Fatal error - cannot read from pipe! - %v", err)
 		}
 		text := string(output)
 		return text
@@ -28,10 +40,10 @@ func testingPing(t *testing.T, arguments []string, expectedResult string, expect
 
 	if errPing != nil {
 		if expectedErr == nil {
-			t.Errorf("Expecting no error from Ping function, but got: %w\n", errPing)
+			t.Errorf("Expecting no error from Ping function, but got: %v\n", errPing)
 			return
 		} else if !errors.Is(errPing, expectedErr) {
-			t.Errorf("Expecting error %v, bug got: %w", expectedErr, errPing)
+			t.Errorf("Expecting error %v, bug got: %v", expectedErr, errPing)
 			return
 		} else if expectedResult != "" {
 			text := takeResult()
@@ -60,11 +72,10 @@ func TestPing(t *testing.T) {
 		result    string
 		err       error
 	}{
-		{[]string{}, "", ErrPingOneArg},
-		{[]string{"google.com", "google.com"}, "", ErrPingOneArg},
+		{[]string{}, "", ErrPingNoHosts},
 		{[]string{"1"}, "Pinging 1", ErrPingDial},
 		{[]string{"95.43.237.143"},
-			`Pinging 95.43.237.143
+			`Pinging 95.43.237.143 [95.43.237.143]
 Request timed out.
 Request timed out.
 Request timed out.
@@ -79,3 +90,444 @@ Ping statistics for 95.43.237.143:` + "\n    Packets: Sent = 4, Received = 0, Lo
 		})
 	}
 }
+
+// pingTestListener function starts listener on address, which accepts and closes the connections, and returns its port
+func pingTestListener(t *testing.T, network string, address string) (net.Listener, string) {
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		t.Skipf("cannot listen on %s - %v", address, err)
+	}
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			connection.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return listener, port
+}
+
+func TestPingOptions(t *testing.T) {
+	listener, port := pingTestListener(t, "tcp4", "127.0.0.1:0")
+	defer listener.Close()
+	closed, closedPort := pingTestListener(t, "tcp4", "127.0.0.1:0")
+	closed.Close()
+
+	var tests = []struct {
+		words  []string
+		output string
+		err    error
+	}{
+		{[]string{"--mode=tcp", "-c", "2", "-i", "0.01", "-p", port, "127.0.0.1"}, `Pinging 127.0.0.1 \[127.0.0.1:` + port + `\]
+Reply from 127.0.0.1:` + port + `: time = \S+
+Reply from 127.0.0.1:` + port + `: time = \S+
+Ping statistics for 127.0.0.1:` + port + `:
+    Packets: Sent = 2, Received = 2, Lost = 0 \(0% loss\)
+Approximate round trip times in milli-seconds:
+    Minimum = \d+\.\d{3}ms, Maximum = \d+\.\d{3}ms, Average = \d+\.\d{3}ms, Mdev = \d+\.\d{3}ms, Jitter = \d+\.\d{3}ms$`, nil},
+		{[]string{"--mode", "tcp", "-4", "-c1", "-W", "2", "-p" + port, "localhost"}, `^Pinging localhost \[127.0.0.1:` + port + `\]
+Reply from `, nil},
+		{[]string{"--mode=tcp", "-c", "1", "-p", closedPort, "127.0.0.1"}, `Request failed: .*
+Ping statistics for 127.0.0.1:` + closedPort + `:
+    Packets: Sent = 1, Received = 0, Lost = 1 \(100% loss\)
+$`, ErrPingDial},
+		{[]string{"-6", "-c", "1", "127.0.0.1"}, `^Pinging 127.0.0.1
+$`, ErrPingDial},
+		{[]string{"-c", "0", "127.0.0.1"}, "^$", ErrPingInvalidValue},
+		{[]string{"-i", "-1", "127.0.0.1"}, "^$", ErrPingInvalidValue},
+		{[]string{"-W", "0", "127.0.0.1"}, "^$", ErrPingInvalidValue},
+		{[]string{"-p", "70000", "127.0.0.1"}, "^$", ErrPingInvalidValue},
+		{[]string{"-s", "70000", "127.0.0.1"}, "^$", ErrPingInvalidValue},
+		{[]string{"--mode=udp", "127.0.0.1"}, "^$", ErrPingInvalidValue},
+		{[]string{"-c"}, "^$", ErrMissingValue},
+		{[]string{"-x", "127.0.0.1"}, "^$", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Ping test with words %v", test.words), func(t *testing.T) {
+			output, err := runTestCommand(t, &Ping{}, "", test.words)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if !regexp.MustCompile(test.output).MatchString(output) {
+				t.Errorf("Expected output matching %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+func TestPingICMP(t *testing.T) {
+	for _, mode := range []string{"dgram", "raw"} {
+		t.Run("Ping test with mode "+mode, func(t *testing.T) {
+			if pinger, err := newICMPPinger(net.IPv4(127, 0, 0, 1), mode == "raw", 0); err != nil {
+				t.Skipf("cannot open ICMP socket - %v", err)
+			} else {
+				pinger.close()
+			}
+			output, err := runTestCommand(t, &Ping{}, "", []string{"--mode=" + mode, "-c", "2", "-i", "0.01", "-s", "100", "127.0.0.1"})
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+			expected := `^Pinging 127.0.0.1 \[127.0.0.1\]
+Reply from 127.0.0.1: seq = 1, bytes = 100, ttl = \d+, time = \S+
+Reply from 127.0.0.1: seq = 2, bytes = 100, ttl = \d+, time = \S+
+Ping statistics for 127.0.0.1:
+    Packets: Sent = 2, Received = 2, Lost = 0 \(0% loss\)
+`
+			if !regexp.MustCompile(expected).MatchString(output) {
+				t.Errorf("Expected output matching %q, but got: %q", expected, output)
+			}
+		})
+	}
+}
+
+func TestMakeEchoRequest(t *testing.T) {
+	if request := makeEchoRequest(false, 0, 0, 0); string(request) != "\x08\x00\xf7\xff\x00\x00\x00\x00" {
+		t.Errorf("Expected request %q, but got: %q", "\x08\x00\xf7\xff\x00\x00\x00\x00", request)
+	}
+	request := makeEchoRequest(false, 0x1234, 5, 3)
+	if icmpChecksum(request) != 0 {
+		t.Errorf("Expected request %q with valid checksum", request)
+	}
+	if expected := "\x80\x00\x00\x00\x12\x34\x00\x05\x00\x01\x02"; string(makeEchoRequest(true, 0x1234, 5, 3)) != expected {
+		t.Errorf("Expected request %q, but got: %q", expected, makeEchoRequest(true, 0x1234, 5, 3))
+	}
+}
+
+func TestPingIPv6(t *testing.T) {
+	listener, port := pingTestListener(t, "tcp6", "[::1]:0")
+	defer listener.Close()
+
+	for _, host := range []string{"::1", "[::1]"} {
+		output, err := runTestCommand(t, &Ping{}, "", []string{"--mode=tcp", "-6", "-c", "1", "-p", port, host})
+		if err != nil {
+			t.Errorf("Expected no error, but got: %v", err)
+		}
+		if expected := "Pinging ::1 [[::1]:" + port + "]\nReply from [::1]:" + port + ": time = "; !strings.HasPrefix(output, expected) {
+			t.Errorf("Expected output starting with %q, but got: %q", expected, output)
+		}
+	}
+}
+
+func TestPingStop(t *testing.T) {
+	listener, port := pingTestListener(t, "tcp4", "127.0.0.1:0")
+	defer listener.Close()
+
+	ping := &Ping{}
+	ping.InitStopSignalCatching()
+	time.AfterFunc(300*time.Millisecond, ping.SendStopSignal)
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	errPing := ping.Execute(CommandProperties{"", nil, nil, os.Stdin, w, []string{"--mode=tcp", "-t", "-i", "0.05", "-p", port, "127.0.0.1"}})
+	w.Close()
+	if errPing != nil {
+		t.Errorf("Expected no error, but got: %v", errPing)
+	}
+	output := make([]byte, 1<<12)
+	n, _ := r.Read(output)
+	replies := strings.Count(string(output[:n]), "Reply from")
+	if replies < 2 {
+		t.Errorf("Expected at least 2 replies before stop, but got: %q", output[:n])
+	}
+	expected := fmt.Sprintf("Packets: Sent = %d, Received = %d, Lost = 0", replies, replies)
+	if !strings.Contains(string(output[:n]), expected) {
+		t.Errorf("Expected statistics %q, but got: %q", expected, output[:n])
+	}
+}
+
+func TestPingStatisticsTimes(t *testing.T) {
+	times := []time.Duration{time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}
+	var tests = []struct {
+		name     string
+		result   time.Duration
+		expected string
+	}{
+		{"minimum", minimumTime(times), "1.000ms"},
+		{"maximum", maximumTime(times), "4.000ms"},
+		{"average", averageTime(times), "2.500ms"},
+		{"mdev", mdevTime(times), "1.118ms"},
+		{"jitter", jitterTime(times), "1.667ms"},
+		{"jitter of one time", jitterTime(times[:1]), "0.000ms"},
+		{"average of sub-millisecond times", averageTime([]time.Duration{1500 * time.Nanosecond, 2 * time.Microsecond}), "0.002ms"},
+	}
+	for _, test := range tests {
+		if result := formatMilliseconds(test.result); result != test.expected {
+			t.Errorf("Expected %s to be %s, but got: %s", test.name, test.expected, result)
+		}
+	}
+}
+
+// fakePacketConn is connection, which answers to the ICMP echo requests with the replies from answer
+type fakePacketConn struct {
+	answer  func(seq int) []int // answer returns the sequence numbers of the replies for request with seq
+	other   func(seq int) []int // other returns the sequence numbers of the replies from another host, which come before the others
+	replies []fakeReply
+}
+
+// fakeReply is reply of fakePacketConn with the address of the host, which sent it
+type fakeReply struct {
+	message []byte
+	source  net.IP
+}
+
+func (c *fakePacketConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	seq := int(b[6])<<8 | int(b[7])
+	if c.other != nil {
+		for _, seq := range c.other(seq) {
+			c.replies = append(c.replies, fakeReply{makeEchoReply(seq), net.IPv4(10, 0, 0, 1)})
+		}
+	}
+	for _, seq := range c.answer(seq) {
+		c.replies = append(c.replies, fakeReply{makeEchoReply(seq), net.IPv4(127, 0, 0, 1)})
+	}
+	return len(b), nil
+}
+
+// makeEchoReply function makes ICMP echo reply with sequence number seq and 4 bytes of data
+func makeEchoReply(seq int) []byte {
+	reply := makeEchoRequest(false, 0, seq, 4)
+	reply[0] = icmpEchoReply
+	return reply
+}
+
+func (c *fakePacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	if len(c.replies) == 0 {
+		return 0, nil, os.ErrDeadlineExceeded
+	}
+	n := copy(b, c.replies[0].message)
+	source := c.replies[0].source
+	c.replies = c.replies[1:]
+	return n, &net.UDPAddr{IP: source}, nil
+}
+
+func (c *fakePacketConn) Close() error                       { return nil }
+func (c *fakePacketConn) LocalAddr() net.Addr                { return &net.UDPAddr{} }
+func (c *fakePacketConn) SetDeadline(t time.Time) error      { return nil }
+func (c *fakePacketConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *fakePacketConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func TestICMPPingerExtraReplies(t *testing.T) {
+	connection := &fakePacketConn{answer: func(seq int) []int {
+		switch seq {
+		case 1:
+			return []int{1}
+		case 2: // duplicate of the first reply and no reply for the second
+			return []int{1}
+		case 3: // the late reply for the second request comes before the third reply
+			return []int{2, 3, 3}
+		}
+		return nil
+	}}
+	pinger := &icmpPinger{
+		connection: connection, ip: net.IPv4(127, 0, 0, 1), size: 4,
+		sentTimes: make(map[int]time.Time), received: make(map[int]bool),
+	}
+
+	var tests = []struct {
+		seq   int
+		err   bool
+		extra string
+	}{
+		{1, false, "[]"},
+		{2, true, "[1 duplicate]"},
+		{3, false, "[2 late]"},
+		{4, true, "[3 duplicate]"},
+	}
+	for _, test := range tests {
+		reply, err := pinger.ping(test.seq, time.Second)
+		if (err != nil) != test.err || (err == nil && reply.seq != test.seq) {
+			t.Errorf("Expected reply for %d with error %v, but got: %v, %v", test.seq, test.err, reply, err)
+		}
+		var extra []string
+		for _, reply := range pinger.extraReplies() {
+			if reply.duplicate == true {
+				extra = append(extra, fmt.Sprintf("%d duplicate", reply.seq))
+			} else if reply.late == true {
+				extra = append(extra, fmt.Sprintf("%d late", reply.seq))
+			}
+		}
+		if result := "[" + strings.Join(extra, " ") + "]"; result != test.extra {
+			t.Errorf("Expected extra replies %s for %d, but got: %s", test.extra, test.seq, result)
+		}
+	}
+}
+
+func TestICMPPingerOtherHost(t *testing.T) {
+	connection := &fakePacketConn{
+		answer: func(seq int) []int {
+			if seq == 1 {
+				return []int{1}
+			}
+			return nil
+		},
+		other: func(seq int) []int { return []int{seq} }, // another host answers to the same sequence numbers
+	}
+	pinger := &icmpPinger{
+		connection: connection, ip: net.IPv4(127, 0, 0, 1), size: 4,
+		sentTimes: make(map[int]time.Time), received: make(map[int]bool),
+	}
+
+	if reply, err := pinger.ping(1, time.Second); err != nil || reply.seq != 1 || len(pinger.extraReplies()) != 0 {
+		t.Errorf("Expected reply for 1 without extra replies, but got: %v, %v, %v", reply, err, pinger.extraReplies())
+	}
+	if reply, err := pinger.ping(2, time.Second); err == nil || len(pinger.extraReplies()) != 0 {
+		t.Errorf("Expected no reply for 2, but got: %v, %v, %v", reply, err, pinger.extraReplies())
+	}
+}
+
+func TestPingJSON(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen - %v", err)
+	}
+	defer listener.Close()
+	closed := make(chan struct{}, 10)
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() { // the connection is closed by ping, so reading ends
+				ioutil.ReadAll(connection)
+				connection.Close()
+				closed <- struct{}{}
+			}()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	output, err := runTestCommand(t, &Ping{}, "", []string{"--json", "--mode=tcp", "-c", "3", "-i", "0.01", "-p", port, "127.0.0.1"})
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 3 records and summary, but got: %q", output)
+	}
+	address := "127.0.0.1:" + port
+	for ind, line := range lines[:3] {
+		var record pingRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Expected JSON record, but got: %q - %v", line, err)
+		}
+		if record.Type != "reply" || record.Host != "127.0.0.1" || record.Address != address || record.Seq != ind+1 ||
+			record.Time == nil || record.TTL != nil || record.Bytes != nil {
+			t.Errorf("Unexpected record %d: %q", ind+1, line)
+		}
+	}
+	var summary pingSummary
+	if err := json.Unmarshal([]byte(lines[3]), &summary); err != nil {
+		t.Fatalf("Expected JSON summary, but got: %q - %v", lines[3], err)
+	}
+	if summary.Type != "summary" || summary.Sent != 3 || summary.Received != 3 || summary.Lost != 0 || summary.LossPercent != 0 ||
+		summary.Min == nil || summary.Avg == nil || summary.Max == nil || summary.Mdev == nil || summary.Jitter == nil ||
+		*summary.Min > *summary.Avg || *summary.Avg > *summary.Max {
+		t.Errorf("Unexpected summary: %q", lines[3])
+	}
+
+	for ind := 0; ind < 3; ind++ {
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected every connection to be closed, but only %d are closed", ind)
+		}
+	}
+}
+
+func TestPingHosts(t *testing.T) {
+	listener, port := pingTestListener(t, "tcp4", "127.0.0.1:0")
+	defer listener.Close()
+	for _, address := range []string{"127.0.0.2", "127.0.0.3"} {
+		listener, _ := pingTestListener(t, "tcp4", net.JoinHostPort(address, port))
+		defer listener.Close()
+	}
+
+	var tests = []struct {
+		words  []string
+		input  string
+		output string
+		err    error
+	}{
+		{[]string{"-q", "127.0.0.1", "127.0.0.2", "127.0.0.4", "127.0.0.3"}, "", `^Host       Address          Sent  Received  Loss  Average
+127.0.0.1  127.0.0.1:PORT     2         2    0%  \d\.\d{3}ms
+127.0.0.2  127.0.0.2:PORT     2         2    0%  \d\.\d{3}ms
+127.0.0.4  127.0.0.4:PORT     2         0  100%        -
+127.0.0.3  127.0.0.3:PORT     2         2    0%  \d\.\d{3}ms
+$`, ErrPingDial},
+		{[]string{"-q", "--concurrency=2", "127.0.0.2", "missing.invalid"}, "", `^Host             Address          Sent  Received  Loss  Average
+127.0.0.2        127.0.0.2:PORT     2         2    0%  \d\.\d{3}ms
+missing.invalid  -                   0         0     -        -
+$`, ErrPingDial},
+		{[]string{"-q"}, "127.0.0.1 127.0.0.2\n# 127.0.0.4\n\n127.0.0.3", `^Host       Address          Sent  Received  Loss  Average
+127.0.0.1  127.0.0.1:PORT     2         2    0%  \d\.\d{3}ms
+127.0.0.2  127.0.0.2:PORT     2         2    0%  \d\.\d{3}ms
+127.0.0.3  127.0.0.3:PORT     2         2    0%  \d\.\d{3}ms
+$`, nil},
+		{[]string{"--concurrency", "1", "127.0.0.1", "127.0.0.2"}, "", `^Pinging 127.0.0.1 \[127.0.0.1:PORT\]
+127.0.0.1: Reply from 127.0.0.1:PORT: time = \S+
+127.0.0.1: Reply from 127.0.0.1:PORT: time = \S+
+Pinging 127.0.0.2 \[127.0.0.2:PORT\]
+127.0.0.2: Reply from 127.0.0.2:PORT: time = \S+
+127.0.0.2: Reply from 127.0.0.2:PORT: time = \S+
+Host       Address          Sent  Received  Loss  Average
+`, nil},
+		{[]string{"-q", "127.0.0.1"}, "", `^Ping statistics for 127.0.0.1:PORT:
+    Packets: Sent = 2, Received = 2, Lost = 0 \(0% loss\)
+`, nil},
+		{[]string{"-q", "--json", "127.0.0.2", "127.0.0.1"}, "", `^\{"type":"summary","host":"127.0.0.[12]",.*\}
+\{"type":"summary","host":"127.0.0.[12]",.*\}
+$`, nil},
+		{[]string{"--concurrency=0", "127.0.0.1"}, "", "^$", ErrPingInvalidValue},
+		{[]string{}, "# no hosts\n", "^$", ErrPingNoHosts},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Ping test with words %v", test.words), func(t *testing.T) {
+			input, inputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			inputW.WriteString(test.input)
+			inputW.Close()
+			defer input.Close()
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+
+			ping := Ping{}
+			ping.InitStopSignalCatching()
+			words := append([]string{"--mode=tcp", "-c", "2", "-i", "0.01", "-W", "1", "-p", port}, test.words...)
+			errPing := ping.Execute(CommandProperties{"", nil, nil, input, w, words})
+			w.Close()
+			if !errors.Is(errPing, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errPing)
+			}
+			output, _ := ioutil.ReadAll(r)
+			if expected := strings.Replace(test.output, "PORT", port, -1); !regexp.MustCompile(expected).Match(output) {
+				t.Errorf("Expected output matching %q, but got: %q", expected, output)
+			}
+		})
+	}
+}
+
+func TestPingHostsStop(t *testing.T) {
+	listener, port := pingTestListener(t, "tcp4", "127.0.0.1:0")
+	defer listener.Close()
+
+	ping := &Ping{}
+	ping.InitStopSignalCatching()
+	time.AfterFunc(200*time.Millisecond, ping.SendStopSignal)
+	output, err := runTestCommand(t, ping, "", []string{"--mode=tcp", "-q", "-t", "-i", "0.05", "-p", port, "127.0.0.1", "localhost"})
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[1], "127.0.0.1 ") || !strings.HasPrefix(lines[2], "localhost ") {
+		t.Errorf("Expected table with 2 hosts, but got: %q", output)
+	}
+}
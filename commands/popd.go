@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Popd is a structure for popd command, implementing ExecuteCommand and DirectoryStackUser interfaces
+type Popd struct {
+	path          string
+	stopExecution chan struct{}
+	stack         *DirectoryStack
+}
+
+// GetName is a getter for command name
+func (p *Popd) GetName() string {
+	return "popd"
+}
+
+// GetPath is a getter for path
+func (p *Popd) GetPath() string {
+	return p.path
+}
+
+// Clone is a method for cloning popd command
+func (p *Popd) Clone() ExecuteCommand {
+	clone := *p
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (p *Popd) InitStopSignalCatching() {
+	p.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (p *Popd) SendStopSignal() {
+	p.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (p *Popd) IsStopSignalReceived() bool {
+	select {
+	case <-p.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetDirectoryStack is a method for setting the directory stack, from which the directories are removed
+func (p *Popd) SetDirectoryStack(stack *DirectoryStack) {
+	p.stack = stack
+}
+
+// Execute is go implementation of popd command
+//
+// Without argument, the top of the directory stack is removed and the path is changed to the next directory in it.
+// With argument +N or -N, the N-th directory from the top or from the bottom of the stack is removed.
+// Then the stack is written like with dirs, unless the option is -q.
+func (p *Popd) Execute(cp CommandProperties) error {
+	p.path = cp.Path
+	_, outputFile := cp.InputFile, cp.OutputFile
+	if p.stack == nil {
+		p.stack = &DirectoryStack{}
+	}
+
+	options, arguments, err := parseStackWords(cp, "q")
+	if err != nil {
+		return err
+	}
+	if len(arguments) > 1 {
+		return ErrDirsTooManyArgs
+	}
+
+	entries, err := p.stack.modify(filepath.Clean(p.path), func(entries []string) ([]string, error) {
+		if len(entries) < 2 {
+			return nil, ErrDirsEmptyStack
+		}
+		position := 0
+		if len(arguments) == 1 {
+			index, ok := parseStackIndex(arguments[0])
+			if ok == false {
+				return nil, fmt.Errorf("%s - %w", arguments[0], ErrDirsInvalidIndex)
+			}
+			if position, err = stackPosition(index, len(entries), arguments[0]); err != nil {
+				return nil, err
+			}
+		}
+		return append(append([]string{}, entries[:position]...), entries[position+1:]...), nil
+	})
+	if err != nil {
+		return err
+	}
+	p.path = entries[0]
+	if strings.ContainsRune(options, 'q') {
+		return nil
+	}
+	return checkWrite(p, outputFile, formatStack(entries, 0, ""))
+}
@@ -0,0 +1,304 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrPrintfMissingFormat indicates that printf command is run without format
+	ErrPrintfMissingFormat = errors.New("missing format")
+	// ErrPrintfInvalidFormat indicates that the format of printf command has conversion, which is not valid
+	ErrPrintfInvalidFormat = errors.New("invalid format character")
+	// ErrPrintfInvalidNumber indicates that the argument of numeric conversion of printf command is not valid number
+	ErrPrintfInvalidNumber = errors.New("invalid number")
+)
+
+// Printf is a structure for printf command, implementing ExecuteCommand interface
+type Printf struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// printfFormatter writes the arguments of printf command by the format
+type printfFormatter struct {
+	arguments []string
+	next      int // next is the position of the next argument, which is not used
+	result    strings.Builder
+	errs      []error // errs are the errors for the arguments, which are not valid numbers
+}
+
+// GetName is a getter for command name
+func (p *Printf) GetName() string {
+	return "printf"
+}
+
+// GetPath is a getter for path
+func (p *Printf) GetPath() string {
+	return p.path
+}
+
+// Clone is a method for cloning printf command
+func (p *Printf) Clone() ExecuteCommand {
+	clone := *p
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (p *Printf) InitStopSignalCatching() {
+	p.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (p *Printf) SendStopSignal() {
+	p.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (p *Printf) IsStopSignalReceived() bool {
+	select {
+	case <-p.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of printf command
+//
+// The first argument is the format, which is written with its conversions replaced by the other arguments, like in bash.
+// The format can have the escapes like \n, \t, \NNN and \xHH, and the conversions %d and %i for integers, %o, %u, %x and %X
+// for unsigned integers, %f, %e and %g for floating point numbers, %c for character, %s for string, %b for string with expanded escapes,
+// where \c stops the output, %q for string quoted for the shell and %% for %. The conversions can have flags, width and precision like in C,
+// * is for width or precision from the arguments. The integers can be in decimal, octal with leading 0 or hexadecimal with leading 0x,
+// and the value of ' or " followed by character is the code of the character.
+//
+// When there are more arguments than conversions, the format is used again until all arguments are used.
+// The missing arguments are empty strings or 0 for numbers.
+func (p *Printf) Execute(cp CommandProperties) error {
+	p.path = cp.Path
+	outputFile := cp.OutputFile
+
+	words := cp.words()
+	if len(words) > 0 && words[0] == "--" {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return ErrPrintfMissingFormat
+	}
+	f := &printfFormatter{arguments: words[1:]}
+	for {
+		used := f.next
+		stopped, err := f.format(words[0])
+		if err != nil {
+			if errWrite := checkWrite(p, outputFile, f.result.String()); errWrite != nil {
+				return errWrite
+			}
+			return err
+		}
+		if stopped == true || f.next == used || f.next == len(f.arguments) {
+			break
+		}
+	}
+	if err := checkWrite(p, outputFile, f.result.String()); err != nil {
+		return err
+	}
+	return joinErrors(f.errs)
+}
+
+// argument is a method returning the next argument, it is empty string when there are no more arguments
+func (f *printfFormatter) argument() string {
+	if f.next == len(f.arguments) {
+		return ""
+	}
+	f.next++
+	return f.arguments[f.next-1]
+}
+
+// format is a method for writing the format once, the result is true when %b stops the output with \c
+func (f *printfFormatter) format(format string) (bool, error) {
+	for ind := 0; ind < len(format); ind++ {
+		start := ind
+		for ind < len(format) && format[ind] != '%' {
+			ind++
+		}
+		text, _ := expandEscapes(format[start:ind], formatEscapes)
+		f.result.WriteString(text)
+		if ind == len(format) {
+			break
+		}
+		if ind+1 < len(format) && format[ind+1] == '%' {
+			f.result.WriteByte('%')
+			ind++
+			continue
+		}
+
+		// spec is the conversion for fmt, with the values of * from the arguments
+		spec, hasPrecision := "%", false
+		end := ind + 1
+		for end < len(format) && strings.IndexByte("-+ #0", format[end]) != -1 {
+			end++
+		}
+		spec += format[ind+1 : end]
+		// number adds the number at end or the argument for * to spec
+		number := func() {
+			if end < len(format) && format[end] == '*' {
+				spec += strconv.FormatInt(f.integer(f.argument()), 10)
+				end++
+				return
+			}
+			for end < len(format) && isDigit(format[end], 10) {
+				spec += format[end : end+1]
+				end++
+			}
+		}
+		number()
+		if end < len(format) && format[end] == '.' {
+			spec, hasPrecision = spec+".", true
+			end++
+			number()
+		}
+		if end == len(format) {
+			return false, fmt.Errorf("%s - %w", format[ind:], ErrPrintfInvalidFormat)
+		}
+
+		switch verb := format[end]; verb {
+		case 'd', 'i':
+			f.result.WriteString(fmt.Sprintf(spec+"d", f.integer(f.argument())))
+		case 'o', 'u', 'x', 'X':
+			if verb == 'u' {
+				verb = 'd'
+			}
+			f.result.WriteString(fmt.Sprintf(spec+string(verb), uint64(f.integer(f.argument()))))
+		case 'e', 'E', 'f', 'F', 'g', 'G':
+			if (verb == 'g' || verb == 'G') && hasPrecision == false { // the precision of %g is 6 like in C, not the shortest like in fmt
+				spec += ".6"
+			}
+			f.result.WriteString(fmt.Sprintf(spec+string(verb), f.float(f.argument())))
+		case 'c':
+			argument := f.argument()
+			character := "\x00" // like in bash, the character of empty argument is the null character
+			if argument != "" {
+				_, size := utf8.DecodeRuneInString(argument)
+				character = argument[:size]
+			}
+			f.result.WriteString(fmt.Sprintf(spec+"s", character))
+		case 's':
+			f.result.WriteString(fmt.Sprintf(spec+"s", f.argument()))
+		case 'b':
+			text, stopped := expandEscapes(f.argument(), argumentEscapes)
+			f.result.WriteString(fmt.Sprintf(spec+"s", text))
+			if stopped == true {
+				return true, nil
+			}
+		case 'q':
+			f.result.WriteString(fmt.Sprintf(spec+"s", shellQuote(f.argument())))
+		default:
+			return false, fmt.Errorf("%c - %w", verb, ErrPrintfInvalidFormat)
+		}
+		ind = end
+	}
+	return false, nil
+}
+
+// integer is a method for parsing argument of integer conversion, the value of the valid start of text is returned for invalid numbers
+func (f *printfFormatter) integer(text string) int64 {
+	if text != "" && (text[0] == '\'' || text[0] == '"') {
+		r, _ := utf8.DecodeRuneInString(text[1:])
+		if r == utf8.RuneError {
+			return 0
+		}
+		return int64(r)
+	}
+	number := strings.TrimLeft(text, " \t\n")
+	sign := ""
+	if number != "" && (number[0] == '-' || number[0] == '+') {
+		sign, number = number[:1], number[1:]
+	}
+	base := 10
+	switch {
+	case strings.HasPrefix(number, "0x"), strings.HasPrefix(number, "0X"):
+		base, number = 16, number[2:]
+	case strings.HasPrefix(number, "0") && len(number) > 1:
+		base, number = 8, number[1:]
+	}
+	end := 0
+	for end < len(number) && isDigit(number[end], base) {
+		end++
+	}
+	value, err := strconv.ParseInt(sign+number[:end], base, 64)
+	if text != "" && (err != nil || end < len(number)) {
+		f.errs = append(f.errs, fmt.Errorf("%s - %w", text, ErrPrintfInvalidNumber))
+	}
+	return value
+}
+
+// float is a method for parsing argument of floating point conversion, the value of the valid start of text is returned for invalid numbers
+func (f *printfFormatter) float(text string) float64 {
+	if text != "" && (text[0] == '\'' || text[0] == '"') {
+		return float64(f.integer(text))
+	}
+	number := strings.TrimLeft(text, " \t\n")
+	for end := len(number); end > 0; end-- {
+		if value, err := strconv.ParseFloat(number[:end], 64); err == nil {
+			if end < len(number) {
+				f.errs = append(f.errs, fmt.Errorf("%s - %w", text, ErrPrintfInvalidNumber))
+			}
+			return value
+		}
+	}
+	if text != "" {
+		f.errs = append(f.errs, fmt.Errorf("%s - %w", text, ErrPrintfInvalidNumber))
+	}
+	return 0
+}
+
+// shellQuote function quotes text like %q of printf in bash, so that it is one word in the shell.
+// The special characters are escaped with \ and text with characters, which cannot be printed, is quoted as $'...'.
+func shellQuote(text string) string {
+	if text == "" {
+		return "''"
+	}
+	var result strings.Builder
+	if isPrintable(text) == false {
+		result.WriteString("$'")
+		for len(text) > 0 {
+			r, size := utf8.DecodeRuneInString(text)
+			switch ind := strings.IndexRune("\a\b\x1b\f\n\r\t\v\\'", r); {
+			case ind != -1:
+				result.WriteString(`\` + `abefnrtv\'`[ind:ind+1])
+			case r != utf8.RuneError && strconv.IsPrint(r):
+				result.WriteRune(r)
+			default:
+				for _, b := range []byte(text[:size]) {
+					result.WriteString(fmt.Sprintf(`\%03o`, b))
+				}
+			}
+			text = text[size:]
+		}
+		result.WriteString("'")
+		return result.String()
+	}
+	for ind, r := range text {
+		if strings.ContainsRune(" '\"\\|&;()<>!{}*[?]^$`,", r) || (ind == 0 && (r == '~' || r == '#')) {
+			result.WriteByte('\\')
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+// isPrintable function checks if text is valid UTF-8 and all its characters can be printed
+func isPrintable(text string) bool {
+	for _, r := range text {
+		if r == utf8.RuneError || strconv.IsPrint(r) == false {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestPrintf(t *testing.T) {
+	var tests = []struct {
+		words  []string
+		output string
+		err    error
+	}{
+		{[]string{`%s-%s\n`, "a", "b", "c"}, "a-b\nc-\n", nil},
+		{[]string{`x\n`, "a", "b"}, "x\n", nil},
+		{[]string{"%d %s|", "1"}, "1 |", nil},
+		{[]string{"--", `%s\n`, "x"}, "x\n", nil},
+		{[]string{`100%%\n`}, "100%\n", nil},
+		{[]string{`%%%s%%\n`, "a", "b"}, "%a%\n%b%\n", nil},
+		{[]string{`%s\n`}, "\n", nil},
+		{[]string{`%d\n`, "'A", "0x1f", "017", "-3", " 5"}, "65\n31\n15\n-3\n5\n", nil},
+		{[]string{`%i|%d\n`, "+7", "-0x10"}, "7|-16\n", nil},
+		{[]string{`%u %x %o %X %#x %#o\n`, "-1", "-1", "8", "255", "255", "8"}, "18446744073709551615 ffffffffffffffff 10 FF 0xff 010\n", nil},
+		{[]string{`%x\n`, "'ъ"}, "44a\n", nil},
+		{[]string{`%5.2f|%-6s|%06d|%+d|% d|%e\n`, "3.14159", "ab", "42", "5", "5", "1234.5"}, " 3.14|ab    |000042|+5| 5|1.234500e+03\n", nil},
+		{[]string{`%g|%g|%g|%G\n`, "3.14159265", "100000", "1e6", "1e-5"}, "3.14159|100000|1e+06|1E-05\n", nil},
+		{[]string{`%5.1e|%-8.3g|%08.3f\n`, "12345.678", "0.000123456", "-3.14159"}, "1.2e+04|0.000123|-003.142\n", nil},
+		{[]string{`%.3s|%5s|%-5s|\n`, "abcdef", "ab", "ab"}, "abc|   ab|ab   |\n", nil},
+		{[]string{`%*d|%-*d|%.*f\n`, "5", "1", "4", "2", "2", "3.14159"}, "    1|2   |3.14\n", nil},
+		{[]string{"%c%c|", "hello", ""}, "h\x00|", nil},
+		{[]string{"%c|", "ъгъл"}, "ъ|", nil},
+		{[]string{"%b|", `a\tb`, `x\0101y`, `x\101y`, `q\cZ`, "never"}, "a\tb|xAy|xAy|q", nil},
+		{[]string{`A\101\x41\"\c done\n`}, "AAA\"\\c done\n", nil},
+		{[]string{`\e[1m\x\q\\|\n`}, "\x1b[1m\\x\\q\\|\n", nil},
+		{[]string{"%q|", "a b", "it's", "", "a\nb", "x*y", "~home", "a#b", "#a", "a=b", "\t", "a!b", "{}", "\x01"},
+			"a\\ b|it\\'s|''|$'a\\nb'|x\\*y|\\~home|a#b|\\#a|a=b|$'\\t'|a\\!b|\\{\\}|$'\\001'|", nil},
+		{[]string{"%q|", "a,b:c@d%e+f-g./h_i", "ъ b"}, "a\\,b:c@d%e+f-g./h_i|ъ\\ b|", nil},
+		{[]string{`%d|%d\n`, "3x", "4"}, "3|4\n", ErrPrintfInvalidNumber},
+		{[]string{`%f|%d\n`, "abc", ""}, "0.000000|0\n", ErrPrintfInvalidNumber},
+		{[]string{"a%"}, "a", ErrPrintfInvalidFormat},
+		{[]string{"a%5", "1"}, "a", ErrPrintfInvalidFormat},
+		{[]string{"a%yb", "1"}, "a", ErrPrintfInvalidFormat},
+		{[]string{}, "", ErrPrintfMissingFormat},
+		{[]string{"--"}, "", ErrPrintfMissingFormat},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Printf test with words %q", test.words), func(t *testing.T) {
+			output, err := runTestCommand(t, &Printf{}, os.TempDir(), test.words)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// progressTerminalInterval is the time between two redraws of progress when the output is a terminal
+	progressTerminalInterval = 200 * time.Millisecond
+	// progressLineInterval is the time between two lines of progress when the output is a pipe or a file
+	progressLineInterval = 2 * time.Second
+)
+
+// progress is used for reporting how much of a file is copied.
+// When the output is a terminal, the same line is redrawn, otherwise a new line is written periodically.
+type progress struct {
+	command    ExecuteCommand
+	outputFile *os.File
+	terminal   bool
+	name       string
+	total      int64     // total is the size of the file
+	startDone  int64     // startDone is the number of bytes that were already copied when the copying started
+	start      time.Time // start is the time when the copying started
+	lastOutput time.Time // lastOutput is the time when the progress was last written
+}
+
+// newProgress function is constructor for progress of copying file with name and size total, from which done bytes are already copied
+func newProgress(e ExecuteCommand, outputFile *os.File, name string, total int64, done int64) *progress {
+	now := time.Now()
+	return &progress{e, outputFile, isTerminal(outputFile), name, total, done, now, now}
+}
+
+// update is a method for registering that done bytes are copied, the progress is written only if enough time passed since the last output
+func (p *progress) update(done int64) error {
+	now := time.Now()
+	interval := progressLineInterval
+	if p.terminal == true {
+		interval = progressTerminalInterval
+	}
+	if now.Sub(p.lastOutput) < interval {
+		return nil
+	}
+	p.lastOutput = now
+	return p.output(done, now)
+}
+
+// finish is a method for writing the final progress when the copying is finished
+func (p *progress) finish(done int64) error {
+	if err := p.output(done, time.Now()); err != nil {
+		return err
+	}
+	if p.terminal == true {
+		return checkWrite(p.command, p.outputFile, "\n")
+	}
+	return nil
+}
+
+// output is a method for writing the copied bytes, the percentage, the throughput and the estimated remaining time
+func (p *progress) output(done int64, now time.Time) error {
+	line := p.name + ": " + formatBytes(done) + " / " + formatBytes(p.total)
+	if p.total > 0 {
+		line += " (" + strconv.FormatInt(done*100/p.total, 10) + "%)"
+	} else {
+		line += " (100%)"
+	}
+	if elapsed := now.Sub(p.start).Seconds(); elapsed > 0 {
+		speed := float64(done-p.startDone) / elapsed
+		line += " " + formatBytes(int64(speed)) + "/s"
+		if speed > 0 {
+			line += " ETA " + formatDuration(time.Duration(float64(p.total-done)/speed*float64(time.Second)))
+		}
+	}
+
+	if p.terminal == true {
+		return checkWrite(p.command, p.outputFile, "\r"+line+"\x1b[K") // \x1b[K clears the rest of the old line
+	}
+	return checkWrite(p.command, p.outputFile, line+"\n")
+}
+
+// formatBytes function is helper for writing number of bytes with binary prefix, for example 1.5 MiB
+func formatBytes(bytes int64) string {
+	if bytes < 1024 {
+		return strconv.FormatInt(bytes, 10) + " B"
+	}
+	value := float64(bytes)
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	var unit string
+	for _, unit = range units {
+		value /= 1024
+		if value < 1024 {
+			break
+		}
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + " " + unit
+}
+
+// formatDuration function is helper for writing duration in format hh:mm:ss or mm:ss if it is less than hour
+func formatDuration(d time.Duration) string {
+	seconds := int64(d.Round(time.Second) / time.Second)
+	if hours := seconds / 3600; hours > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hours, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
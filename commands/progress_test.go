@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	var tests = []struct {
+		bytes  int64
+		result string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 << 30, "5.0 GiB"},
+	}
+	for _, test := range tests {
+		if result := formatBytes(test.bytes); result != test.result {
+			t.Errorf("Expecting %s for %d, but got: %s", test.result, test.bytes, result)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	var tests = []struct {
+		duration time.Duration
+		result   string
+	}{
+		{0, "00:00"},
+		{1500 * time.Millisecond, "00:02"},
+		{61 * time.Minute, "01:01:00"},
+	}
+	for _, test := range tests {
+		if result := formatDuration(test.duration); result != test.result {
+			t.Errorf("Expecting %s for %v, but got: %s", test.result, test.duration, result)
+		}
+	}
+}
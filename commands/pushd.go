@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Pushd is a structure for pushd command, implementing ExecuteCommand and DirectoryStackUser interfaces
+type Pushd struct {
+	path          string
+	stopExecution chan struct{}
+	stack         *DirectoryStack
+}
+
+// GetName is a getter for command name
+func (p *Pushd) GetName() string {
+	return "pushd"
+}
+
+// GetPath is a getter for path
+func (p *Pushd) GetPath() string {
+	return p.path
+}
+
+// Clone is a method for cloning pushd command
+func (p *Pushd) Clone() ExecuteCommand {
+	clone := *p
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (p *Pushd) InitStopSignalCatching() {
+	p.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (p *Pushd) SendStopSignal() {
+	p.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (p *Pushd) IsStopSignalReceived() bool {
+	select {
+	case <-p.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetDirectoryStack is a method for setting the directory stack, in which the directories are pushed
+func (p *Pushd) SetDirectoryStack(stack *DirectoryStack) {
+	p.stack = stack
+}
+
+// Execute is go implementation of pushd command
+//
+// With directory argument, the current path is pushed in the directory stack and the path is changed to the directory, like with cd.
+// Without argument, the two directories on the top of the stack are exchanged.
+// With argument +N or -N, the stack is rotated, so that the N-th directory from the top or from the bottom is on the top.
+// Then the stack is written like with dirs, unless the option is -q.
+func (p *Pushd) Execute(cp CommandProperties) error {
+	p.path = cp.Path
+	_, outputFile := cp.InputFile, cp.OutputFile
+	if p.stack == nil {
+		p.stack = &DirectoryStack{}
+	}
+
+	options, arguments, err := parseStackWords(cp, "q")
+	if err != nil {
+		return err
+	}
+	if len(arguments) > 1 {
+		return ErrDirsTooManyArgs
+	}
+
+	rotated, err := p.stack.modify(filepath.Clean(p.path), func(entries []string) ([]string, error) {
+		if len(arguments) == 0 {
+			if len(entries) < 2 {
+				return nil, ErrDirsEmptyStack
+			}
+			return append([]string{entries[1], entries[0]}, entries[2:]...), nil
+		}
+		if index, ok := parseStackIndex(arguments[0]); ok == true {
+			position, err := stackPosition(index, len(entries), arguments[0])
+			if err != nil {
+				return nil, err
+			}
+			return append(append([]string{}, entries[position:]...), entries[:position]...), nil
+		}
+		path, err := changeDirectory(p.path, arguments[0], true, false)
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{path}, entries...), nil
+	})
+	if err != nil {
+		return err
+	}
+	p.path = rotated[0]
+	if strings.ContainsRune(options, 'q') {
+		return nil
+	}
+	return checkWrite(p, outputFile, formatStack(rotated, 0, ""))
+}
@@ -1,5 +1,17 @@
 package commands
 
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrPwdDeleted indicates that the current directory was deleted
+	ErrPwdDeleted = errors.New("current directory was deleted")
+)
+
 // Pwd is a structure for pwd command, implementing ExecuteCommand interface
 type Pwd struct {
 	path          string
@@ -45,13 +57,39 @@ func (p *Pwd) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of pwd command
+//
+// With option -L, which is the default, the logical path is written i.e. the path with the symbolic links in it.
+// With option -P, the physical path is written i.e. the path with all symbolic links resolved.
 func (p *Pwd) Execute(cp CommandProperties) error {
 	p.path = cp.Path
 	_, outputFile := cp.InputFile, cp.OutputFile
 
-	if err := checkWrite(p, outputFile, p.path); err != nil {
+	short := "LP"
+	if _, _, err := parseOptions(cp, short, nil); err != nil {
+		return err
+	}
+	path := p.path
+	if lastOption(cp, short, nil, "LP", nil) == 'P' {
+		physicalPath, err := filepath.EvalSymlinks(path)
+		if os.IsNotExist(err) {
+			return CheckPath(path)
+		} else if err != nil {
+			return err
+		}
+		path = physicalPath
+	}
+
+	if err := checkWrite(p, outputFile, path+"\n"); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// CheckPath function checks if the current path still exists, because it can be deleted while the terminal is in it
+func CheckPath(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("%s - %w", path, ErrPwdDeleted)
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -13,7 +17,7 @@ func TestPwd(t *testing.T) {
 
 	testPath := "testPwd"
 	pwd := Pwd{}
-	if err := pwd.Execute(CommandProperties{testPath, []string{}, []string{}, os.Stdin, w}); err != nil {
+	if err := pwd.Execute(CommandProperties{testPath, []string{}, []string{}, os.Stdin, w, nil}); err != nil {
 		t.Error("Expecting no error from Pwd function\n")
 	}
 
@@ -27,6 +31,58 @@ func TestPwd(t *testing.T) {
 	}
 }
 
+func TestPwdLogicalPhysical(t *testing.T) {
+	path, err := ioutil.TempDir("", "pwd-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		t.Fatalf("Fatal error - cannot evaluate temporary directory! - %v", err)
+	}
+	makeTestTree(t, path, map[string]string{"real/": "", "deleted/": ""})
+	link, deleted := filepath.Join(path, "link"), filepath.Join(path, "deleted")
+	if err := os.Symlink(filepath.Join(path, "real"), link); err != nil {
+		t.Skipf("Cannot make symbolic link - %v", err)
+	}
+	if err := os.Remove(deleted); err != nil {
+		t.Fatalf("Fatal error - cannot remove directory! - %v", err)
+	}
+
+	var tests = []struct {
+		path   string
+		words  []string
+		output string
+		err    error
+	}{
+		{link, nil, link + "\n", nil},
+		{link, []string{"-L"}, link + "\n", nil},
+		{link, []string{"-P"}, filepath.Join(path, "real") + "\n", nil},
+		{link, []string{"-P", "-L"}, link + "\n", nil},
+		{deleted, nil, deleted + "\n", nil},
+		{deleted, []string{"-P"}, "", ErrPwdDeleted},
+		{link, []string{"-x"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Pwd test with words %v in %s", test.words, test.path), func(t *testing.T) {
+			output, err := runTestCommand(t, &Pwd{}, test.path, test.words)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+
+	if err := CheckPath(link); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if err := CheckPath(deleted); !errors.Is(err, ErrPwdDeleted) {
+		t.Errorf("Expected error %v, but got: %v", ErrPwdDeleted, err)
+	}
+}
+
 func ExamplePwd_Execute() {
 	pwd := Pwd{}
 	pwd.Execute(newCp("Example/Path", []string{}, []string{}))
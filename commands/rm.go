@@ -3,25 +3,42 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
-	"strings"
+	"path/filepath"
+	"strconv"
 )
 
 var (
 	// ErrRmNoArgs indicates that there were no arguments passed to rm command
 	ErrRmNoArgs = errors.New("At least one argument is needed")
 	// ErrRmIsFile indicates that argument is name of file
+	//
+	// Deprecated: rm -r removes files too, so this error is not returned anymore.
 	ErrRmIsFile = errors.New("is a file")
 	// ErrRmIsDir indicates that argument is name of directory
 	ErrRmIsDir = errors.New("is a directory")
 	// ErrRmInvalidName indicates that argument is not a valid name in file system
 	ErrRmInvalidName = errors.New("is not a valid name in the file system")
+	// ErrRmNotEmpty indicates that directory, which should be removed with -d, is not empty
+	ErrRmNotEmpty = errors.New("is a directory that is not empty")
+	// ErrRmPreserveRoot indicates that argument is the root directory, the home directory or directory containing the current path
+	ErrRmPreserveRoot = errors.New("is protected as the root, home or current directory, use --no-preserve-root to override")
 )
 
 // Rm is a structure for rm command, implementing ExecuteCommand interface
+//
+// If UseTrash is true, rm works in trash mode by default, like with --trash option.
 type Rm struct {
+	UseTrash      bool // UseTrash is the interpreter option for moving files to the trash instead of removing them
 	path          string
 	stopExecution chan struct{}
+	inputFile     *os.File // inputFile is used for reading the answers to the questions of interactive mode
+	outputFile    *os.File // outputFile is used for the questions of interactive mode and verbose output
+	prompt        byte     // prompt is the last of the options f, i and I, which decides when to ask before removing
+	verbose       bool
+	trash         bool    // trash is true in trash mode, in which the files are moved to the trash
+	errs          []error // errs collects the errors in the removed directory tree, which do not stop the removing
 }
 
 // GetName is a getter for command name
@@ -63,62 +80,198 @@ func (r *Rm) IsStopSignalReceived() bool {
 }
 
 // Execute is go implementation of rm command
+//
+// Directories are removed only with -r or, if they are empty, with -d.
+// Like in rm, by default it refuses to remove the root directory, the home directory and the directories that contain the current path.
+// In trash mode (--trash) the files are moved to the trash, from where they can be restored with trash command.
 func (r *Rm) Execute(cp CommandProperties) error {
 	r.path = cp.Path
+	r.inputFile, r.outputFile = cp.InputFile, cp.OutputFile
 
-	if len(cp.Arguments) == 0 {
-		return ErrRmNoArgs
+	short, long := "rRfiIdv", []string{"recursive", "force", "dir", "verbose", "preserve-root", "no-preserve-root", "trash", "no-trash"}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	recursive := options.has("r") || options.has("R") || options.has("recursive")
+	dirOption := options.has("d") || options.has("dir")
+	r.verbose = options.has("v") || options.has("verbose")
+	r.prompt = lastOption(cp, short, long, "fiI", map[string]byte{"force": 'f'}) // like in rm, only the last of -f, -i and -I is used
+	preserveRoot := true
+	r.trash = r.UseTrash
+	for _, word := range cp.words() { // the last of --preserve-root and --no-preserve-root is used, the same for --trash and --no-trash
+		if word == "--preserve-root" || word == "--no-preserve-root" {
+			preserveRoot = word == "--preserve-root"
+		}
+		if word == "--trash" || word == "--no-trash" {
+			r.trash = word == "--trash"
+		}
 	}
 
-	recursiveOption := false
-	for _, option := range cp.Options {
-		if option == "r" {
-			recursiveOption = true
+	if len(arguments) == 0 {
+		if r.prompt == 'f' {
+			return nil
+		}
+		return ErrRmNoArgs
+	}
+	if r.prompt == 'I' && (len(arguments) > 3 || recursive == true) {
+		question := "remove " + strconv.Itoa(len(arguments)) + " arguments"
+		if recursive == true {
+			question += " recursively"
+		}
+		if remove, err := askForConfirmation(r, r.inputFile, r.outputFile, question+"? "); err != nil || remove == false {
+			return err
 		}
 	}
 
-	var errStrings []string // in slice errStrings we collect all the errors
-	for _, argument := range cp.Arguments {
+	var errs []error // in slice errs we collect all the errors
+	for _, argument := range arguments {
 		if r.IsStopSignalReceived() == true {
-			if len(errStrings) > 0 {
-				return errors.New(strings.Join(errStrings, "\n"))
-			}
-			return nil
+			break
 		}
 
 		fullName := FullFileName(r.path, argument)
-		stat, err := os.Stat(fullName)
-
+		info, err := os.Lstat(fullName) // symbolic links are removed, not the files they point to
 		if os.IsNotExist(err) {
-			errStrings = append(errStrings, fmt.Errorf("%s %w", fullName, ErrRmInvalidName).Error())
+			if r.prompt != 'f' {
+				errs = append(errs, fmt.Errorf("%s %w", fullName, ErrRmInvalidName))
+			}
 			continue
 		}
 		if err != nil {
-			errStrings = append(errStrings, err.Error())
+			errs = append(errs, err)
 			continue
 		}
 
-		if recursiveOption == true {
-			if stat.IsDir() {
-				if err := os.RemoveAll(fullName); err != nil {
-					errStrings = append(errStrings, err.Error())
-				}
-			} else {
-				errStrings = append(errStrings, fmt.Errorf("%s %w", fullName, ErrRmIsFile).Error())
+		if info.IsDir() {
+			if recursive == false && dirOption == false {
+				errs = append(errs, fmt.Errorf("%s %w", fullName, ErrRmIsDir))
+				continue
+			}
+			if preserveRoot == true && r.isProtected(fullName) {
+				errs = append(errs, fmt.Errorf("%s %w", fullName, ErrRmPreserveRoot))
+				continue
 			}
+		}
+		if r.trash == true {
+			err = r.trashEntry(fullName, info)
+		} else if recursive == true {
+			err = r.removeTree(fullName, info)
 		} else {
-			if !stat.IsDir() {
-				if err := os.Remove(fullName); err != nil {
-					errStrings = append(errStrings, err.Error())
-				}
-			} else {
-				errStrings = append(errStrings, fmt.Errorf("%s %w", fullName, ErrRmIsDir).Error())
+			err = r.removeEntry(fullName, info)
+		}
+		errs = append(errs, r.errs...)
+		r.errs = nil
+		if err == ErrStoppedExec {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// isProtected is a method for checking if the directory with fullName is the root directory, the home directory or it contains the current path
+func (r *Rm) isProtected(fullName string) bool {
+	resolve := func(name string) string { // we compare the names without symbolic links
+		name = filepath.Clean(name)
+		if resolved, err := filepath.EvalSymlinks(name); err == nil {
+			return resolved
+		}
+		return name
+	}
+
+	name := resolve(fullName)
+	if name == filepath.Dir(name) { // only for the root directory the parent is the same
+		return true
+	}
+	if home, err := os.UserHomeDir(); err == nil && name == resolve(home) {
+		return true
+	}
+	return r.path != "" && isSubPath(name, resolve(r.path))
+}
+
+// removeTree is a method for removing the file with name and information info and if it is a directory - all files in it.
+// The errors for the files in the directory are collected in r.errs, so the removing can continue with the other files.
+func (r *Rm) removeTree(name string, info os.FileInfo) error {
+	if info.IsDir() == false {
+		return r.removeEntry(name, info)
+	}
+	if r.prompt == 'i' {
+		if descend, err := askForConfirmation(r, r.inputFile, r.outputFile, "descend into directory '"+name+"'? "); err != nil || descend == false {
+			return err
+		}
+	}
+
+	files, err := ioutil.ReadDir(name)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if r.IsStopSignalReceived() == true {
+			return ErrStoppedExec
+		}
+		err := r.removeTree(filepath.Join(name, file.Name()), file)
+		if err == ErrStoppedExec {
+			return err
+		}
+		if err != nil {
+			r.errs = append(r.errs, err)
+		}
+	}
+	if files, err := ioutil.ReadDir(name); err == nil && len(files) > 0 {
+		return nil // some files were not removed, so the directory cannot be removed - the reasons are already known
+	}
+	return r.removeEntry(name, info)
+}
+
+// removeEntry is a method for removing one file or empty directory, asking before it in interactive mode
+func (r *Rm) removeEntry(name string, info os.FileInfo) error {
+	kind := ""
+	if info.IsDir() {
+		kind = "directory "
+	}
+	if r.prompt == 'i' {
+		if remove, err := askForConfirmation(r, r.inputFile, r.outputFile, "remove "+kind+"'"+name+"'? "); err != nil || remove == false {
+			return err
+		}
+	}
+
+	if err := os.Remove(name); err != nil {
+		if info.IsDir() {
+			if files, errRead := ioutil.ReadDir(name); errRead == nil && len(files) > 0 {
+				return fmt.Errorf("%s %w", name, ErrRmNotEmpty)
 			}
 		}
+		return err
+	}
+	if r.verbose == true {
+		return checkWrite(r, r.outputFile, "removed "+kind+"'"+name+"'\n")
+	}
+	return nil
+}
+
+// trashEntry is a method for moving the file with name to the trash, asking before it in interactive mode.
+// Directories are moved with all files in them.
+func (r *Rm) trashEntry(name string, info os.FileInfo) error {
+	kind := ""
+	if info.IsDir() {
+		kind = "directory "
+	}
+	if r.prompt == 'i' {
+		if trash, err := askForConfirmation(r, r.inputFile, r.outputFile, "move "+kind+"'"+name+"' to trash? "); err != nil || trash == false {
+			return err
+		}
 	}
 
-	if len(errStrings) > 0 {
-		return errors.New(strings.Join(errStrings, "\n"))
+	trashName, err := moveToTrash(r, name)
+	if err != nil {
+		return err
+	}
+	if r.verbose == true {
+		return checkWrite(r, r.outputFile, "trashed "+kind+"'"+name+"' as '"+trashName+"'\n")
 	}
 	return nil
 }
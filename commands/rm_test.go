@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -66,7 +70,9 @@ func TestRm(t *testing.T) {
 		{"file", "", []string{"file1"}, []string{}, FullFileName(path, "file1") + " " + ErrRmInvalidName.Error()},
 		{"file", "", []string{"file", "file"}, []string{}, FullFileName(path, "file") + " " + ErrRmInvalidName.Error()},
 		{"f", "dir", []string{"dir"}, []string{"r"}, ""},
-		{"f", "dir", []string{"dir/f"}, []string{"r"}, FullFileName(path, "dir/f") + " " + ErrRmIsFile.Error()},
+		{"f", "dir", []string{"dir/f"}, []string{"r"}, ""},
+		{"f", "dir", []string{"dir/f", "file1"}, []string{"f"}, ""},
+		{"f", "dir", []string{"dir"}, []string{"d"}, FullFileName(path, "dir") + " " + ErrRmNotEmpty.Error()},
 		{"f", "dir", []string{"dir", "dir/f"}, []string{"r"}, FullFileName(path, "dir/f") + " " + ErrRmInvalidName.Error()},
 	}
 
@@ -77,6 +83,77 @@ func TestRm(t *testing.T) {
 	}
 }
 
+func TestRmOptions(t *testing.T) {
+	var tests = []struct {
+		words    []string // words are the options and the arguments in the order they are written
+		input    string
+		output   string
+		err      error
+		existing []string // existing are the files which should not be removed
+		removed  []string
+	}{
+		{[]string{"-r", "file", "dir"}, "", "", nil, nil, []string{"file", "dir"}},
+		{[]string{"-d", "empty"}, "", "", nil, nil, []string{"empty"}},
+		{[]string{"-v", "file", "-d", "empty"}, "", "removed '{}file'\nremoved directory '{}empty'\n", nil, nil, []string{"file", "empty"}},
+		{[]string{"-f"}, "", "", nil, nil, nil},
+		{[]string{"-i", "file", "dir/a"}, "y\nn\n", "remove '{}file'? remove '{}dir/a'? ", nil, []string{"dir/a"}, []string{"file"}},
+		{[]string{"-f", "-i", "file"}, "n\n", "remove '{}file'? ", nil, []string{"file"}, nil},
+		{[]string{"-i", "-f", "file"}, "", "", nil, nil, []string{"file"}},
+		{[]string{"--force", "-i", "file"}, "n\n", "remove '{}file'? ", nil, []string{"file"}, nil},
+		{[]string{"-i", "--force", "file"}, "", "", nil, nil, []string{"file"}},
+		{[]string{"-ri", "dir"}, "y\ny\nn\n", "descend into directory '{}dir'? remove '{}dir/a'? remove '{}dir/b'? ",
+			nil, []string{"dir", "dir/b"}, []string{"dir/a"}},
+		{[]string{"-I", "file", "empty", "dir/a", "dir/b"}, "n\n", "remove 4 arguments? ", nil, []string{"file", "dir/a"}, nil},
+		{[]string{"-rI", "dir"}, "y\n", "remove 1 arguments recursively? ", nil, nil, []string{"dir"}},
+		{[]string{"-r", "."}, "", "", ErrRmPreserveRoot, []string{"file", "dir"}, nil},
+		{[]string{"-r", "dir/.."}, "", "", ErrRmPreserveRoot, []string{"file", "dir"}, nil},
+		{[]string{"-r", "/"}, "", "", ErrRmPreserveRoot, nil, nil},
+		{[]string{"-r", "dir", "--no-preserve-root"}, "", "", nil, nil, []string{"dir"}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Rm test with words %v", test.words), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "rm-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"file": "", "dir/a": "", "dir/b": "", "empty/": ""})
+
+			inputR, inputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+			inputW.WriteString(test.input)
+			inputW.Close()
+			outputR, outputW, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+			}
+
+			rm := Rm{}
+			errRm := rm.Execute(CommandProperties{path, nil, nil, inputR, outputW, test.words})
+			outputW.Close()
+			if !errors.Is(errRm, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, errRm)
+			}
+			output, _ := ioutil.ReadAll(outputR)
+			if expected := strings.Replace(test.output, "{}", path+string(os.PathSeparator), -1); string(output) != expected {
+				t.Errorf("Expected output %q, but got: %q", expected, output)
+			}
+			for _, name := range test.existing {
+				if _, err := os.Lstat(filepath.Join(path, name)); err != nil {
+					t.Errorf("Expected %s to exist, but got: %v", name, err)
+				}
+			}
+			for _, name := range test.removed {
+				if _, err := os.Lstat(filepath.Join(path, name)); !os.IsNotExist(err) {
+					t.Errorf("Expected %s to be removed, but got: %v", name, err)
+				}
+			}
+		})
+	}
+}
+
 func ExampleRm_Execute() {
 	path, _ := os.Getwd()
 	file, _ := os.Create(path + string(os.PathSeparator) + "not-existing-file")
@@ -0,0 +1,364 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrSedNoScript indicates that sed command is run without script
+	ErrSedNoScript = errors.New("no script specified")
+	// ErrSedMissingCommand indicates that the script of sed command has addresses without command after them
+	ErrSedMissingCommand = errors.New("missing command")
+	// ErrSedUnknownCommand indicates that the script of sed command has command, which is not supported
+	ErrSedUnknownCommand = errors.New("unknown command")
+	// ErrSedExtraCharacters indicates that command in the script of sed command is followed by characters, which are not part of it
+	ErrSedExtraCharacters = errors.New("extra characters after command")
+	// ErrSedUnterminated indicates that regular expression or s command in the script of sed command does not have its closing delimiter
+	ErrSedUnterminated = errors.New("unterminated command")
+	// ErrSedUnknownFlag indicates that s command in the script of sed command has flag, which is not supported
+	ErrSedUnknownFlag = errors.New("unknown option to s command")
+	// ErrSedInvalidAddress indicates that address in the script of sed command is not valid
+	ErrSedInvalidAddress = errors.New("invalid address")
+	// ErrSedInvalidPattern indicates that regular expression in the script of sed command is not valid
+	ErrSedInvalidPattern = errors.New("invalid regular expression")
+	// ErrSedInvalidReference indicates that the replacement of s command has group, which is not in the regular expression
+	ErrSedInvalidReference = errors.New("invalid reference on s command's replacement")
+	// ErrSedNoInputFiles indicates that sed command with -i is run without files
+	ErrSedNoInputFiles = errors.New("no input files")
+	// ErrSedNotRegularFile indicates that the file, which sed command edits in place, is not regular file
+	ErrSedNotRegularFile = errors.New("couldn't edit, not a regular file")
+)
+
+// Sed is a structure for sed command, implementing ExecuteCommand interface
+type Sed struct {
+	path          string
+	stopExecution chan struct{}
+	script        []*sedCommand
+	quiet         bool
+	usesLast      bool // usesLast is true when the script has address $, so the lines are read one line ahead
+}
+
+// sedInput is the input of sed command, the lines of the files are read one after another.
+// The files, which cannot be opened or read, are skipped and their errors are collected.
+type sedInput struct {
+	command   *Sed
+	names     []string // names are the files, which are not read yet
+	inputFile *os.File
+	name      string
+	file      *os.File
+	in        *streamReader
+	errs      []error
+	next      string // next is the next line with its new line, if it has one
+	ok        bool   // ok is false when there are no more lines
+}
+
+// sedOutput is the output of sed command, the new line, which is missing at the end of the last line, is written only if there is more output
+type sedOutput struct {
+	*streamWriter
+	missingNewline bool
+}
+
+// GetName is a getter for command name
+func (s *Sed) GetName() string {
+	return "sed"
+}
+
+// GetPath is a getter for path
+func (s *Sed) GetPath() string {
+	return s.path
+}
+
+// Clone is a method for cloning sed command
+func (s *Sed) Clone() ExecuteCommand {
+	clone := *s
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (s *Sed) InitStopSignalCatching() {
+	s.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (s *Sed) SendStopSignal() {
+	s.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (s *Sed) IsStopSignalReceived() bool {
+	select {
+	case <-s.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of sed command
+//
+// The script is run for every line of the files in the arguments, - is for the input and without files the input is read.
+// The script is the first argument or the scripts given with -e, which are joined with new lines.
+// At the end of the script the line is written, unless -n is given.
+//
+// The commands in the script are separated with new lines or ; and they are run for the lines, which are selected by their addresses.
+// The address can be number of line, $ for the last line or /re/ for the lines matching the regular expression.
+// With two addresses, separated by comma, the command is run for the range of lines from the first to the second address
+// and with ! after the addresses, it is run for the other lines.
+// The command d deletes the line and starts the next cycle, p writes the line and s/re/replacement/flags replaces the match of re,
+// where & in the replacement is the match and \1 to \9 are its groups. The flags of s are g for all matches, number N for the N-th match,
+// i for ignoring case and p for writing the line after replacement. The regular expressions are basic regular expressions like in sed, with -E they are extended.
+//
+// With -i[SUFFIX] the files are edited in place and with SUFFIX the original files are kept as backups, with SUFFIX added to their names.
+// The result is written to temporary file, which replaces the file only after it is written, so the file is never left partly edited.
+func (s *Sed) Execute(cp CommandProperties) error {
+	s.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	short, long := "ne:Eri::", []string{"quiet", "silent", "expression=", "regexp-extended", "in-place"}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	var expressions []string
+	inPlace, suffix := false, ""
+	walkOptions(cp, short, long, func(name string, value string) {
+		switch name {
+		case "e", "expression":
+			expressions = append(expressions, value)
+		case "i", "in-place":
+			inPlace, suffix = true, value
+		}
+	})
+	if len(expressions) == 0 {
+		if len(arguments) == 0 {
+			return ErrSedNoScript
+		}
+		expressions, arguments = arguments[:1], arguments[1:]
+	}
+	extended := options.has("E") || options.has("r") || options.has("regexp-extended")
+	if s.script, err = parseSedScript(strings.Join(expressions, "\n"), extended); err != nil {
+		return err
+	}
+	s.quiet = options.has("n") || options.has("quiet") || options.has("silent")
+	s.usesLast = false
+	for _, command := range s.script {
+		for _, address := range []*sedAddress{command.start, command.end} {
+			if address != nil && address.last == true {
+				s.usesLast = true
+			}
+		}
+	}
+
+	if inPlace == true {
+		if len(arguments) == 0 {
+			return ErrSedNoInputFiles
+		}
+		var errs []error // in slice errs we collect all the errors
+		for _, argument := range arguments {
+			err := s.editInPlace(argument, suffix)
+			if err == ErrStoppedExec {
+				return err
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return joinErrors(errs)
+	}
+
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+	input := &sedInput{command: s, names: arguments, inputFile: inputFile}
+	out := &sedOutput{streamWriter: newStreamWriter(s, outputFile)}
+	if err := s.edit(input, out); err != nil {
+		return out.finish(err)
+	}
+	return out.finish(joinErrors(input.errs))
+}
+
+// editInPlace is a method for editing the file with name, the result is written to temporary file in the same directory,
+// which is renamed to the file at the end. When suffix is not empty, the original file is kept as backup with the name of the file
+// followed by suffix, or with the name in suffix where * is replaced with the name of the file.
+func (s *Sed) editInPlace(name string, suffix string) error {
+	if name == "-" {
+		return fmt.Errorf("%s - %w", inputFileName(name), ErrSedNotRegularFile)
+	}
+	fullName := FullFileName(s.path, name)
+	info, err := os.Stat(fullName)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s - %w", name, os.ErrNotExist)
+	} else if err != nil {
+		return fmt.Errorf("%s - %w", name, err)
+	}
+	if info.Mode().IsRegular() == false {
+		return fmt.Errorf("%s - %w", name, ErrSedNotRegularFile)
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(fullName), "sed")
+	if err != nil {
+		return fmt.Errorf("%s - %w", name, err)
+	}
+	input := &sedInput{command: s, names: []string{name}}
+	out := &sedOutput{streamWriter: newStreamWriter(s, temp)}
+	err = out.finish(s.edit(input, out))
+	if err == nil {
+		err = joinErrors(input.errs)
+	}
+	if errClose := temp.Close(); err == nil && errClose != nil {
+		err = fmt.Errorf("%s - %w", name, errClose)
+	}
+	if err == nil {
+		err = os.Chmod(temp.Name(), info.Mode())
+	}
+	if err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+
+	backup := fullName + suffix
+	if strings.Contains(suffix, "*") {
+		backup = strings.ReplaceAll(suffix, "*", filepath.Base(fullName))
+		if strings.ContainsAny(backup, `/\`) {
+			backup = FullFileName(s.path, backup)
+		} else {
+			backup = filepath.Join(filepath.Dir(fullName), backup)
+		}
+	}
+	if filepath.Clean(backup) != filepath.Clean(fullName) { // there is no backup without suffix or when its name is the name of the file
+		os.Remove(backup)
+		if err := os.Link(fullName, backup); err != nil { // the file is renamed only if hard link cannot be made
+			if err := os.Rename(fullName, backup); err != nil {
+				os.Remove(temp.Name())
+				return fmt.Errorf("%s - %w", name, err)
+			}
+		}
+	}
+	if err := os.Rename(temp.Name(), fullName); err != nil {
+		os.Remove(temp.Name())
+		return fmt.Errorf("%s - %w", name, err)
+	}
+	return nil
+}
+
+// edit is a method for running the script for every line of input, the result is written to out
+func (s *Sed) edit(input *sedInput, out *sedOutput) error {
+	defer input.close()
+	for _, command := range s.script {
+		command.reset()
+	}
+	if err := input.advance(); err != nil {
+		return err
+	}
+	for number := 1; input.ok == true; number++ {
+		line := input.next
+		if s.usesLast == true { // the next line is read before the end of the cycle, so that the last line is known
+			if err := input.advance(); err != nil {
+				return err
+			}
+		}
+		text := strings.TrimSuffix(line, "\n")
+		newline, last := text != line, s.usesLast == true && input.ok == false
+		deleted := false
+	script:
+		for _, command := range s.script {
+			if command.selects(number, text, last) == false {
+				continue
+			}
+			var err error
+			switch command.name {
+			case 'd':
+				deleted = true
+				break script
+			case 'p':
+				err = out.writeLine(text, newline)
+			case 's':
+				var replaced bool
+				if text, replaced = command.substitution.apply(text); replaced == true && command.substitution.print == true {
+					err = out.writeLine(text, newline)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if deleted == false && s.quiet == false {
+			if err := out.writeLine(text, newline); err != nil {
+				return err
+			}
+		}
+
+		if input.in != nil {
+			if err := out.flushIfWaiting(input.in); err != nil {
+				return err
+			}
+		}
+		if s.usesLast == false {
+			if err := input.advance(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeLine is a method for writing line with text, newline is false for the last line of file without new line
+func (o *sedOutput) writeLine(text string, newline bool) error {
+	if o.missingNewline == true {
+		text = "\n" + text
+	}
+	if newline == true {
+		text += "\n"
+	}
+	o.missingNewline = !newline
+	return o.WriteString(text)
+}
+
+// advance is a method for reading the next line, the next files are opened when the current file ends
+func (i *sedInput) advance() error {
+	for {
+		if i.in == nil {
+			if len(i.names) == 0 {
+				i.ok = false
+				return nil
+			}
+			i.name, i.names = i.names[0], i.names[1:]
+			file, err := openInputFile(i.command.path, i.name, i.inputFile)
+			if err != nil {
+				i.errs = append(i.errs, err)
+				continue
+			}
+			i.file, i.in = file, newStreamReader(i.command, file)
+		}
+		line, err := i.in.ReadString('\n')
+		if err == ErrStoppedExec {
+			return err
+		}
+		if err != nil {
+			if err != io.EOF {
+				i.errs = append(i.errs, fmt.Errorf("%s - %w", i.name, err))
+			}
+			i.close()
+		}
+		if line != "" {
+			i.next, i.ok = line, true
+			return nil
+		}
+	}
+}
+
+// close is a method for closing the current file
+func (i *sedInput) close() {
+	if i.file != nil && i.file != i.inputFile {
+		i.file.Close()
+	}
+	i.file, i.in = nil, nil
+}
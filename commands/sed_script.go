@@ -0,0 +1,422 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sedAddress is address of sed command - number of line, the last line or regular expression
+type sedAddress struct {
+	line  int            // line is the number of the line, it is -1 for the other addresses and 0 for the start of 0,/re/
+	last  bool           // last is true for $, the last line
+	regex *regexp.Regexp // regex is the regular expression of /re/
+}
+
+// sedCommand is command of sed script with its addresses
+type sedCommand struct {
+	start        *sedAddress // start is nil when the command does not have addresses
+	end          *sedAddress // end is nil when the command does not have range of lines
+	negate       bool        // negate is true for the commands with ! after the addresses, which are run for the lines that are not selected
+	name         rune        // name is d, p or s
+	substitution *sedSubstitution
+	active       bool // active is true when the current line is in the range of the command
+}
+
+// sedSubstitution is the regular expression, the replacement and the flags of s command
+type sedSubstitution struct {
+	regex       *regexp.Regexp
+	replacement []sedReplacementPart
+	global      bool
+	occurrence  int  // occurrence is the number of the first match, which is replaced, it is 1 without number flag
+	print       bool // print is true for p flag, the line is written when there is replacement
+}
+
+// sedReplacementPart is text of the replacement of s command or group of the match, & is the group 0
+type sedReplacementPart struct {
+	text  string
+	group int // group is -1 for text
+}
+
+// sedParser is parser of sed script, it keeps the last regular expression, which is used for the empty regular expressions like in s//x/
+type sedParser struct {
+	text      []rune
+	pos       int
+	extended  bool
+	lastRegex *regexp.Regexp
+}
+
+// parseSedScript function parses script of sed command, the commands are separated with new lines or ;
+// Without extended, the regular expressions are basic regular expressions.
+func parseSedScript(script string, extended bool) ([]*sedCommand, error) {
+	p := &sedParser{text: []rune(script), extended: extended}
+	var commands []*sedCommand
+	for {
+		p.skip(" \t\n;")
+		if p.pos == len(p.text) {
+			return commands, nil
+		}
+		command, err := p.parseCommand()
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+}
+
+// peek is a method returning the current character, it is 0 at the end of the script
+func (p *sedParser) peek() rune {
+	if p.pos == len(p.text) {
+		return 0
+	}
+	return p.text[p.pos]
+}
+
+// skip is a method for skipping the characters in chars
+func (p *sedParser) skip(chars string) {
+	for p.pos < len(p.text) && strings.ContainsRune(chars, p.text[p.pos]) {
+		p.pos++
+	}
+}
+
+// parseCommand is a method for parsing one command with its addresses
+func (p *sedParser) parseCommand() (*sedCommand, error) {
+	start := p.pos
+	command := &sedCommand{}
+	var err error
+	if command.start, err = p.parseAddress(); err != nil {
+		return nil, err
+	}
+	if command.start != nil && p.peek() == ',' {
+		p.pos++
+		p.skip(" \t")
+		if command.end, err = p.parseAddress(); err != nil {
+			return nil, err
+		}
+		if command.end == nil {
+			return nil, fmt.Errorf("%s - %w", string(p.text[start:p.pos]), ErrSedInvalidAddress)
+		}
+	}
+	// like in sed, line 0 can only start range which ends with regular expression
+	if (command.start != nil && command.start.line == 0 && (command.end == nil || command.end.regex == nil)) ||
+		(command.end != nil && command.end.line == 0) {
+		return nil, fmt.Errorf("%s - %w", string(p.text[start:p.pos]), ErrSedInvalidAddress)
+	}
+	p.skip(" \t")
+	if p.peek() == '!' {
+		command.negate = true
+		p.pos++
+		p.skip(" \t")
+	}
+
+	command.name = p.peek()
+	switch command.name {
+	case 'd', 'p':
+		p.pos++
+	case 's':
+		p.pos++
+		if command.substitution, err = p.parseSubstitution(); err != nil {
+			return nil, err
+		}
+	case 0, '\n', ';':
+		return nil, fmt.Errorf("%s - %w", string(p.text[start:p.pos]), ErrSedMissingCommand)
+	default:
+		return nil, fmt.Errorf("%c - %w", command.name, ErrSedUnknownCommand)
+	}
+	p.skip(" \t")
+	if p.pos < len(p.text) && p.peek() != '\n' && p.peek() != ';' {
+		return nil, fmt.Errorf("%s - %w", string(p.text[start:]), ErrSedExtraCharacters)
+	}
+	return command, nil
+}
+
+// parseAddress is a method for parsing address - number, $, /re/ or \cREc, the regular expressions can be followed by I for ignoring case.
+// The result is nil when there is no address.
+func (p *sedParser) parseAddress() (*sedAddress, error) {
+	switch r := p.peek(); {
+	case r >= '0' && r <= '9':
+		start := p.pos
+		for r := p.peek(); r >= '0' && r <= '9'; r = p.peek() {
+			p.pos++
+		}
+		line, err := strconv.Atoi(string(p.text[start:p.pos]))
+		if err != nil {
+			return nil, fmt.Errorf("%s - %w", string(p.text[start:p.pos]), ErrSedInvalidAddress)
+		}
+		return &sedAddress{line: line}, nil
+	case r == '$':
+		p.pos++
+		return &sedAddress{line: -1, last: true}, nil
+	case r == '/', r == '\\':
+		start := p.pos
+		if r == '\\' {
+			p.pos++
+		}
+		if p.pos == len(p.text) || p.peek() == '\n' {
+			return nil, fmt.Errorf("%s - %w", string(p.text[start:]), ErrSedUnterminated)
+		}
+		delimiter := p.peek()
+		p.pos++
+		pattern, ok := p.readPart(delimiter, true)
+		if ok == false {
+			return nil, fmt.Errorf("%s - %w", string(p.text[start:p.pos]), ErrSedUnterminated)
+		}
+		ignoreCase := p.peek() == 'I'
+		if ignoreCase == true {
+			p.pos++
+		}
+		regex, err := p.compile(pattern, delimiter, ignoreCase)
+		if err != nil {
+			return nil, err
+		}
+		return &sedAddress{line: -1, regex: regex}, nil
+	}
+	return nil, nil
+}
+
+// readPart is a method for reading until delimiter, which is not escaped, the result is false if there is no such delimiter on the line.
+// The escapes are left in the result. For regular expressions, the delimiter can also be in bracket expression like [/].
+func (p *sedParser) readPart(delimiter rune, regex bool) (string, bool) {
+	start := p.pos
+	for ; p.pos < len(p.text) && p.text[p.pos] != '\n'; p.pos++ {
+		switch p.text[p.pos] {
+		case delimiter:
+			p.pos++
+			return string(p.text[start : p.pos-1]), true
+		case '\\':
+			p.pos++
+		case '[':
+			if regex == false {
+				break
+			}
+			end := p.pos + 1
+			if end < len(p.text) && p.text[end] == '^' {
+				end++
+			}
+			if end < len(p.text) && p.text[end] == ']' { // ] is part of the list if it is first
+				end++
+			}
+			for end < len(p.text) && p.text[end] != ']' && p.text[end] != '\n' {
+				if p.text[end] == '[' && end+1 < len(p.text) && p.text[end+1] == ':' { // ] of class like [:alpha:] does not end the list
+					for close := end + 2; close+1 < len(p.text); close++ {
+						if p.text[close] == ':' && p.text[close+1] == ']' {
+							end = close + 1
+							break
+						}
+					}
+				}
+				end++
+			}
+			if end < len(p.text) && p.text[end] == ']' {
+				p.pos = end
+			}
+		}
+	}
+	return "", false
+}
+
+// compile is a method for compiling regular expression, which was between delimiter, \ before the delimiter is removed.
+// The empty regular expression is the last compiled regular expression.
+func (p *sedParser) compile(pattern string, delimiter rune, ignoreCase bool) (*regexp.Regexp, error) {
+	if pattern == "" {
+		if p.lastRegex == nil {
+			return nil, fmt.Errorf("no previous regular expression - %w", ErrSedInvalidPattern)
+		}
+		return p.lastRegex, nil
+	}
+	var expression strings.Builder
+	for text := []rune(pattern); len(text) > 0; text = text[1:] {
+		switch {
+		case text[0] == '\\' && len(text) > 1 && text[1] == delimiter: // like in sed, the delimiter has its meaning in the regular expression
+			expression.WriteRune(delimiter)
+			text = text[1:]
+		case text[0] == '\\' && len(text) > 1:
+			expression.WriteString(string(text[:2]))
+			text = text[1:]
+		default:
+			expression.WriteRune(text[0])
+		}
+	}
+	result := expression.String()
+	if p.extended == false {
+		result = basicToExtended(result)
+	}
+	if ignoreCase == true {
+		result = "(?i)" + result
+	}
+	regex, err := regexp.Compile(result)
+	if err != nil {
+		return nil, fmt.Errorf("%s - %w", pattern, ErrSedInvalidPattern)
+	}
+	p.lastRegex = regex
+	return regex, nil
+}
+
+// parseSubstitution is a method for parsing s command after s - the regular expression, the replacement and the flags g, p, i and number
+func (p *sedParser) parseSubstitution() (*sedSubstitution, error) {
+	start := p.pos - 1
+	if p.pos == len(p.text) || p.peek() == '\n' || p.peek() == '\\' {
+		return nil, fmt.Errorf("%s - %w", string(p.text[start:p.pos]), ErrSedUnterminated)
+	}
+	delimiter := p.peek()
+	p.pos++
+	pattern, ok := p.readPart(delimiter, true)
+	if ok == false {
+		return nil, fmt.Errorf("%s - %w", string(p.text[start:]), ErrSedUnterminated)
+	}
+	replacement, ok := p.readPart(delimiter, false)
+	if ok == false {
+		return nil, fmt.Errorf("%s - %w", string(p.text[start:]), ErrSedUnterminated)
+	}
+
+	substitution := &sedSubstitution{occurrence: 1}
+	ignoreCase, hasOccurrence := false, false
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\n;", p.peek()) == false {
+		switch r := p.peek(); {
+		case r == 'g':
+			substitution.global = true
+		case r == 'p':
+			substitution.print = true
+		case r == 'i', r == 'I':
+			ignoreCase = true
+		case r >= '0' && r <= '9' && hasOccurrence == false:
+			number := p.pos
+			for r := p.peek(); r >= '0' && r <= '9'; r = p.peek() {
+				p.pos++
+			}
+			occurrence, err := strconv.Atoi(string(p.text[number:p.pos]))
+			if err != nil || occurrence == 0 {
+				return nil, fmt.Errorf("%s - %w", string(p.text[number:p.pos]), ErrSedUnknownFlag)
+			}
+			substitution.occurrence, hasOccurrence = occurrence, true
+			continue
+		default:
+			return nil, fmt.Errorf("%c - %w", r, ErrSedUnknownFlag)
+		}
+		p.pos++
+	}
+
+	var err error
+	if substitution.regex, err = p.compile(pattern, delimiter, ignoreCase); err != nil {
+		return nil, err
+	}
+	substitution.replacement, err = parseSedReplacement(replacement, substitution.regex.NumSubexp())
+	return substitution, err
+}
+
+// parseSedReplacement function parses the replacement of s command, where & is the match, \1 to \9 are the groups of the match
+// and \n is new line. The number of the groups in the regular expression is groups.
+func parseSedReplacement(replacement string, groups int) ([]sedReplacementPart, error) {
+	var parts []sedReplacementPart
+	var text strings.Builder
+	addGroup := func(group int) {
+		if text.Len() > 0 {
+			parts = append(parts, sedReplacementPart{text.String(), -1})
+			text.Reset()
+		}
+		parts = append(parts, sedReplacementPart{"", group})
+	}
+	for runes := []rune(replacement); len(runes) > 0; runes = runes[1:] {
+		switch {
+		case runes[0] == '&':
+			addGroup(0)
+		case runes[0] == '\\' && len(runes) > 1:
+			runes = runes[1:]
+			switch r := runes[0]; {
+			case r >= '0' && r <= '9':
+				if int(r-'0') > groups {
+					return nil, fmt.Errorf("\\%c - %w", r, ErrSedInvalidReference)
+				}
+				addGroup(int(r - '0'))
+			case r == 'n':
+				text.WriteByte('\n')
+			case r == 't':
+				text.WriteByte('\t')
+			default:
+				text.WriteRune(r)
+			}
+		default:
+			text.WriteRune(runes[0])
+		}
+	}
+	if text.Len() > 0 {
+		parts = append(parts, sedReplacementPart{text.String(), -1})
+	}
+	return parts, nil
+}
+
+// matches is a method for checking if the line with number and text is selected by the address, last is true for the last line
+func (a *sedAddress) matches(number int, text string, last bool) bool {
+	switch {
+	case a.regex != nil:
+		return a.regex.MatchString(text)
+	case a.last == true:
+		return last
+	}
+	return a.line == number
+}
+
+// reset is a method for preparing the command for new input, the range of 0,/re/ is active from the start
+func (c *sedCommand) reset() {
+	c.active = c.start != nil && c.start.line == 0
+}
+
+// selects is a method for checking if the command is run for the line with number and text, last is true for the last line.
+// Like in sed, the end of range is checked from the line after its start and when the end is line number,
+// which is not after the start, the range has only one line.
+func (c *sedCommand) selects(number int, text string, last bool) bool {
+	return c.matchesAddresses(number, text, last) != c.negate
+}
+
+// matchesAddresses is a method for checking if the line is selected by the addresses of the command, it changes the state of the range
+func (c *sedCommand) matchesAddresses(number int, text string, last bool) bool {
+	switch {
+	case c.start == nil:
+		return true
+	case c.end == nil:
+		return c.start.matches(number, text, last)
+	case c.active == true:
+		if c.end.line > 0 {
+			if number >= c.end.line {
+				c.active = false
+			}
+			return number <= c.end.line
+		}
+		if c.end.matches(number, text, last) {
+			c.active = false
+		}
+		return true
+	case c.start.matches(number, text, last):
+		c.active = c.end.line < 0 || number < c.end.line
+		return true
+	}
+	return false
+}
+
+// apply is a method for replacing the matches in text, the result is false when there is no replacement
+func (s *sedSubstitution) apply(text string) (string, bool) {
+	limit := s.occurrence
+	if s.global == true {
+		limit = -1
+	}
+	matches := s.regex.FindAllStringSubmatchIndex(text, limit)
+	if len(matches) < s.occurrence {
+		return text, false
+	}
+	var result strings.Builder
+	last := 0
+	for _, match := range matches[s.occurrence-1:] {
+		result.WriteString(text[last:match[0]])
+		for _, part := range s.replacement {
+			if part.group == -1 {
+				result.WriteString(part.text)
+			} else if start := match[2*part.group]; start != -1 {
+				result.WriteString(text[start:match[2*part.group+1]])
+			}
+		}
+		last = match[1]
+	}
+	result.WriteString(text[last:])
+	return result.String(), true
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSed(t *testing.T) {
+	path, err := ioutil.TempDir("", "sed-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"words": "one two\nfoo bar\nthree\nfoo baz\nlast line", "letters": "a\nb\n", "slashes": "a/b c\n"})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"s/o/0/", "words"}, "0ne two\nf0o bar\nthree\nf0o baz\nlast line", nil},
+		{"", []string{"s/o/0/g", "words"}, "0ne tw0\nf00 bar\nthree\nf00 baz\nlast line", nil},
+		{"", []string{"s/o/0/2", "words"}, "one tw0\nfo0 bar\nthree\nfo0 baz\nlast line", nil},
+		{"", []string{"s/O/x/ig", "words"}, "xne twx\nfxx bar\nthree\nfxx baz\nlast line", nil},
+		{"", []string{`s/\(o*\)\(t\)/[\2\1]/`, "words"}, "one [t]wo\nfoo bar\n[t]hree\nfoo baz\nlas[t] line", nil},
+		{"", []string{"-E", `s/(o+)|(e)/<\1\2>/g`, "words"}, "<o>n<e> tw<o>\nf<oo> bar\nthr<e><e>\nf<oo> baz\nlast lin<e>", nil},
+		{"", []string{`s/o\+/O/g`, "words"}, "One twO\nfO bar\nthree\nfO baz\nlast line", nil},
+		{"", []string{"-n", "s/o/&&/gp", "words"}, "oone twoo\nfoooo bar\nfoooo baz\n", nil},
+		{"", []string{`s/$/\t;/`, "letters"}, "a\t;\nb\t;\n", nil},
+		{"", []string{`s/./\n/`, "letters"}, "\n\n\n\n", nil},
+		{"", []string{"s/x*/-/g", "letters"}, "-a-\n-b-\n", nil},
+		{"", []string{"/foo/s//X/g", "words"}, "one two\nX bar\nthree\nX baz\nlast line", nil},
+		{"", []string{"s/[/]/_/", "slashes"}, "a_b c\n", nil},
+		{"", []string{`s|a\|b|x|g`, "slashes"}, "a/b c\n", nil},
+		{"", []string{"-E", `s|a\|b|x|g`, "slashes"}, "x/x c\n", nil},
+		{"", []string{"2,3d", "words"}, "one two\nfoo baz\nlast line", nil},
+		{"", []string{"$d", "words"}, "one two\nfoo bar\nthree\nfoo baz\n", nil},
+		{"", []string{"2!d", "words"}, "foo bar\n", nil},
+		{"", []string{"3,1p", "words"}, "one two\nfoo bar\nthree\nthree\nfoo baz\nlast line", nil},
+		{"", []string{"/foo/p", "words"}, "one two\nfoo bar\nfoo bar\nthree\nfoo baz\nfoo baz\nlast line", nil},
+		{"", []string{"/foo/,/three/s/^/> /", "words"}, "one two\n> foo bar\n> three\n> foo baz\n> last line", nil},
+		{"", []string{"-n", "/foo/,$p", "words"}, "foo bar\nthree\nfoo baz\nlast line", nil},
+		{"", []string{"/foo/!s/e/E/", "words"}, "onE two\nfoo bar\nthrEe\nfoo baz\nlast linE", nil},
+		{"", []string{"0,/foo/s/foo/X/", "words"}, "one two\nX bar\nthree\nfoo baz\nlast line", nil},
+		{"", []string{"/FOO/Id", "words"}, "one two\nthree\nlast line", nil},
+		{"", []string{`\,foo,d`, "words"}, "one two\nthree\nlast line", nil},
+		{"", []string{"p", "words", "letters"},
+			"one two\none two\nfoo bar\nfoo bar\nthree\nthree\nfoo baz\nfoo baz\nlast line\nlast line\na\na\nb\nb\n", nil},
+		{"", []string{"-n", "$p", "words", "letters"}, "b\n", nil},
+		{"", []string{"-e", "1d", "-e", "s/a/A/", "letters", "words"}, "b\none two\nfoo bAr\nthree\nfoo bAz\nlAst line", nil},
+		{"", []string{"-n", "--expression=2p; 4p", "words"}, "foo bar\nfoo baz\n", nil},
+		{"x\ny\n", []string{"1d"}, "y\n", nil},
+		{"x\ny\n", []string{"s/^/-/", "letters", "-"}, "-a\n-b\n-x\n-y\n", nil},
+		{"", []string{"p", "missing", "letters"}, "a\na\nb\nb\n", os.ErrNotExist},
+		{"", []string{}, "", ErrSedNoScript},
+		{"", []string{"1", "words"}, "", ErrSedMissingCommand},
+		{"", []string{"k", "words"}, "", ErrSedUnknownCommand},
+		{"", []string{"p x", "words"}, "", ErrSedExtraCharacters},
+		{"", []string{"s/o/x", "words"}, "", ErrSedUnterminated},
+		{"", []string{"/o", "words"}, "", ErrSedUnterminated},
+		{"", []string{"s/o/x/z", "words"}, "", ErrSedUnknownFlag},
+		{"", []string{"s/o/x/0", "words"}, "", ErrSedUnknownFlag},
+		{"", []string{"1,", "words"}, "", ErrSedInvalidAddress},
+		{"", []string{"0p", "words"}, "", ErrSedInvalidAddress},
+		{"", []string{`s/\(o/x/`, "words"}, "", ErrSedInvalidPattern},
+		{"", []string{"s//x/", "words"}, "", ErrSedInvalidPattern},
+		{"", []string{`s/o/\1/`, "words"}, "", ErrSedInvalidReference},
+		{"", []string{"-i", "p"}, "", ErrSedNoInputFiles},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Sed test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Sed{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+func TestSedInPlace(t *testing.T) {
+	path, err := ioutil.TempDir("", "sed-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a": "one\ntwo\n", "b": "three\n", "dir/c": "four\n"})
+	if err := os.Chmod(filepath.Join(path, "b"), 0600); err != nil {
+		t.Fatalf("Fatal error - cannot change mode of file! - %v", err)
+	}
+
+	output, err := runTestCommand(t, &Sed{}, path, []string{"-i.bak", "s/o/0/;$p", "a", "b"})
+	if err != nil || output != "" {
+		t.Fatalf("Expected no error and output from sed -i, but got: %v and %q", err, output)
+	}
+	for name, expected := range map[string]string{"a": "0ne\ntw0\ntw0\n", "a.bak": "one\ntwo\n", "b": "three\nthree\n", "b.bak": "three\n"} {
+		if data := readTestFile(filepath.Join(path, name)); data != expected {
+			t.Errorf("Expected file %s to be %q, but got: %q", name, expected, data)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(path, "b")); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the mode of the edited file to be kept, but got: %v", info.Mode())
+	}
+
+	if _, err = runTestCommand(t, &Sed{}, path, []string{"--in-place=old_*", "1d", "dir/c", "missing"}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected error %v for missing file, but got: %v", os.ErrNotExist, err)
+	}
+	if _, err = runTestCommand(t, &Sed{}, path, []string{"-i", "1d", "dir"}); !errors.Is(err, ErrSedNotRegularFile) {
+		t.Errorf("Expected error %v for directory, but got: %v", ErrSedNotRegularFile, err)
+	}
+	if data := readTestFile(filepath.Join(path, "dir/c")); data != "" {
+		t.Errorf("Expected file dir/c to be empty, but got: %q", data)
+	}
+	if data := readTestFile(filepath.Join(path, "dir/old_c")); data != "four\n" {
+		t.Errorf("Expected backup dir/old_c to be %q, but got: %q", "four\n", data)
+	}
+
+	sed := &Sed{}
+	sed.InitStopSignalCatching()
+	sed.SendStopSignal()
+	if err := sed.Execute(CommandProperties{path, nil, nil, nil, nil, []string{"-i", "d", "a"}}); err != ErrStoppedExec {
+		t.Errorf("Expected error %v from stopped sed, but got: %v", ErrStoppedExec, err)
+	}
+	if data := readTestFile(filepath.Join(path, "a")); data != "0ne\ntw0\ntw0\n" {
+		t.Errorf("Expected stopped sed to keep file a, but got: %q", data)
+	}
+	if files, _ := ioutil.ReadDir(path); len(files) != 5 {
+		t.Errorf("Expected stopped sed to remove its temporary file, but got %d files", len(files))
+	}
+}
+
+func TestSedInPlaceBackupDirectory(t *testing.T) {
+	path, err := ioutil.TempDir("", "sed-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"dir/a": "one\n", "dir/b": "two\n", "bak/c": "three\n"})
+
+	// the suffix with / is relative to the current directory and the * in it is replaced with the name of the file without its directory
+	if _, err = runTestCommand(t, &Sed{}, path, []string{"-ibak/*_old", "s/o/0/", "dir/a"}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	for name, expected := range map[string]string{"dir/a": "0ne\n", "bak/a_old": "one\n", "dir/bak/a_old": "<missing>"} {
+		if data := readTestFile(filepath.Join(path, name)); data != expected {
+			t.Errorf("Expected file %s to be %q, but got: %q", name, expected, data)
+		}
+	}
+
+	if _, err = runTestCommand(t, &Sed{}, path, []string{"--in-place=missing/*", "s/t/T/", "dir/b"}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected error %v for missing backup directory, but got: %v", os.ErrNotExist, err)
+	}
+	if data := readTestFile(filepath.Join(path, "dir/b")); data != "two\n" {
+		t.Errorf("Expected file dir/b to be kept without its backup, but got: %q", data)
+	}
+
+	// the backup with the name of the file itself is not made, instead of removing the file
+	if _, err = runTestCommand(t, &Sed{}, path, []string{"-i./dir/*", "s/t/T/", "dir/b"}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if data := readTestFile(filepath.Join(path, "dir/b")); data != "Two\n" {
+		t.Errorf("Expected file dir/b to be %q, but got: %q", "Two\n", data)
+	}
+	if files, _ := ioutil.ReadDir(filepath.Join(path, "dir")); len(files) != 2 {
+		t.Errorf("Expected no temporary files in dir, but got %d files", len(files))
+	}
+}
@@ -0,0 +1,370 @@
+package commands
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrSortInvalidKey indicates that the value of -k option of sort command is not valid key
+	ErrSortInvalidKey = errors.New("invalid key")
+	// ErrSortInvalidSeparator indicates that the value of -t option of sort command is not one character
+	ErrSortInvalidSeparator = errors.New("the separator must be one character")
+	// ErrSortInvalidSize indicates that the value of -S option of sort command is not valid size
+	ErrSortInvalidSize = errors.New("invalid buffer size")
+)
+
+const (
+	// DefaultSortBufferSize is the size of the lines, which sort command keeps in memory, the bigger inputs are sorted in temporary files
+	DefaultSortBufferSize = 256 << 20
+	// sortLineOverhead is the memory for every line, added to its length, when the size of the lines in memory is counted
+	sortLineOverhead = 32
+	// sortMergeWays is the maximum number of temporary files, which are merged at the same time
+	sortMergeWays = 16
+)
+
+// Sort is a structure for sort command, implementing ExecuteCommand interface
+type Sort struct {
+	path          string
+	stopExecution chan struct{}
+	order         *sortOrder
+	chunks        []string // chunks are the names of the temporary files with sorted lines
+}
+
+// GetName is a getter for command name
+func (s *Sort) GetName() string {
+	return "sort"
+}
+
+// GetPath is a getter for path
+func (s *Sort) GetPath() string {
+	return s.path
+}
+
+// Clone is a method for cloning sort command
+func (s *Sort) Clone() ExecuteCommand {
+	clone := *s
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (s *Sort) InitStopSignalCatching() {
+	s.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (s *Sort) SendStopSignal() {
+	s.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (s *Sort) IsStopSignalReceived() bool {
+	select {
+	case <-s.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of sort command
+//
+// The lines of all files in the arguments are written sorted, - is for the input and without arguments the input is sorted.
+// The options are like in sort: -n compares numbers, -h compares numbers with suffixes like 2K and 1G, -V compares version numbers,
+// -f ignores case, -b ignores the leading blanks, -r reverses the order and -u writes only the first of the equal lines.
+// With -k POS1[,POS2] the lines are compared by the fields from POS1 to POS2, where position is FIELD[.CHAR] followed by the ordering options of the key.
+// The fields are separated by the blanks or by the separator given with -t.
+//
+// When the size of the lines is more than the buffer size, which can be given with -S, the sorted parts are written to temporary files,
+// which are merged at the end, so the input can be bigger than the memory.
+func (s *Sort) Execute(cp CommandProperties) error {
+	s.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	short, long := "nhVfbruk:t:S:", []string{"numeric-sort", "human-numeric-sort", "version-sort", "ignore-case",
+		"ignore-leading-blanks", "reverse", "unique", "key=", "field-separator=", "buffer-size="}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+	global := sortKey{startField: 1, startChar: 1, blanks: has("b", "ignore-leading-blanks"), foldCase: has("f", "ignore-case"),
+		numeric: has("n", "numeric-sort"), human: has("h", "human-numeric-sort"), version: has("V", "version-sort"), reverse: has("r", "reverse")}
+	s.order = &sortOrder{unique: has("u", "unique"), reverse: global.reverse}
+	walkOptions(cp, short, long, func(name string, value string) {
+		if (name != "k" && name != "key") || err != nil {
+			return
+		}
+		var key sortKey
+		if key, err = parseSortKey(value); key.hasFlags() == false {
+			key.blanks, key.foldCase, key.numeric, key.human, key.version, key.reverse =
+				global.blanks, global.foldCase, global.numeric, global.human, global.version, global.reverse
+		}
+		s.order.keys = append(s.order.keys, key)
+	})
+	if err != nil {
+		return err
+	}
+	if len(s.order.keys) == 0 && global.hasFlags() == true {
+		s.order.keys = []sortKey{global}
+	}
+	for _, name := range []string{"t", "field-separator"} {
+		if options.has(name) {
+			if separator := options.value(name); utf8.RuneCountInString(separator) != 1 {
+				return fmt.Errorf("%s - %w", separator, ErrSortInvalidSeparator)
+			}
+			s.order.separator = options.value(name)
+		}
+	}
+	bufferSize := int64(DefaultSortBufferSize)
+	for _, name := range []string{"S", "buffer-size"} {
+		if options.has(name) {
+			if bufferSize, err = parseSortSize(options.value(name)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+
+	s.chunks = nil
+	defer func() {
+		for _, chunk := range s.chunks {
+			os.Remove(chunk)
+		}
+	}()
+	var lines []string
+	var size int64 // size is the memory used by lines
+	for _, argument := range arguments {
+		file, err := openInputFile(s.path, argument, inputFile)
+		if err != nil {
+			return err
+		}
+		in := newStreamReader(s, file)
+		for {
+			line, errRead := in.ReadString('\n')
+			if line != "" {
+				line = strings.TrimSuffix(line, "\n")
+				lines, size = append(lines, line), size+int64(len(line))+sortLineOverhead
+				if size >= bufferSize {
+					if err = s.writeChunk(lines); err != nil {
+						break
+					}
+					lines, size = nil, 0
+				}
+			}
+			if errRead != nil {
+				if errRead != io.EOF {
+					err = errRead
+				}
+				break
+			}
+		}
+		if file != inputFile {
+			file.Close()
+		}
+		if err == ErrStoppedExec {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("%s - %w", argument, err)
+		}
+	}
+
+	out := newStreamWriter(s, outputFile)
+	if len(s.chunks) == 0 {
+		sort.SliceStable(lines, func(i, j int) bool { return s.order.compare(lines[i], lines[j]) < 0 })
+		last := ""
+		for ind, line := range lines {
+			if s.order.unique == true && ind > 0 && s.order.compare(last, line) == 0 {
+				continue
+			}
+			if err := out.WriteString(line + "\n"); err != nil {
+				return out.finish(err)
+			}
+			last = line
+		}
+		return out.finish(nil)
+	}
+	if len(lines) > 0 {
+		if err := s.writeChunk(lines); err != nil {
+			return err
+		}
+	}
+	return out.finish(s.mergeChunks(out))
+}
+
+// parseSortSize function parses the value of -S option of sort command, it is number of bytes, which can be followed by K, M, G or T
+func parseSortSize(value string) (int64, error) {
+	number, multiplier := value, int64(1)
+	if value != "" {
+		if suffix := strings.IndexByte("BKMGT", strings.ToUpper(value)[len(value)-1]); suffix != -1 {
+			number, multiplier = value[:len(value)-1], 1<<(10*suffix)
+		}
+	}
+	size, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("%s - %w", value, ErrSortInvalidSize)
+	}
+	return size * multiplier, nil
+}
+
+// writeChunk is a method for sorting lines and writing them to temporary file, which is merged at the end
+func (s *Sort) writeChunk(lines []string) error {
+	sort.SliceStable(lines, func(i, j int) bool { return s.order.compare(lines[i], lines[j]) < 0 })
+	file, err := ioutil.TempFile("", "go-terminal-sort-")
+	if err != nil {
+		return err
+	}
+	s.chunks = append(s.chunks, file.Name())
+	writer := bufio.NewWriterSize(file, streamBufferSize)
+	for _, line := range lines {
+		if s.IsStopSignalReceived() == true {
+			file.Close()
+			return ErrStoppedExec
+		}
+		if _, err := writer.WriteString(line + "\n"); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// sortSource is one of the sorted files, which are merged, with its current line
+type sortSource struct {
+	reader *bufio.Reader
+	line   string // line is without its new line
+	index  int    // index is the position of the file, the lines of the earlier files are first when the lines are equal
+}
+
+// sortHeap is a heap of the sources in merge, implementing heap.Interface, the source with the first line is on the top
+type sortHeap struct {
+	sources []*sortSource
+	order   *sortOrder
+}
+
+func (h *sortHeap) Len() int { return len(h.sources) }
+func (h *sortHeap) Less(i, j int) bool {
+	if result := h.order.compare(h.sources[i].line, h.sources[j].line); result != 0 {
+		return result < 0
+	}
+	return h.sources[i].index < h.sources[j].index
+}
+func (h *sortHeap) Swap(i, j int)      { h.sources[i], h.sources[j] = h.sources[j], h.sources[i] }
+func (h *sortHeap) Push(x interface{}) { h.sources = append(h.sources, x.(*sortSource)) }
+func (h *sortHeap) Pop() interface{} {
+	last := h.sources[len(h.sources)-1]
+	h.sources = h.sources[:len(h.sources)-1]
+	return last
+}
+
+// mergeChunks is a method for merging the temporary files with sorted lines to out.
+// When there are more than sortMergeWays files, groups of them are merged to new temporary files first,
+// every new file takes the place of its group, so the lines of the earlier files stay first.
+func (s *Sort) mergeChunks(out *streamWriter) error {
+	for len(s.chunks) > sortMergeWays {
+		for start := 0; start < len(s.chunks); start++ {
+			if err := s.mergeGroup(start); err != nil {
+				return err
+			}
+		}
+	}
+	return s.merge(s.chunks, out.WriteString)
+}
+
+// mergeGroup is a method for merging the group of at most sortMergeWays files, which starts at position start of the chunks, to new temporary file
+func (s *Sort) mergeGroup(start int) error {
+	end := start + sortMergeWays
+	if end > len(s.chunks) {
+		end = len(s.chunks)
+	}
+	if end-start < 2 {
+		return nil
+	}
+	file, err := ioutil.TempFile("", "go-terminal-sort-")
+	if err != nil {
+		return err
+	}
+	group := append([]string(nil), s.chunks[start:end]...)
+	s.chunks = append(s.chunks[:start], append([]string{file.Name()}, s.chunks[end:]...)...)
+	writer := bufio.NewWriterSize(file, streamBufferSize)
+	err = s.merge(group, func(line string) error {
+		if s.IsStopSignalReceived() == true {
+			return ErrStoppedExec
+		}
+		_, err := writer.WriteString(line)
+		return err
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	for _, chunk := range group {
+		os.Remove(chunk)
+	}
+	return err
+}
+
+// merge is a method for merging the files with names, which have sorted lines, every line of the result is given to write with its new line.
+// When only unique lines are written, only the first of the equal lines is given.
+func (s *Sort) merge(names []string, write func(line string) error) error {
+	h := &sortHeap{order: s.order}
+	for ind, name := range names {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		source := &sortSource{reader: bufio.NewReaderSize(file, streamBufferSize/sortMergeWays), index: ind}
+		if source.line, err = source.reader.ReadString('\n'); err == nil {
+			source.line = strings.TrimSuffix(source.line, "\n")
+			h.sources = append(h.sources, source)
+		} else if err != io.EOF {
+			return err
+		}
+	}
+	heap.Init(h)
+
+	last, written := "", false
+	for h.Len() > 0 {
+		source := h.sources[0]
+		line := source.line
+		if s.order.unique == false || written == false || s.order.compare(last, line) != 0 {
+			if err := write(line + "\n"); err != nil {
+				return err
+			}
+			last, written = line, true
+		}
+		var err error
+		if source.line, err = source.reader.ReadString('\n'); err == io.EOF {
+			heap.Pop(h)
+		} else if err != nil {
+			return err
+		} else {
+			source.line = strings.TrimSuffix(source.line, "\n")
+			heap.Fix(h, 0)
+		}
+	}
+	return nil
+}
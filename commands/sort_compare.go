@@ -0,0 +1,390 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sortKey is a key of sort command, given with -k POS1[,POS2], where the positions are FIELD[.CHAR] followed by ordering options
+type sortKey struct {
+	startField int
+	startChar  int // startChar is the first character of the key in the start field, counted from 1
+	endField   int // endField is 0 when the key is until the end of the line
+	endChar    int // endChar is the last character of the key in the end field, 0 is for the end of the field
+	blanks     bool
+	foldCase   bool
+	numeric    bool
+	human      bool
+	version    bool
+	reverse    bool
+}
+
+// sortOrder stores how sort command compares the lines
+type sortOrder struct {
+	keys      []sortKey
+	separator string // separator is the field separator given with -t, without it fields are separated by blanks
+	unique    bool
+	reverse   bool // reverse is for the comparison of whole lines, when the keys are equal
+}
+
+// setFlag is a method for setting the ordering option with letter of the key, it returns false for unknown letter
+func (k *sortKey) setFlag(letter byte) bool {
+	switch letter {
+	case 'b':
+		k.blanks = true
+	case 'f':
+		k.foldCase = true
+	case 'n':
+		k.numeric = true
+	case 'h':
+		k.human = true
+	case 'V':
+		k.version = true
+	case 'r':
+		k.reverse = true
+	default:
+		return false
+	}
+	return true
+}
+
+// hasFlags is a method for checking if the key has its own ordering options, otherwise the global options are used for it
+func (k *sortKey) hasFlags() bool {
+	return k.blanks || k.foldCase || k.numeric || k.human || k.version || k.reverse
+}
+
+// parseSortKey function parses the value of -k option of sort command
+func parseSortKey(value string) (sortKey, error) {
+	var key sortKey
+	start, end := value, ""
+	if comma := strings.IndexByte(value, ','); comma != -1 {
+		start, end = value[:comma], value[comma+1:]
+	}
+	parsePosition := func(position string, field *int, char *int) bool {
+		digits := strings.IndexFunc(position, func(r rune) bool { return r < '0' || r > '9' })
+		if digits == -1 {
+			digits = len(position)
+		}
+		number, err := strconv.Atoi(position[:digits])
+		if err != nil {
+			return false
+		}
+		*field, position = number, position[digits:]
+		if strings.HasPrefix(position, ".") {
+			position = position[1:]
+			if digits = strings.IndexFunc(position, func(r rune) bool { return r < '0' || r > '9' }); digits == -1 {
+				digits = len(position)
+			}
+			if *char, err = strconv.Atoi(position[:digits]); err != nil {
+				return false
+			}
+			position = position[digits:]
+		}
+		for ind := 0; ind < len(position); ind++ {
+			if key.setFlag(position[ind]) == false {
+				return false
+			}
+		}
+		return true
+	}
+	if parsePosition(start, &key.startField, &key.startChar) == false || key.startField < 1 || key.startChar < 0 {
+		return sortKey{}, fmt.Errorf("%s - %w", value, ErrSortInvalidKey)
+	}
+	if key.startChar == 0 {
+		key.startChar = 1
+	}
+	if end != "" && (parsePosition(end, &key.endField, &key.endChar) == false || key.endField < 1) {
+		return sortKey{}, fmt.Errorf("%s - %w", value, ErrSortInvalidKey)
+	}
+	return key, nil
+}
+
+// isBlank function checks if r is blank, which separates the fields when there is no separator
+func isBlank(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// fields is a method returning the start and the end of every field in line.
+// Without separator, the blanks before field are part of it, like in sort.
+func (o *sortOrder) fields(line string) [][2]int {
+	var result [][2]int
+	if o.separator != "" {
+		start := 0
+		for {
+			end := strings.Index(line[start:], o.separator)
+			if end == -1 {
+				return append(result, [2]int{start, len(line)})
+			}
+			result = append(result, [2]int{start, start + end})
+			start += end + len(o.separator)
+		}
+	}
+	for pos := 0; pos < len(line); {
+		start := pos
+		for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+			pos++
+		}
+		for pos < len(line) && line[pos] != ' ' && line[pos] != '\t' {
+			pos++
+		}
+		result = append(result, [2]int{start, pos})
+	}
+	return result
+}
+
+// skipChars function returns the position in text after count characters from pos, but not after limit
+func skipChars(text string, pos int, count int, limit int) int {
+	for ; count > 0 && pos < limit; count-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
+
+// keyText is a method returning the part of line, which is compared for key
+func (o *sortOrder) keyText(line string, key sortKey) string {
+	fields := o.fields(line)
+	if key.startField > len(fields) {
+		return ""
+	}
+	field := fields[key.startField-1]
+	start := field[0]
+	if key.blanks == true {
+		for start < field[1] && isBlank(rune(line[start])) {
+			start++
+		}
+	}
+	start = skipChars(line, start, key.startChar-1, len(line))
+
+	end := len(line)
+	if key.endField > 0 && key.endField <= len(fields) {
+		field = fields[key.endField-1]
+		if end = field[1]; key.endChar > 0 {
+			fieldStart := field[0]
+			if key.blanks == true {
+				for fieldStart < field[1] && isBlank(rune(line[fieldStart])) {
+					fieldStart++
+				}
+			}
+			end = skipChars(line, fieldStart, key.endChar, field[1])
+		}
+	}
+	if end <= start {
+		return ""
+	}
+	return line[start:end]
+}
+
+// compare is a method for comparing lines a and b, the result is negative when a is before b, positive when it is after and 0 if they are equal.
+// When the keys are equal, the whole lines are compared, unless only unique lines are written.
+func (o *sortOrder) compare(a string, b string) int {
+	for _, key := range o.keys {
+		result := key.compare(o.keyText(a, key), o.keyText(b, key))
+		if key.reverse == true {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	if o.unique == true && len(o.keys) > 0 {
+		return 0
+	}
+	result := strings.Compare(a, b)
+	if o.reverse == true {
+		result = -result
+	}
+	return result
+}
+
+// compare is a method for comparing the texts of the key in two lines, without reversing
+func (k *sortKey) compare(a string, b string) int {
+	switch {
+	case k.numeric == true:
+		return compareNumbers(parseSortNumber(a), parseSortNumber(b))
+	case k.human == true:
+		return compareHumanNumbers(a, b)
+	case k.version == true:
+		return compareVersions(a, b)
+	case k.foldCase == true:
+		return strings.Compare(strings.ToUpper(a), strings.ToUpper(b))
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNumbers function compares two numbers
+func compareNumbers(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sortNumberEnd function returns the end of the number at the start of text, after the leading blanks, for -n option of sort command.
+// The number can have - before it and decimal point, text without number is 0.
+func sortNumberEnd(text string) (int, int) {
+	start := 0
+	for start < len(text) && unicode.IsSpace(rune(text[start])) {
+		start++
+	}
+	end := start
+	if end < len(text) && text[end] == '-' {
+		end++
+	}
+	for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+		end++
+	}
+	if end < len(text) && text[end] == '.' {
+		for end++; end < len(text) && text[end] >= '0' && text[end] <= '9'; end++ {
+		}
+	}
+	return start, end
+}
+
+// parseSortNumber function returns the number at the start of text for -n option of sort command
+func parseSortNumber(text string) float64 {
+	start, end := sortNumberEnd(text)
+	number, err := strconv.ParseFloat(strings.TrimSuffix(text[start:end], "."), 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// compareHumanNumbers function compares numbers with suffixes like 2K and 1G for -h option of sort command.
+// The numbers are compared by their sign, then by their suffix and then by their value.
+func compareHumanNumbers(a string, b string) int {
+	parse := func(text string) (float64, int) {
+		_, end := sortNumberEnd(text)
+		number := parseSortNumber(text)
+		suffix := 0
+		if end < len(text) {
+			suffix = strings.IndexByte("KMGTPEZY", byte(unicode.ToUpper(rune(text[end])))) + 1
+		}
+		sign := compareNumbers(number, 0)
+		return number, sign * (suffix + 1)
+	}
+	numberA, orderA := parse(a)
+	numberB, orderB := parse(b)
+	if orderA != orderB {
+		return compareNumbers(float64(orderA), float64(orderB))
+	}
+	return compareNumbers(numberA, numberB)
+}
+
+// versionOrder function returns the order of the character at pos in text for compareVersions.
+// Digits and the end of text are 0, ~ is before them, letters are after them and the other characters are after the letters.
+func versionOrder(text string, pos int) int {
+	switch {
+	case pos >= len(text) || (text[pos] >= '0' && text[pos] <= '9'):
+		return 0
+	case text[pos] == '~':
+		return -1
+	case unicode.IsLetter(rune(text[pos])):
+		return int(text[pos])
+	}
+	return int(text[pos]) + 256
+}
+
+// compareVersions function compares texts with version numbers for -V option of sort command, like filevercmp of sort.
+// The empty text is first, then ., .. and the other names starting with dot. The texts without their suffixes like .tar.gz
+// are compared first and only when they are equal, the whole texts are compared.
+func compareVersions(a string, b string) int {
+	switch {
+	case a == "" || b == "":
+		return compareNumbers(float64(len(a)), float64(len(b)))
+	case a[0] == '.' && b[0] == '.':
+		for _, special := range []string{".", ".."} {
+			switch {
+			case a == special && b == special:
+				return 0
+			case a == special:
+				return -1
+			case b == special:
+				return 1
+			}
+		}
+	case a[0] == '.':
+		return -1
+	case b[0] == '.':
+		return 1
+	}
+	prefixA, prefixB := versionPrefixLength(a), versionPrefixLength(b)
+	if result := compareVersionParts(a[:prefixA], b[:prefixB]); result != 0 || (prefixA == len(a) && prefixB == len(b)) {
+		return result
+	}
+	return compareVersionParts(a, b)
+}
+
+// versionPrefixLength function returns the length of text without its suffix for compareVersions.
+// The suffix is the longest end of text, which consists of dots followed by letter or ~ and then letters, digits or ~.
+func versionPrefixLength(text string) int {
+	isSuffixStart := func(pos int) bool {
+		return pos+1 < len(text) && text[pos] == '.' && (isASCIILetter(text[pos+1]) || text[pos+1] == '~')
+	}
+	prefix := 0
+	for i := 0; i < len(text); {
+		if isSuffixStart(i) == false {
+			i++
+			prefix = i
+			continue
+		}
+		i += 2
+		for i < len(text) && (isASCIILetter(text[i]) || (text[i] >= '0' && text[i] <= '9') || text[i] == '~') {
+			i++
+		}
+	}
+	return prefix
+}
+
+// isASCIILetter function checks if c is letter from the English alphabet
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// compareVersionParts function compares texts like the version comparison of Debian.
+// The parts without digits are compared by characters and the numbers in the texts are compared by their value.
+func compareVersionParts(a string, b string) int {
+	isDigit := func(text string, pos int) bool {
+		return pos < len(text) && text[pos] >= '0' && text[pos] <= '9'
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && isDigit(a, i) == false) || (j < len(b) && isDigit(b, j) == false) {
+			orderA, orderB := versionOrder(a, i), versionOrder(b, j)
+			if orderA != orderB {
+				return compareNumbers(float64(orderA), float64(orderB))
+			}
+			i, j = i+1, j+1
+		}
+		for isDigit(a, i) && a[i] == '0' {
+			i++
+		}
+		for isDigit(b, j) && b[j] == '0' {
+			j++
+		}
+		firstDifference := 0
+		for isDigit(a, i) && isDigit(b, j) {
+			if firstDifference == 0 {
+				firstDifference = int(a[i]) - int(b[j])
+			}
+			i, j = i+1, j+1
+		}
+		if isDigit(a, i) {
+			return 1
+		}
+		if isDigit(b, j) {
+			return -1
+		}
+		if firstDifference != 0 {
+			return compareNumbers(float64(firstDifference), 0)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	path, err := ioutil.TempDir("", "sort-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"first": "b\nd\n", "second": "c\na"})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"b\na\nc\n", []string{}, "a\nb\nc\n", nil},
+		{"b\na\nc\n", []string{"-r"}, "c\nb\na\n", nil},
+		{"b\na", []string{}, "a\nb\n", nil},
+		{"", []string{}, "", nil},
+		{"", []string{"first", "second"}, "a\nb\nc\nd\n", nil},
+		{"e\n", []string{"first", "-", "second"}, "a\nb\nc\nd\ne\n", nil},
+		{"10\n9\n-1\n2.5\nx\n", []string{"-n"}, "-1\nx\n2.5\n9\n10\n", nil},
+		{"10\n9\n-1\n2.5\nx\n", []string{}, "-1\n10\n2.5\n9\nx\n", nil},
+		{"1G\n2K\n3M\n10\n-1K\n", []string{"-h"}, "-1K\n10\n2K\n3M\n1G\n", nil},
+		{"v1.10\nv1.2\nv1.9\nv1.2~rc1\n", []string{"-V"}, "v1.2~rc1\nv1.2\nv1.9\nv1.10\n", nil},
+		{"file-1.0-rc1.tar\nfile-1.0.tar\nfile-1.0.tar.gz\nfile-1.0.1.tar\n", []string{"-V"}, "file-1.0.tar\nfile-1.0.tar.gz\nfile-1.0-rc1.tar\nfile-1.0.1.tar\n", nil},
+		{"a\n..\n.b\n.\n", []string{"-V"}, ".\n..\n.b\na\n", nil},
+		{"b\nA\na\nB\n", []string{"-f"}, "A\na\nB\nb\n", nil},
+		{"b\na\nb\n", []string{"-u"}, "a\nb\n", nil},
+		{"a\nA\nb\n", []string{"-f", "-u"}, "a\nb\n", nil},
+		{"a,3\nb,1\nc,2\n", []string{"-t", ",", "-k2,2n"}, "b,1\nc,2\na,3\n", nil},
+		{"a,3\nb,1\nc,2\n", []string{"-t,", "-k", "2", "-r"}, "a,3\nc,2\nb,1\n", nil},
+		{"x  b\ny a\n", []string{"-k2"}, "x  b\ny a\n", nil},
+		{"x  b\ny a\n", []string{"-k2b"}, "y a\nx  b\n", nil},
+		{"x 2\ny 1\nz 2\n", []string{"-k2,2", "-k1,1r"}, "y 1\nz 2\nx 2\n", nil},
+		{"x 2\ny 1\nz 2\n", []string{"-k2,2", "-u"}, "y 1\nx 2\n", nil},
+		{"ab3\nac1\nad2\n", []string{"-k1.3"}, "ac1\nad2\nab3\n", nil},
+		{"ж3\nя1\nю2\n", []string{"-k1.2"}, "я1\nю2\nж3\n", nil},
+		{"ab\naa\n", []string{"-k1.1,1.1"}, "aa\nab\n", nil},
+		{"a:1\nb:2\n", []string{"-t", "::", "-k2"}, "", ErrSortInvalidSeparator},
+		{"", []string{"-k", "0"}, "", ErrSortInvalidKey},
+		{"", []string{"-k", "1x"}, "", ErrSortInvalidKey},
+		{"", []string{"-S", "0"}, "", ErrSortInvalidSize},
+		{"", []string{"missing"}, "", os.ErrNotExist},
+		{"", []string{"-x"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Sort test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Sort{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+func TestSortExternal(t *testing.T) {
+	path, err := ioutil.TempDir("", "sort-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	var numbers, expected, expectedUnique []string
+	for i := 0; i < 1000; i++ {
+		numbers = append(numbers, fmt.Sprint(i/2))
+		expected = append(expected, fmt.Sprint(i/2))
+		if i%2 == 0 {
+			expectedUnique = append(expectedUnique, fmt.Sprint(i/2))
+		}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(numbers), func(i, j int) { numbers[i], numbers[j] = numbers[j], numbers[i] })
+	input := strings.Join(numbers, "\n") + "\n"
+	temporary, _ := ioutil.ReadDir(os.TempDir())
+
+	var tests = []struct {
+		words  []string
+		output string
+	}{
+		{[]string{"-n", "-S", "1K"}, strings.Join(expected, "\n") + "\n"},
+		{[]string{"-n", "-u", "-S", "1K"}, strings.Join(expectedUnique, "\n") + "\n"},
+		{[]string{"-n", "-u", "-S", "1K", "-r"}, strings.Join(reverseStrings(expectedUnique), "\n") + "\n"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Sort test with words %v", test.words), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Sort{}, path, test.words, input)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+			if output != test.output {
+				t.Errorf("Expected sorted output, but got: %q", output)
+			}
+		})
+	}
+	if files, _ := ioutil.ReadDir(os.TempDir()); len(files) != len(temporary) {
+		t.Errorf("Expected the temporary files to be removed, but there are %d new files", len(files)-len(temporary))
+	}
+}
+
+func TestSortExternalManyChunks(t *testing.T) {
+	path, err := ioutil.TempDir("", "sort-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	// every line is longer than the buffer, so every line is in its own chunk and the chunks are merged in groups
+	var lines []string
+	for i := 0; i < 2*sortMergeWays+8; i++ {
+		lines = append(lines, fmt.Sprintf("%d %d", i%5, i))
+	}
+	input := strings.Join(lines, "\n") + "\n"
+	temporary, _ := ioutil.ReadDir(os.TempDir())
+
+	output, err := runTestCommandWithInput(t, &Sort{}, path, []string{"-n", "-k2", "-r", "-S", "1B"}, input)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if expected := strings.Join(reverseStrings(lines), "\n") + "\n"; output != expected {
+		t.Errorf("Expected output %q, but got: %q", expected, output)
+	}
+
+	output, err = runTestCommandWithInput(t, &Sort{}, path, []string{"-u", "-k1,1", "-S", "1B"}, input)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if expected := "0 0\n1 1\n2 2\n3 3\n4 4\n"; output != expected {
+		t.Errorf("Expected the first of the equal lines %q, but got: %q", expected, output)
+	}
+	if files, _ := ioutil.ReadDir(os.TempDir()); len(files) != len(temporary) {
+		t.Errorf("Expected the temporary files to be removed, but there are %d new files", len(files)-len(temporary))
+	}
+}
+
+// reverseStrings function returns the strings in reversed order
+func reverseStrings(strs []string) []string {
+	result := make([]string, len(strs))
+	for ind, str := range strs {
+		result[len(strs)-1-ind] = str
+	}
+	return result
+}
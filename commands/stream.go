@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// streamBufferSize is the size of the buffers of streamReader and streamWriter
+const streamBufferSize = 64 << 10
+
+// streamReader is buffered reader of input file, implementing io.Reader.
+// Like checkRead, it checks for stop signal before every read and returns ErrStoppedExec if there is one.
+type streamReader struct {
+	command ExecuteCommand
+	reader  *bufio.Reader
+}
+
+// newStreamReader function is constructor for streamReader of inputFile for the command e
+func newStreamReader(e ExecuteCommand, inputFile *os.File) *streamReader {
+	return &streamReader{e, bufio.NewReaderSize(inputFile, streamBufferSize)}
+}
+
+// Read is a method for reading at most len(p) bytes, the bytes in the buffer are read before the file is read again
+func (r *streamReader) Read(p []byte) (int, error) {
+	if r.command.IsStopSignalReceived() == true {
+		return 0, ErrStoppedExec
+	}
+	return r.reader.Read(p)
+}
+
+// ReadString is a method for reading until the first delim, which is included in the result, or until the end of the file
+func (r *streamReader) ReadString(delim byte) (string, error) {
+	if r.command.IsStopSignalReceived() == true {
+		return "", ErrStoppedExec
+	}
+	return r.reader.ReadString(delim)
+}
+
+// Buffered is a method returning the number of bytes that can be read without reading the file.
+// When it is 0, the next read can block, so the commands flush their output before it.
+func (r *streamReader) Buffered() int {
+	return r.reader.Buffered()
+}
+
+// streamWriter is buffered writer of output file, implementing io.Writer.
+// Like checkWrite, it checks for stop signal before every write and returns ErrStoppedExec if there is one.
+//
+// The buffer is written to the file when it is full and when flush is called, which commands do before returning, see finish.
+// When the output file is a terminal, the buffer is also written at the end of every line, so the user sees the lines as they are made.
+type streamWriter struct {
+	command   ExecuteCommand
+	writer    *bufio.Writer
+	lineFlush bool // lineFlush is true when the buffer is written at the end of every line
+	stopped   bool // stopped is true after stop signal is received, because the signal is consumed when it is checked
+}
+
+// newStreamWriter function is constructor for streamWriter of outputFile for the command e
+func newStreamWriter(e ExecuteCommand, outputFile *os.File) *streamWriter {
+	return &streamWriter{e, bufio.NewWriterSize(outputFile, streamBufferSize), isTerminal(outputFile), false}
+}
+
+// isStopped is a method for checking if stop signal was received now or before
+func (w *streamWriter) isStopped() bool {
+	if w.stopped == false && w.command.IsStopSignalReceived() == true {
+		w.stopped = true
+	}
+	return w.stopped
+}
+
+// Write is a method for writing the bytes in p to the buffer
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if w.isStopped() == true {
+		return 0, ErrStoppedExec
+	}
+	n, err := w.writer.Write(p)
+	if err == nil && w.lineFlush == true && bytes.IndexByte(p, '\n') >= 0 {
+		err = w.writer.Flush()
+	}
+	return n, err
+}
+
+// WriteString is a method for writing text to the buffer, it is the buffered version of checkWrite
+func (w *streamWriter) WriteString(text string) error {
+	if w.isStopped() == true {
+		return ErrStoppedExec
+	}
+	_, err := w.writer.WriteString(text)
+	if err == nil && w.lineFlush == true && strings.IndexByte(text, '\n') >= 0 {
+		err = w.writer.Flush()
+	}
+	return err
+}
+
+// flush is a method for writing the buffer to the output file
+func (w *streamWriter) flush() error {
+	if w.isStopped() == true {
+		return ErrStoppedExec
+	}
+	return w.writer.Flush()
+}
+
+// flushIfWaiting is a method for flushing the buffer when in has no buffered bytes, because the next read from in can wait for more input
+func (w *streamWriter) flushIfWaiting(in *streamReader) error {
+	if in.Buffered() == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// finish is a method for flushing the buffer at the end of the command, which returns err.
+// The result is err or the error from flushing if err is nil.
+// When the command was stopped, the buffer is not written.
+func (w *streamWriter) finish(err error) error {
+	if err == ErrStoppedExec {
+		return err
+	}
+	if errFlush := w.flush(); err == nil {
+		return errFlush
+	}
+	return err
+}
+
+// copyStream function writes everything from in to out until the end of the input.
+// When the next read can wait for more input, what is read until now is written, so the output of interactive input is not delayed.
+func copyStream(in *streamReader, out *streamWriter) error {
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if _, err := out.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err := out.flushIfWaiting(in); err != nil {
+			return err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// processLines function calls process for every line from in, without its new line, until the end of the input.
+// Like in copyStream, out is flushed when the next read can wait for more input, out is nil for commands which write after the end of the input.
+func processLines(in *streamReader, out *streamWriter, process func(line string) error) error {
+	for {
+		line, err := in.ReadString('\n')
+		if line != "" {
+			if err := process(strings.TrimSuffix(line, "\n")); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if out == nil {
+			continue
+		}
+		if err := out.flushIfWaiting(in); err != nil {
+			return err
+		}
+	}
+}
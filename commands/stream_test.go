@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStreamReader(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	defer r.Close()
+	w.WriteString("first\x00line\nsecond")
+	w.Close()
+
+	cat := &Cat{}
+	cat.InitStopSignalCatching()
+	in := newStreamReader(cat, r)
+	if line, err := in.ReadString('\n'); err != nil || line != "first\x00line\n" {
+		t.Errorf("Expected line %q with no error, but got: %q and %v", "first\x00line\n", line, err)
+	}
+	if in.Buffered() != len("second") {
+		t.Errorf("Expected %d buffered bytes, but got: %d", len("second"), in.Buffered())
+	}
+	cat.SendStopSignal()
+	if _, err := in.ReadString('\n'); err != ErrStoppedExec {
+		t.Errorf("Expected error %v, but got: %v", ErrStoppedExec, err)
+	}
+	if line, err := in.ReadString('\n'); line != "second" {
+		t.Errorf("Expected line %q with error EOF, but got: %q and %v", "second", line, err)
+	}
+}
+
+func TestStreamWriter(t *testing.T) {
+	path, err := ioutil.TempDir("", "stream-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	name := filepath.Join(path, "output")
+	file, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make file! - %v", err)
+	}
+	defer file.Close()
+	readOutput := func() string {
+		output, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Fatal error - cannot read file! - %v", err)
+		}
+		return string(output)
+	}
+
+	cat := &Cat{}
+	cat.InitStopSignalCatching()
+	out := newStreamWriter(cat, file)
+	if err := out.WriteString("first\n"); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if output := readOutput(); output != "" { // the output is a file, so the lines are not flushed
+		t.Errorf("Expected no output before flush, but got: %q", output)
+	}
+	if err := out.flush(); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if output := readOutput(); output != "first\n" {
+		t.Errorf("Expected output %q, but got: %q", "first\n", output)
+	}
+
+	big := strings.Repeat("x", streamBufferSize+1) // the text, which is bigger than the buffer, is written without flush
+	if _, err := out.Write([]byte(big)); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if output := readOutput(); len(output) <= len("first\n") {
+		t.Errorf("Expected output longer than %d bytes, but got: %d", len("first\n"), len(output))
+	}
+	if err := out.finish(nil); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if output := readOutput(); output != "first\n"+big {
+		t.Errorf("Expected output with %d bytes, but got: %d", len("first\n"+big), len(output))
+	}
+
+	out.WriteString("stopped\n")
+	cat.SendStopSignal()
+	if err := out.WriteString("last\n"); err != ErrStoppedExec {
+		t.Errorf("Expected error %v, but got: %v", ErrStoppedExec, err)
+	}
+	if err := out.finish(nil); err != ErrStoppedExec { // the stop signal is consumed, but the writer remembers it
+		t.Errorf("Expected error %v, but got: %v", ErrStoppedExec, err)
+	}
+	if output := readOutput(); output != "first\n"+big {
+		t.Errorf("Expected no output after stop, but got: %q", strings.TrimPrefix(output, "first\n"+big))
+	}
+}
@@ -0,0 +1,440 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+var (
+	// ErrTailInvalidValue indicates that the number of lines or bytes or the sleep interval of tail command is not valid
+	ErrTailInvalidValue = errors.New("invalid value")
+)
+
+// DefaultTailInterval is the default time between two checks of the followed files in tail -f and tail -F
+const DefaultTailInterval = time.Second
+
+// Tail is a structure for tail command, implementing ExecuteCommand interface
+type Tail struct {
+	path          string
+	stopExecution chan struct{}
+	out           *streamWriter
+	headers       bool      // headers is true when the output of every file starts with header with its name
+	headerWritten bool      // headerWritten is true after the first header is written
+	lastName      string    // lastName is the name of the file, which output was written last
+	notices       io.Writer // notices is where the notices about the followed files are written, it is os.Stderr when it is not set
+}
+
+// GetName is a getter for command name
+func (t *Tail) GetName() string {
+	return "tail"
+}
+
+// GetPath is a getter for path
+func (t *Tail) GetPath() string {
+	return t.path
+}
+
+// Clone is a method for cloning tail command
+func (t *Tail) Clone() ExecuteCommand {
+	clone := *t
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (t *Tail) InitStopSignalCatching() {
+	t.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (t *Tail) SendStopSignal() {
+	t.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (t *Tail) IsStopSignalReceived() bool {
+	select {
+	case <-t.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of tail command
+//
+// The last 10 lines of every file in the arguments are written, - is for the input and without arguments the input is read.
+// With -n N the last N lines are written and with -n +N the lines starting from the N-th.
+// With -c N and -c +N the same is done for bytes.
+// When there are more files, the output of every file starts with header with its name, -q removes the headers and -v always writes them.
+//
+// With -f the files are followed i.e. the data appended to them is written until the command is stopped.
+// With -F the files are followed by name, so when the file is rotated, the new file with the same name is followed.
+// The notices about the followed files, like that the file was truncated or replaced, are written to the standard error.
+// The changes of the files are watched with inotify on Linux, otherwise the files are checked every second or every -s seconds.
+func (t *Tail) Execute(cp CommandProperties) error {
+	t.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	short, long := "n:c:fFqvs:", []string{"lines=", "bytes=", "follow", "quiet", "silent", "verbose", "sleep-interval="}
+	options, arguments, err := parseOptions(cp, short, long)
+	if err != nil {
+		return err
+	}
+	count, err := parseCountOption(cp, short, long, ErrTailInvalidValue)
+	if err != nil {
+		return err
+	}
+	byName := options.has("F")
+	follow := byName || options.has("f") || options.has("follow")
+	interval := DefaultTailInterval
+	for _, name := range []string{"s", "sleep-interval"} {
+		if options.has(name) {
+			if interval, err = parseSeconds(options.value(name)); err != nil || interval <= 0 {
+				return fmt.Errorf("%s - %w", options.value(name), ErrTailInvalidValue)
+			}
+		}
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+	t.headers = showHeaders(cp, short, long, len(arguments))
+	t.headerWritten, t.lastName = false, ""
+
+	t.out = newStreamWriter(t, outputFile)
+	if t.notices == nil {
+		t.notices = os.Stderr
+	}
+	var errs []error              // in slice errs we collect all the errors
+	var followers []*tailFollower // followers are the files followed with -f or -F
+	closeFollowers := func() {
+		for _, f := range followers {
+			if f.file != inputFile {
+				f.close()
+			}
+		}
+	}
+	defer closeFollowers()
+	for _, argument := range arguments {
+		file, err := openInputFile(t.path, argument, inputFile)
+		if err != nil {
+			errs = append(errs, err)
+			if byName == true && argument != "-" { // with -F, the file is followed when it appears
+				followers = append(followers, &tailFollower{name: argument})
+			}
+			continue
+		}
+		if err = t.outputHeader(argument); err == nil {
+			err = t.outputTail(file, count)
+		}
+		if err == ErrStoppedExec {
+			if file != inputFile {
+				file.Close()
+			}
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+		}
+
+		info, errStat := file.Stat()
+		if follow == true && err == nil && errStat == nil && info.Mode().IsRegular() {
+			offset, _ := file.Seek(0, io.SeekCurrent)
+			followers = append(followers, &tailFollower{name: argument, file: file, info: info, offset: offset})
+		} else if file != inputFile {
+			file.Close()
+		}
+	}
+
+	if follow == true && len(followers) > 0 {
+		err := t.follow(followers, byName, interval)
+		if err == ErrStoppedExec {
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return t.out.finish(joinErrors(errs))
+}
+
+// outputHeader is a method for writing the header for file with name, when the last output is for other file
+func (t *Tail) outputHeader(name string) error {
+	if t.headers == false || (t.headerWritten == true && t.lastName == name) {
+		return nil
+	}
+	err := t.out.WriteString(fileHeader(name, t.headerWritten == false))
+	t.headerWritten, t.lastName = true, name
+	return err
+}
+
+// outputTail is a method for writing the end of file, as it is given by count.
+// The file is read until its end, so it can be followed after that.
+func (t *Tail) outputTail(file *os.File, count countOption) error {
+	info, err := file.Stat()
+	if err == nil && info.Mode().IsRegular() && count.sign != '+' { // the end of regular file is found without reading all of it
+		start, err := t.tailStart(file, info.Size(), count)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		return copyStream(newStreamReader(t, file), t.out)
+	}
+
+	in := newStreamReader(t, file)
+	if count.sign == '+' { // the lines or bytes before the N-th are skipped
+		if count.bytes == true && count.count > 1 {
+			if _, err := io.CopyN(ioutil.Discard, in, count.count-1); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		for skipped := int64(1); count.bytes == false && skipped < count.count; skipped++ {
+			if _, err := in.ReadString('\n'); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		return copyStream(in, t.out)
+	}
+
+	if count.bytes == true {
+		var last []byte // last are the last count bytes read until now
+		buf := make([]byte, streamBufferSize)
+		for {
+			n, err := in.Read(buf)
+			if last = append(last, buf[:n]...); int64(len(last)) > count.count {
+				last = append(last[:0], last[int64(len(last))-count.count:]...)
+			}
+			if err == io.EOF {
+				_, err := t.out.Write(last)
+				return err
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	var lines []string // lines are the last count lines read until now
+	for {
+		line, err := in.ReadString('\n')
+		if len(line) > 0 {
+			if lines = append(lines, line); int64(len(lines)) > count.count {
+				lines = lines[1:]
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, line := range lines {
+		if err := t.out.WriteString(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tailStart is a method for finding the position in regular file with size, from which its end given by count starts.
+// The lines are counted by reading the file backwards, the new line at the end of the file does not start new line.
+func (t *Tail) tailStart(file *os.File, size int64, count countOption) (int64, error) {
+	if count.bytes == true {
+		if count.count > size {
+			return 0, nil
+		}
+		return size - count.count, nil
+	}
+	if count.count == 0 {
+		return size, nil
+	}
+
+	lines := count.count
+	buf := make([]byte, streamBufferSize)
+	for end := size; end > 0; {
+		if t.IsStopSignalReceived() == true {
+			return 0, ErrStoppedExec
+		}
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		chunk := buf[:end-start]
+		if _, err := file.ReadAt(chunk, start); err != nil && err != io.EOF {
+			return 0, err
+		}
+		for i := len(chunk) - 1; i >= 0; i-- {
+			if chunk[i] != '\n' || start+int64(i) == size-1 {
+				continue
+			}
+			if lines--; lines == 0 {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// tailFollower stores the state of file followed by tail -f or tail -F
+type tailFollower struct {
+	name   string      // name is the argument for the file
+	file   *os.File    // file is nil when the file with name can not be opened
+	info   os.FileInfo // info is for the opened file, it is used for finding if the file with name is replaced
+	offset int64       // offset is the position in file, to which it is written
+}
+
+// close is a method for closing the file of the follower
+func (f *tailFollower) close() {
+	if f.file != nil {
+		f.file.Close()
+		f.file, f.info = nil, nil
+	}
+}
+
+// tailWatcher watches files for changes, so that tail -f can write the new data in them without waiting
+type tailWatcher interface {
+	add(name string) error    // add is for watching the file with name and the directory with it
+	changes() <-chan struct{} // changes returns channel, which receives when some of the watched files may have changed
+	close() error
+}
+
+// follow is a method for writing the data appended to the files of followers, until the command is stopped.
+// If byName is true, the files are checked for being rotated i.e. replaced with new file with the same name.
+func (t *Tail) follow(followers []*tailFollower, byName bool, interval time.Duration) error {
+	var changes <-chan struct{} // changes is nil when the files can not be watched, then they are checked every interval
+	watcher := newTailWatcher()
+	if watcher != nil {
+		defer watcher.close()
+		changes = watcher.changes()
+		for _, f := range followers {
+			if f.name != "-" {
+				watcher.add(FullFileName(t.path, f.name))
+			}
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	buf := make([]byte, streamBufferSize)
+
+	for {
+		if err := t.out.flush(); err != nil {
+			return err
+		}
+		select {
+		case <-t.stopExecution:
+			return ErrStoppedExec
+		case <-changes:
+		case <-ticker.C:
+		}
+
+		for _, f := range followers {
+			if err := t.outputAppended(f, buf); err != nil {
+				return err
+			}
+			if byName == true && f.name != "-" {
+				if err := t.checkRotation(f, watcher, buf); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// outputAppended is a method for writing the data appended to the file of f since the last check.
+// When the file is truncated, it is written from its start.
+func (t *Tail) outputAppended(f *tailFollower, buf []byte) error {
+	if f.file == nil {
+		return nil
+	}
+	if info, err := f.file.Stat(); err == nil && info.Size() < f.offset {
+		if err := t.outputNotice(f.name, "file truncated"); err != nil {
+			return err
+		}
+		if f.offset, err = f.file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	for {
+		if t.IsStopSignalReceived() == true {
+			return ErrStoppedExec
+		}
+		n, err := f.file.Read(buf)
+		if n > 0 {
+			if err := t.outputHeader(f.name); err != nil {
+				return err
+			}
+			if _, err := t.out.Write(buf[:n]); err != nil {
+				return err
+			}
+			f.offset += int64(n)
+		}
+		if err == io.EOF || n == 0 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// checkRotation is a method for checking if the file with the name of f is not the followed file anymore.
+// Then the new file is followed from its start, if it is not accessible, it is checked again later.
+func (t *Tail) checkRotation(f *tailFollower, watcher tailWatcher, buf []byte) error {
+	name := FullFileName(t.path, f.name)
+	info, err := os.Stat(name)
+	if err != nil {
+		if f.file != nil {
+			f.close()
+			return t.outputNotice(f.name, "has become inaccessible")
+		}
+		return nil
+	}
+	if f.file != nil && os.SameFile(info, f.info) {
+		return nil
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil
+	}
+	if info, err = file.Stat(); err != nil {
+		file.Close()
+		return nil
+	}
+	notice := "has appeared; following new file"
+	if f.file != nil {
+		notice = "has been replaced; following new file"
+	}
+	f.close()
+	f.file, f.info, f.offset = file, info, 0
+	if watcher != nil {
+		watcher.add(name)
+	}
+	if err := t.outputNotice(f.name, notice); err != nil {
+		return err
+	}
+	return t.outputAppended(f, buf)
+}
+
+// outputNotice is a method for writing notice about the followed file with name, like that it was truncated.
+// Like in tail, the notices are not written to the output with the data of the files, so they are not mixed with it in pipe.
+// The output is written first, so that the notice is after the data before it in the terminal.
+func (t *Tail) outputNotice(name string, notice string) error {
+	if err := t.out.flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(t.notices, "tail: "+inputFileName(name)+": "+notice+"\n")
+	return err
+}
@@ -0,0 +1,64 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// inotifyWatcher is tailWatcher, which uses inotify for watching the files
+type inotifyWatcher struct {
+	fd     int      // fd is the inotify file descriptor, it is used for adding watches
+	file   *os.File // file is for reading the events from fd, closing it stops the reading
+	events chan struct{}
+}
+
+// newTailWatcher function returns watcher of the changes of files or nil if they can not be watched, then they are checked periodically
+func newTailWatcher() tailWatcher {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil
+	}
+	// the file descriptor is non-blocking, so the file is read with the runtime poller and closing it stops the reading
+	w := &inotifyWatcher{fd, os.NewFile(uintptr(fd), "inotify"), make(chan struct{}, 1)}
+	go w.read()
+	return w
+}
+
+// read is a method for reading the events, every event is only a notification that the files should be checked
+func (w *inotifyWatcher) read() {
+	buf := make([]byte, 4096)
+	for {
+		if _, err := w.file.Read(buf); err != nil {
+			return
+		}
+		select {
+		case w.events <- struct{}{}:
+		default: // there is already notification, which is not received
+		}
+	}
+}
+
+// add is a method for watching the file with name for changes and the directory with it for new files, like the rotated file
+func (w *inotifyWatcher) add(name string) error {
+	const fileEvents = syscall.IN_MODIFY | syscall.IN_ATTRIB | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+	const dirEvents = syscall.IN_CREATE | syscall.IN_MOVED_TO
+	if _, err := syscall.InotifyAddWatch(w.fd, filepath.Dir(name), dirEvents); err != nil {
+		return err
+	}
+	_, err := syscall.InotifyAddWatch(w.fd, name, fileEvents)
+	return err
+}
+
+// changes is a method returning the channel, which receives when some of the watched files may have changed
+func (w *inotifyWatcher) changes() <-chan struct{} {
+	return w.events
+}
+
+// close is a method for closing the inotify file descriptor, which removes all watches
+func (w *inotifyWatcher) close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+// newTailWatcher function returns watcher of the changes of files or nil if they can not be watched, then they are checked periodically
+func newTailWatcher() tailWatcher {
+	return nil
+}
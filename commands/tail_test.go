@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTail(t *testing.T) {
+	path, err := ioutil.TempDir("", "tail-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	var numbers []string
+	for i := 1; i <= 12; i++ {
+		numbers = append(numbers, fmt.Sprint(i))
+	}
+	long := strings.Repeat(strings.Repeat("x", 99)+"\n", 2000) + "end\n" // the file is bigger than the buffer for reading it backwards
+	makeTestTree(t, path, map[string]string{
+		"numbers": strings.Join(numbers, "\n") + "\n", "short": "a\nb", "empty": "", "long": long, "newlines": "\n\n\n",
+	})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"numbers"}, "3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n", nil},
+		{"", []string{"-n", "2", "numbers"}, "11\n12\n", nil},
+		{"", []string{"-n", "-2", "numbers"}, "11\n12\n", nil},
+		{"", []string{"-n", "+11", "numbers"}, "11\n12\n", nil},
+		{"", []string{"-n", "+0", "short"}, "a\nb", nil},
+		{"", []string{"-n", "+5", "short"}, "", nil},
+		{"", []string{"-n", "0", "numbers"}, "", nil},
+		{"", []string{"-n", "1", "short"}, "b", nil},
+		{"", []string{"-n", "20", "short"}, "a\nb", nil},
+		{"", []string{"-n", "2", "newlines"}, "\n\n", nil},
+		{"", []string{"-n", "2", "long"}, strings.Repeat("x", 99) + "\nend\n", nil},
+		{"", []string{"-n", "2001", "long"}, long, nil},
+		{"", []string{"-c", "4", "numbers"}, "\n12\n", nil},
+		{"", []string{"-c", "+25", "numbers"}, "12\n", nil},
+		{"", []string{"--bytes=100", "short"}, "a\nb", nil},
+		{"", []string{"-n", "1", "numbers", "short"}, "==> numbers <==\n12\n\n==> short <==\nb", nil},
+		{"", []string{"-n", "1", "-q", "numbers", "short"}, "12\nb", nil},
+		{"", []string{"-n", "1", "--verbose", "short"}, "==> short <==\nb", nil},
+		{"1\n2\n3\n", nil, "1\n2\n3\n", nil},
+		{"1\n2\n3\n", []string{"-n", "2"}, "2\n3\n", nil},
+		{"1\n2\n3\n", []string{"-n", "+2", "-"}, "2\n3\n", nil},
+		{"1\n2\n3\n", []string{"-c", "3"}, "\n3\n", nil},
+		{"1\n2\n3\n", []string{"-c", "+3"}, "2\n3\n", nil},
+		{"1\n2\n3\n", []string{"-f", "-n", "1"}, "3\n", nil}, // the input is not followed, because it is not regular file
+		{"", []string{"missing", "short"}, "==> short <==\na\nb", os.ErrNotExist},
+		{"", []string{"empty"}, "", nil},
+		{"", []string{"-n", "1x", "numbers"}, "", ErrTailInvalidValue},
+		{"", []string{"-f", "-s", "0", "numbers"}, "", ErrTailInvalidValue},
+		{"", []string{"-x", "numbers"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Tail test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Tail{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
+
+// tailFollowTest is a helper for running tail with words in path, until it is stopped, and reading its output
+type tailFollowTest struct {
+	t       *testing.T
+	tail    *Tail
+	mutex   sync.Mutex
+	output  string
+	notices string // notices are the notices about the followed files, which tail writes separately from the output
+	result  chan error
+}
+
+// startTailFollowTest function starts tail with words in path and reads its output in separate go routine
+func startTailFollowTest(t *testing.T, path string, words []string) *tailFollowTest {
+	outputR, outputW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	test := &tailFollowTest{t: t, result: make(chan error, 1)}
+	test.tail = &Tail{notices: test}
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := outputR.Read(buf)
+			test.mutex.Lock()
+			test.output += string(buf[:n])
+			test.mutex.Unlock()
+			if err != nil {
+				outputR.Close()
+				return
+			}
+		}
+	}()
+	test.tail.InitStopSignalCatching()
+	go func() {
+		test.result <- test.tail.Execute(CommandProperties{path, nil, nil, os.Stdin, outputW, words})
+		outputW.Close()
+	}()
+	return test
+}
+
+// Write is a method for collecting the notices of tail, implementing io.Writer interface
+func (test *tailFollowTest) Write(p []byte) (int, error) {
+	test.mutex.Lock()
+	defer test.mutex.Unlock()
+	test.notices += string(p)
+	return len(p), nil
+}
+
+// waitOutput is a method for waiting until the output and the notices of tail are expected
+func (test *tailFollowTest) waitOutput(expected string, expectedNotices string) {
+	test.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		test.mutex.Lock()
+		output, notices := test.output, test.notices
+		test.mutex.Unlock()
+		if output == expected && notices == expectedNotices {
+			return
+		}
+		if time.Now().After(deadline) {
+			test.t.Fatalf("Expected output %q and notices %q, but got: %q and %q", expected, expectedNotices, output, notices)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// stop is a method for stopping tail and checking that it stops promptly with expectedErr
+func (test *tailFollowTest) stop(expectedErr error) {
+	test.t.Helper()
+	test.tail.SendStopSignal()
+	select {
+	case err := <-test.result:
+		if !errors.Is(err, expectedErr) {
+			test.t.Errorf("Expected error %v, but got: %v", expectedErr, err)
+		}
+	case <-time.After(time.Second):
+		test.t.Fatalf("Expected tail to stop after stop signal")
+	}
+}
+
+func TestTailFollow(t *testing.T) {
+	path, err := ioutil.TempDir("", "tail-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	name := filepath.Join(path, "log")
+	makeTestTree(t, path, map[string]string{"log": "old\n"})
+	appendText := func(name string, text string) {
+		file, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			t.Fatalf("Fatal error - cannot open file! - %v", err)
+		}
+		defer file.Close()
+		if _, err := file.WriteString(text); err != nil {
+			t.Fatalf("Fatal error - cannot write to file! - %v", err)
+		}
+	}
+
+	test := startTailFollowTest(t, path, []string{"-f", "-s", "0.05", "log"})
+	test.waitOutput("old\n", "")
+	appendText(name, "new\n")
+	test.waitOutput("old\nnew\n", "")
+	if err := ioutil.WriteFile(name, []byte("cut\n"), 0666); err != nil {
+		t.Fatalf("Fatal error - cannot write file! - %v", err)
+	}
+	test.waitOutput("old\nnew\ncut\n", "tail: log: file truncated\n")
+	if err := os.Rename(name, name+".1"); err != nil { // with -f, the renamed file is still followed
+		t.Fatalf("Fatal error - cannot rename file! - %v", err)
+	}
+	appendText(name+".1", "renamed\n")
+	test.waitOutput("old\nnew\ncut\nrenamed\n", "tail: log: file truncated\n")
+	test.stop(nil)
+}
+
+func TestTailFollowName(t *testing.T) {
+	path, err := ioutil.TempDir("", "tail-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"log": "first\n", "other": ""})
+	// rotate function replaces file with name with new one with text, like log rotation does
+	rotate := func(name string, text string) {
+		if err := ioutil.WriteFile(filepath.Join(path, name+".new"), []byte(text), 0666); err != nil {
+			t.Fatalf("Fatal error - cannot write file! - %v", err)
+		}
+		if err := os.Rename(filepath.Join(path, name+".new"), filepath.Join(path, name)); err != nil {
+			t.Fatalf("Fatal error - cannot rename file! - %v", err)
+		}
+	}
+
+	test := startTailFollowTest(t, path, []string{"-F", "-s", "0.05", "log", "missing", "other"})
+	expected, notices := "==> log <==\nfirst\n\n==> other <==\n", ""
+	test.waitOutput(expected, notices)
+	rotate("log", "second\n")
+	expected += "\n==> log <==\nsecond\n"
+	notices += "tail: log: has been replaced; following new file\n"
+	test.waitOutput(expected, notices)
+	rotate("missing", "appeared\n")
+	expected += "\n==> missing <==\nappeared\n"
+	notices += "tail: missing: has appeared; following new file\n"
+	test.waitOutput(expected, notices)
+	if err := os.Remove(filepath.Join(path, "log")); err != nil {
+		t.Fatalf("Fatal error - cannot remove file! - %v", err)
+	}
+	notices += "tail: log: has become inaccessible\n"
+	test.waitOutput(expected, notices)
+	test.stop(os.ErrNotExist) // the error for the missing file is returned at the end
+}
+
+func ExampleTail_Execute() {
+	path, _ := os.Getwd()
+	tail := Tail{}
+	tail.Execute(CommandProperties{path, nil, nil, os.Stdin, os.Stdout, []string{"-n", "1", "tail.go"}})
+	// Output:
+	// }
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Tee is a structure for tee command, implementing ExecuteCommand and FileOpenerUser interfaces
+type Tee struct {
+	path          string
+	stopExecution chan struct{}
+	openFile      FileOpener
+}
+
+// GetName is a getter for command name
+func (t *Tee) GetName() string {
+	return "tee"
+}
+
+// GetPath is a getter for path
+func (t *Tee) GetPath() string {
+	return t.path
+}
+
+// Clone is a method for cloning tee command
+func (t *Tee) Clone() ExecuteCommand {
+	clone := *t
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (t *Tee) InitStopSignalCatching() {
+	t.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (t *Tee) SendStopSignal() {
+	t.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (t *Tee) IsStopSignalReceived() bool {
+	select {
+	case <-t.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetFileOpener is a method for setting the function, which opens the files
+func (t *Tee) SetFileOpener(open FileOpener) {
+	t.openFile = open
+}
+
+// Execute is go implementation of tee command
+//
+// The input is written to the output and to all files in the arguments, which are truncated or with -a the input is appended to them.
+// The files are opened with the helper of the interpreter, so the relative names are in its path.
+// When some file cannot be opened or written, the input is still written to the others.
+func (t *Tee) Execute(cp CommandProperties) error {
+	t.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "a", []string{"append"})
+	if err != nil {
+		return err
+	}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if options.has("a") || options.has("append") {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	open := t.openFile
+	if open == nil { // without interpreter, the files are opened relative to the path of the command
+		open = func(name string, flag int) (*os.File, error) {
+			return os.OpenFile(FullFileName(t.path, name), flag, 0666)
+		}
+	}
+
+	var errs []error // in slice errs we collect all the errors
+	writers, names := []*streamWriter{newStreamWriter(t, outputFile)}, []string{""}
+	for _, argument := range arguments {
+		file, err := open(argument, flag)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", argument, err))
+			continue
+		}
+		defer file.Close()
+		writers, names = append(writers, newStreamWriter(t, file)), append(names, argument)
+	}
+	// write writes to all writers with f, the writers with errors are removed
+	write := func(f func(w *streamWriter) error) error {
+		for ind := 0; ind < len(writers); {
+			err := f(writers[ind])
+			if err == ErrStoppedExec {
+				return err
+			}
+			if err != nil {
+				if names[ind] != "" {
+					err = fmt.Errorf("%s - %w", names[ind], err)
+				}
+				errs = append(errs, err)
+				writers, names = append(writers[:ind], writers[ind+1:]...), append(names[:ind], names[ind+1:]...)
+				continue
+			}
+			ind++
+		}
+		return nil
+	}
+
+	in := newStreamReader(t, inputFile)
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, errRead := in.Read(buf)
+		if errRead == ErrStoppedExec {
+			return errRead
+		}
+		err := write(func(w *streamWriter) error {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+			if errRead != nil {
+				return w.flush()
+			}
+			return w.flushIfWaiting(in)
+		})
+		if err != nil {
+			return err
+		}
+		if errRead == io.EOF {
+			return joinErrors(errs)
+		}
+		if errRead != nil {
+			return joinErrors(append(errs, errRead))
+		}
+	}
+}
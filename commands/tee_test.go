@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	var tests = []struct {
+		words  []string
+		input  string
+		output string
+		files  map[string]string
+		err    error
+	}{
+		{[]string{}, "one\ntwo\n", "one\ntwo\n", map[string]string{"old": "old\n"}, nil},
+		{[]string{"new"}, "one\ntwo", "one\ntwo", map[string]string{"new": "one\ntwo", "old": "old\n"}, nil},
+		{[]string{"old", "dir/new"}, "one\n", "one\n", map[string]string{"old": "one\n", "dir/new": "one\n"}, nil},
+		{[]string{"-a", "old", "new"}, "one\n", "one\n", map[string]string{"old": "old\none\n", "new": "one\n"}, nil},
+		{[]string{"--append", "old"}, "", "", map[string]string{"old": "old\n"}, nil},
+		{[]string{"old"}, "", "", map[string]string{"old": ""}, nil},
+		{[]string{"dir", "new"}, "one\n", "one\n", map[string]string{"new": "one\n"}, syscall.EISDIR},
+		{[]string{"missing/new", "old"}, "one\n", "one\n", map[string]string{"missing/new": "<missing>", "old": "one\n"}, os.ErrNotExist},
+		{[]string{"-x", "old"}, "one\n", "", map[string]string{"old": "old\n"}, ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Tee test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			path, err := ioutil.TempDir("", "tee-test")
+			if err != nil {
+				t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+			}
+			defer os.RemoveAll(path)
+			makeTestTree(t, path, map[string]string{"old": "old\n", "dir/": ""})
+
+			output, err := runTestCommandWithInput(t, &Tee{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+			for name, content := range test.files {
+				if result := readTestFile(filepath.Join(path, name)); result != content {
+					t.Errorf("Expected file %s with content %q, but got: %q", name, content, result)
+				}
+			}
+		})
+	}
+}
+
+func TestTeeFileOpener(t *testing.T) {
+	path, err := ioutil.TempDir("", "tee-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	var names []string
+	tee := &Tee{}
+	tee.SetFileOpener(func(name string, flag int) (*os.File, error) {
+		names = append(names, name)
+		return os.OpenFile(filepath.Join(path, "opened-"+name), flag, 0666)
+	})
+	output, err := runTestCommandWithInput(t, tee, "/nonexistent", []string{"a", "b"}, "text\n")
+	if err != nil || output != "text\n" {
+		t.Errorf("Expected output %q and no error, but got: %q and %v", "text\n", output, err)
+	}
+	if fmt.Sprint(names) != "[a b]" {
+		t.Errorf("Expected opened files [a b], but got: %v", names)
+	}
+	if result := readTestFile(filepath.Join(path, "opened-b")); result != "text\n" {
+		t.Errorf("Expected file opened-b with content %q, but got: %q", "text\n", result)
+	}
+}
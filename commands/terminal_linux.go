@@ -0,0 +1,33 @@
+//go:build linux
+// +build linux
+
+package commands
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the structure filled by TIOCGWINSZ ioctl
+type winsize struct {
+	rows, columns, xPixels, yPixels uint16
+}
+
+// isTerminal function is helper for checking if file is a terminal
+func isTerminal(file *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
+
+// terminalWidth function is helper for getting the number of columns of the terminal file.
+// When file is not a terminal, the width is taken from COLUMNS environment variable and if it is not set, 80 is returned.
+func terminalWidth(file *os.File) int {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws)))
+	if errno == 0 && ws.columns > 0 {
+		return int(ws.columns)
+	}
+	return defaultTerminalWidth()
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package commands
+
+import "os"
+
+// isTerminal function is helper for checking if file is a terminal
+func isTerminal(file *os.File) bool {
+	stat, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth function is helper for getting the number of columns of the terminal file.
+// Without ioctl the width is taken from COLUMNS environment variable and if it is not set, 80 is returned.
+func terminalWidth(file *os.File) int {
+	return defaultTerminalWidth()
+}
@@ -0,0 +1,369 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrTrMissingOperand indicates that tr command does not have the sets, which it needs
+	ErrTrMissingOperand = errors.New("missing operand")
+	// ErrTrExtraOperand indicates that tr command has more sets than it needs
+	ErrTrExtraOperand = errors.New("extra operand")
+	// ErrTrInvalidSet indicates that set of tr command is not valid, for example range is in reverse order or class is unknown
+	ErrTrInvalidSet = errors.New("invalid set")
+)
+
+// trClasses are the character classes, which can be in the sets of tr command as [:name:]
+var trClasses = map[string]func(r rune) bool{
+	"alnum":  func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) },
+	"alpha":  unicode.IsLetter,
+	"blank":  func(r rune) bool { return r == ' ' || r == '\t' },
+	"cntrl":  unicode.IsControl,
+	"digit":  func(r rune) bool { return r >= '0' && r <= '9' },
+	"graph":  func(r rune) bool { return unicode.IsGraphic(r) && unicode.IsSpace(r) == false },
+	"lower":  unicode.IsLower,
+	"print":  unicode.IsPrint,
+	"punct":  func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) },
+	"space":  unicode.IsSpace,
+	"upper":  unicode.IsUpper,
+	"xdigit": func(r rune) bool { return strings.ContainsRune("0123456789abcdefABCDEF", r) },
+}
+
+// Tr is a structure for tr command, implementing ExecuteCommand interface
+type Tr struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// trSet is a set of characters of tr command
+type trSet struct {
+	runes     []rune            // runes are the characters in their order, the characters of classes are the ones in ASCII
+	classes   []func(rune) bool // classes are the character classes in the set, which have characters outside ASCII
+	caseClass string            // caseClass is lower or upper when the set is only this class, so that the case of all letters can be changed
+	fill      int               // fill is the position of [c*], which is repeated until the set is as long as the first set, it is -1 without it
+}
+
+// GetName is a getter for command name
+func (t *Tr) GetName() string {
+	return "tr"
+}
+
+// GetPath is a getter for path
+func (t *Tr) GetPath() string {
+	return t.path
+}
+
+// Clone is a method for cloning tr command
+func (t *Tr) Clone() ExecuteCommand {
+	clone := *t
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (t *Tr) InitStopSignalCatching() {
+	t.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (t *Tr) SendStopSignal() {
+	t.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (t *Tr) IsStopSignalReceived() bool {
+	select {
+	case <-t.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of tr command
+//
+// The characters of the input in SET1 are changed to the characters at the same positions in SET2 and the result is written.
+// When SET2 is shorter, its last character is repeated. The sets have characters, ranges like a-z, escapes like \n and \NNN,
+// classes like [:alpha:] and [:upper:], and in SET2 [c*N] is c repeated N times and [c*] is c repeated until SET2 is as long as SET1.
+// The characters are in UTF-8 and the classes also have the characters outside ASCII, [:lower:] and [:upper:] change the case of all letters.
+//
+// The options are like in tr: -d deletes the characters in SET1, -s squeezes the repeated characters in the last set to one
+// and -c uses the characters, which are not in SET1.
+func (t *Tr) Execute(cp CommandProperties) error {
+	t.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "cCds", []string{"complement", "delete", "squeeze-repeats"})
+	if err != nil {
+		return err
+	}
+	complement := options.has("c") || options.has("C") || options.has("complement")
+	remove, squeeze := options.has("d") || options.has("delete"), options.has("s") || options.has("squeeze-repeats")
+	needed := 2
+	if (remove == true && squeeze == false) || (squeeze == true && remove == false && len(arguments) == 1) {
+		needed = 1
+	}
+	if len(arguments) < needed {
+		return ErrTrMissingOperand
+	}
+	if len(arguments) > needed {
+		return fmt.Errorf("%s - %w", arguments[needed], ErrTrExtraOperand)
+	}
+
+	set1, err := parseTrSet(arguments[0], false)
+	if err != nil {
+		return err
+	}
+	var set2 *trSet
+	if needed == 2 {
+		if set2, err = parseTrSet(arguments[1], true); err != nil {
+			return err
+		}
+	}
+	contains1 := func(r rune) bool {
+		return set1.contains(r) != complement
+	}
+	translate := func(r rune) rune { return r }
+	if remove == false && set2 != nil {
+		if translate, err = trTranslation(set1, set2, complement); err != nil {
+			return err
+		}
+	}
+	squeezed := contains1
+	if set2 != nil {
+		squeezed = set2.contains
+	}
+
+	in, out := newStreamReader(t, inputFile), newStreamWriter(t, outputFile)
+	var last rune = -1 // last is the last written character, for squeezing
+	var pending []byte // pending are the bytes at the end of the last read, which are not full character
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := in.Read(buf)
+		data := append(pending, buf[:n]...)
+		var result strings.Builder
+		for len(data) > 0 {
+			if utf8.FullRune(data) == false && err == nil {
+				break
+			}
+			r, size := utf8.DecodeRune(data)
+			if r == utf8.RuneError && size == 1 { // invalid UTF-8 is written unchanged
+				result.WriteByte(data[0])
+				data, last = data[1:], -1
+				continue
+			}
+			data = data[size:]
+			if remove == true && contains1(r) {
+				continue
+			}
+			r = translate(r)
+			if squeeze == true && r == last && squeezed(r) {
+				continue
+			}
+			result.WriteRune(r)
+			last = r
+		}
+		pending = append(pending[:0], data...)
+		if errWrite := out.WriteString(result.String()); errWrite != nil {
+			return out.finish(errWrite)
+		}
+		if errFlush := out.flushIfWaiting(in); errFlush != nil {
+			return out.finish(errFlush)
+		}
+		if err == io.EOF {
+			return out.finish(nil)
+		}
+		if err != nil {
+			return out.finish(err)
+		}
+	}
+}
+
+// contains is a method for checking if r is in the set
+func (s *trSet) contains(r rune) bool {
+	for _, class := range s.classes {
+		if class(r) {
+			return true
+		}
+	}
+	for _, c := range s.runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// trTranslation function returns the function for changing the characters of set1 to the characters of set2
+func trTranslation(set1 *trSet, set2 *trSet, complement bool) (func(r rune) rune, error) {
+	if len(set2.runes) == 0 {
+		return nil, fmt.Errorf("SET2 must be non-empty - %w", ErrTrInvalidSet)
+	}
+	lastRune := set2.runes[len(set2.runes)-1]
+	if complement == true { // the characters, which are not in set1, are changed to the last character of set2
+		return func(r rune) rune {
+			if set1.contains(r) {
+				return r
+			}
+			return lastRune
+		}, nil
+	}
+	switch {
+	case set1.caseClass == "lower" && set2.caseClass == "upper":
+		return unicode.ToUpper, nil
+	case set1.caseClass == "upper" && set2.caseClass == "lower":
+		return unicode.ToLower, nil
+	}
+
+	runes2 := set2.runes
+	if set2.fill != -1 && len(runes2) < len(set1.runes) {
+		missing := len(set1.runes) - len(runes2)
+		filled := append([]rune{}, runes2[:set2.fill+1]...)
+		for ind := 0; ind < missing; ind++ {
+			filled = append(filled, runes2[set2.fill])
+		}
+		runes2 = append(filled, runes2[set2.fill+1:]...)
+	}
+	translation := make(map[rune]rune, len(set1.runes))
+	for ind, r := range set1.runes {
+		if ind < len(runes2) {
+			translation[r] = runes2[ind]
+		} else {
+			translation[r] = lastRune
+		}
+	}
+	return func(r rune) rune {
+		if result, ok := translation[r]; ok == true {
+			return result
+		}
+		if set1.contains(r) { // characters of classes outside ASCII
+			return lastRune
+		}
+		return r
+	}, nil
+}
+
+// parseTrSet function parses set of tr command, second is true for SET2, which can have [c*N] and [c*]
+func parseTrSet(value string, second bool) (*trSet, error) {
+	set := &trSet{fill: -1}
+	errInvalid := fmt.Errorf("%s - %w", value, ErrTrInvalidSet)
+	text := []rune(value)
+	// next returns the character at pos with its escape and the position after it
+	next := func(pos int) (rune, int) {
+		if text[pos] != '\\' || pos+1 == len(text) {
+			return text[pos], pos + 1
+		}
+		pos++
+		if text[pos] >= '0' && text[pos] <= '7' {
+			end := pos
+			for end < len(text) && end < pos+3 && text[end] >= '0' && text[end] <= '7' {
+				end++
+			}
+			code, _ := strconv.ParseInt(string(text[pos:end]), 8, 32)
+			return rune(code), end
+		}
+		if ind := strings.IndexRune(`abfnrtv`, text[pos]); ind != -1 {
+			return rune("\a\b\f\n\r\t\v"[ind]), pos + 1
+		}
+		return text[pos], pos + 1
+	}
+
+	classes := 0
+	for pos := 0; pos < len(text); {
+		rest := string(text[pos:])
+		if strings.HasPrefix(rest, "[:") {
+			if end := strings.Index(rest, ":]"); end != -1 {
+				name := rest[2:end]
+				class, ok := trClasses[name]
+				if ok == false {
+					return nil, errInvalid
+				}
+				set.runes = append(set.runes, trClassRunes(name)...)
+				set.classes, set.caseClass = append(set.classes, class), name
+				classes++
+				pos += utf8.RuneCountInString(rest[:end+2])
+				continue
+			}
+		}
+		if strings.HasPrefix(rest, "[=") && len(text) > pos+4 && string(text[pos+3:pos+5]) == "=]" {
+			set.runes = append(set.runes, text[pos+2])
+			pos += 5
+			continue
+		}
+		if text[pos] == '[' && pos+1 < len(text) {
+			r, after := next(pos + 1)
+			if after < len(text) && text[after] == '*' {
+				end := after + 1
+				for end < len(text) && text[end] != ']' {
+					end++
+				}
+				if end < len(text) {
+					if second == false {
+						return nil, errInvalid
+					}
+					count := string(text[after+1 : end])
+					if count == "" || count == "0" {
+						set.fill = len(set.runes)
+						set.runes = append(set.runes, r)
+					} else {
+						base := 10
+						if count[0] == '0' {
+							base = 8
+						}
+						repeat, err := strconv.ParseInt(count, base, 32)
+						if err != nil {
+							return nil, errInvalid
+						}
+						for ind := int64(0); ind < repeat; ind++ {
+							set.runes = append(set.runes, r)
+						}
+					}
+					pos = end + 1
+					continue
+				}
+			}
+		}
+
+		r, after := next(pos)
+		if after+1 < len(text) && text[after] == '-' {
+			end, afterEnd := next(after + 1)
+			if end < r {
+				return nil, errInvalid
+			}
+			for c := r; c <= end; c++ {
+				set.runes = append(set.runes, c)
+			}
+			pos = afterEnd
+			continue
+		}
+		set.runes = append(set.runes, r)
+		pos = after
+	}
+	if classes != 1 || len(set.runes) != len(trClassRunes(set.caseClass)) {
+		set.caseClass = ""
+	}
+	if set.caseClass != "lower" && set.caseClass != "upper" {
+		set.caseClass = ""
+	}
+	return set, nil
+}
+
+// trClassRunes function returns the characters in ASCII of the class with name
+func trClassRunes(name string) []rune {
+	var result []rune
+	if class, ok := trClasses[name]; ok == true {
+		for r := rune(0); r < utf8.RuneSelf; r++ {
+			if class(r) {
+				result = append(result, r)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestTr(t *testing.T) {
+	path := makeFieldFixtures(t)
+	defer os.RemoveAll(path)
+	letters, utf8 := fieldFixtures["letters"], fieldFixtures["utf8"]
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{letters, []string{"a-z", "A-Z"}, "HELLO, WORLD!\nAAA  BBB\t\tCCC\n", nil},
+		{letters, []string{"[:lower:]", "[:upper:]"}, "HELLO, WORLD!\nAAA  BBB\t\tCCC\n", nil},
+		{letters, []string{"-d", "[:space:]"}, "Hello,World!aaabbbccc", nil},
+		{letters, []string{"-s", " \t"}, "Hello, World!\naaa bbb\tccc\n", nil},
+		{letters, []string{"-s", "a"}, "Hello, World!\na  bbb\t\tccc\n", nil},
+		{letters, []string{"-d", "l"}, "Heo, Word!\naaa  bbb\t\tccc\n", nil},
+		{letters, []string{"-c", "[:alpha:]\\n", "_"}, "Hello__World_\naaa__bbb__ccc\n", nil},
+		{letters, []string{"-cd", "[:alpha:]"}, "HelloWorldaaabbbccc", nil},
+		{letters, []string{"-ds", "l", "a"}, "Heo, Word!\na  bbb\t\tccc\n", nil},
+		{letters, []string{"a-c", "x"}, "Hello, World!\nxxx  xxx\t\txxx\n", nil},
+		{letters, []string{"abc", "x[y*]z"}, "Hello, World!\nxxx  yyy\t\tzzz\n", nil},
+		{letters, []string{"a-e", "[x*2]y"}, "Hyllo, Worly!\nxxx  xxx\t\tyyy\n", nil},
+		{letters, []string{"-s", "[:space:]", " "}, "Hello, World! aaa bbb ccc ", nil},
+		{letters, []string{"H\\t", "h\\n"}, "hello, World!\naaa  bbb\n\nccc\n", nil},
+		{letters, []string{"-d", "\\054"}, "Hello World!\naaa  bbb\t\tccc\n", nil},
+		{letters, []string{"l-o", "[.*]"}, "He..., W.r.d!\naaa  bbb\t\tccc\n", nil},
+		{utf8, []string{"[:lower:]", "[:upper:]"}, "ЪГЪЛ;ЯБЪЛКА;30\nЖЪЛТ;СИНЬО;7\n", nil},
+		{utf8, []string{"ъ", "ь"}, "ьгьл;ябьлка;30\nжьлт;синьо;7\n", nil},
+		{utf8, []string{"-d", "[:alpha:]"}, ";;30\n;;7\n", nil},
+		{utf8, []string{"а-я", "*"}, "****;******;30\n****;*****;7\n", nil},
+		{"ааа\xffбб\n", []string{"-s", "аб"}, "а\xffб\n", nil},
+		{"", []string{}, "", ErrTrMissingOperand},
+		{"", []string{"a"}, "", ErrTrMissingOperand},
+		{"", []string{"-d", "a", "b"}, "", ErrTrExtraOperand},
+		{"", []string{"a", "b", "c"}, "", ErrTrExtraOperand},
+		{"", []string{"z-a", "b"}, "", ErrTrInvalidSet},
+		{"", []string{"[:letter:]", "b"}, "", ErrTrInvalidSet},
+		{"", []string{"[a*]", "b"}, "", ErrTrInvalidSet},
+		{"", []string{"a", ""}, "", ErrTrInvalidSet},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Tr test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Tr{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrTrashNoArgs indicates that there is no subcommand or its arguments are missing
+	ErrTrashNoArgs = errors.New("Usage: trash list | trash restore [-f] NAME... | trash empty [--older-than AGE]")
+	// ErrTrashInvalidSubcommand indicates that the subcommand is not list, restore or empty
+	ErrTrashInvalidSubcommand = errors.New("invalid subcommand, valid subcommands are list, restore and empty")
+	// ErrTrashNotFound indicates that there is no file in the trash with the given name
+	ErrTrashNotFound = errors.New("is not in the trash")
+	// ErrTrashInvalidName indicates that the name given to trash restore is not name of file in the trash, because it is path
+	ErrTrashInvalidName = errors.New("is not valid name of trashed file")
+	// ErrTrashInvalidAge indicates that the value of --older-than option is not valid age like 30d, 12h or 2w
+	ErrTrashInvalidAge = errors.New("invalid age, examples of valid ages are 30d, 12h and 2w")
+	// ErrTrashInfo indicates that the .trashinfo file of trashed file cannot be parsed
+	ErrTrashInfo = errors.New("invalid trash information file")
+)
+
+// trashDateFormat is the format of DeletionDate in .trashinfo files
+const trashDateFormat = "2006-01-02T15:04:05"
+
+// Trash is a structure for trash command, implementing ExecuteCommand interface
+//
+// The trash is the one from FreeDesktop.org specification - the trashed files are in Trash/files and their information is in Trash/info.
+// It is used by rm command in trash mode.
+type Trash struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// GetName is a getter for command name
+func (t *Trash) GetName() string {
+	return "trash"
+}
+
+// GetPath is a getter for path
+func (t *Trash) GetPath() string {
+	return t.path
+}
+
+// Clone is a method for cloning trash command
+func (t *Trash) Clone() ExecuteCommand {
+	clone := *t
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (t *Trash) InitStopSignalCatching() {
+	t.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (t *Trash) SendStopSignal() {
+	t.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (t *Trash) IsStopSignalReceived() bool {
+	select {
+	case <-t.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of trash command
+//
+// trash list writes the deletion date, the name in the trash and the original path of every trashed file.
+// trash restore NAME moves the trashed file back to its original path, if there is a file with that path, the restored file gets a numbered name unless -f is given.
+// trash empty removes all files from the trash or only the ones trashed before the age given with --older-than.
+func (t *Trash) Execute(cp CommandProperties) error {
+	t.path = cp.Path
+	_, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "f", []string{"older-than="})
+	if err != nil {
+		return err
+	}
+	if len(arguments) == 0 {
+		return ErrTrashNoArgs
+	}
+	trash, err := trashDir()
+	if err != nil {
+		return err
+	}
+
+	switch arguments[0] {
+	case "list":
+		return t.list(trash, outputFile)
+	case "restore":
+		if len(arguments) == 1 {
+			return ErrTrashNoArgs
+		}
+		var errs []error
+		for _, name := range arguments[1:] {
+			if t.IsStopSignalReceived() == true {
+				break
+			}
+			if err := t.restore(trash, name, options.has("f"), outputFile); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return joinErrors(errs)
+	case "empty":
+		var age time.Duration
+		if options.has("older-than") {
+			if age, err = parseAge(options.value("older-than")); err != nil {
+				return err
+			}
+		}
+		return t.empty(trash, age)
+	}
+	return fmt.Errorf("%s - %w", arguments[0], ErrTrashInvalidSubcommand)
+}
+
+// trashedFile stores the information for one file in the trash
+type trashedFile struct {
+	name         string // name is the name of the file in Trash/files
+	path         string // path is the original path of the file
+	deletionDate time.Time
+}
+
+// list is a method for writing the information for all trashed files, sorted by deletion date
+func (t *Trash) list(trash string, outputFile *os.File) error {
+	var errs []error // in slice errs we collect all the errors
+	files, err := readTrashInfo(trash, &errs)
+	if err != nil {
+		return err
+	}
+	out := newStreamWriter(t, outputFile)
+	for _, file := range files {
+		line := file.deletionDate.Format("2006-01-02 15:04:05") + "  " + file.name + "  " + file.path + "\n"
+		if err := out.WriteString(line); err != nil {
+			return out.finish(joinErrors(append(errs, err)))
+		}
+	}
+	return out.finish(joinErrors(errs))
+}
+
+// restore is a method for moving the trashed file with name back to its original path.
+// If the original path is taken, the file is restored with numbered name, unless overwrite is true.
+func (t *Trash) restore(trash string, name string, overwrite bool, outputFile *os.File) error {
+	// the name is joined to the directories of the trash, so it cannot be path to other directory
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/"+string(os.PathSeparator)) {
+		return fmt.Errorf("%s - %w", name, ErrTrashInvalidName)
+	}
+	infoName := filepath.Join(trash, "info", name+".trashinfo")
+	file, err := readTrashInfoFile(infoName)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s - %w", name, ErrTrashNotFound)
+	} else if err != nil {
+		return err
+	}
+
+	target := file.path
+	if _, err := os.Lstat(target); err == nil {
+		if overwrite == true {
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+		} else {
+			if target, err = backupFileName(file.path, "numbered", ""); err != nil {
+				return err
+			}
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0777); err != nil {
+		return err
+	}
+	if err := moveFile(t, filepath.Join(trash, "files", name), target); err != nil {
+		return err
+	}
+	if err := os.Remove(infoName); err != nil {
+		return err
+	}
+	return checkWrite(t, outputFile, "restored '"+name+"' to '"+target+"'\n")
+}
+
+// empty is a method for removing the files from the trash, which were trashed before more than age, or all files if age is 0
+func (t *Trash) empty(trash string, age time.Duration) error {
+	var errs []error
+	files, err := readTrashInfo(trash, &errs)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if t.IsStopSignalReceived() == true {
+			return joinErrors(errs)
+		}
+		if age > 0 && time.Since(file.deletionDate) < age {
+			continue
+		}
+		// the file is removed before its information, so an interrupted removal can be continued
+		if err := os.RemoveAll(filepath.Join(trash, "files", file.name)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.Remove(filepath.Join(trash, "info", file.name+".trashinfo")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if age == 0 { // files without information are also removed
+		names, _ := ioutil.ReadDir(filepath.Join(trash, "files"))
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(trash, "info", name.Name()+".trashinfo")); os.IsNotExist(err) {
+				if err := os.RemoveAll(filepath.Join(trash, "files", name.Name())); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	return joinErrors(errs)
+}
+
+// trashDir function returns the path of the trash, which is $XDG_DATA_HOME/Trash or ~/.local/share/Trash
+func trashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "Trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
+
+// moveToTrash function moves file with fullName to the trash and returns its name in the trash.
+//
+// The name in the trash is the base name of the file, if it is taken, a number is added to it.
+// Like in the specification, the .trashinfo file is made first with O_EXCL, so two files cannot get the same name.
+func moveToTrash(e ExecuteCommand, fullName string) (string, error) {
+	trash, err := trashDir()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range []string{"files", "info"} {
+		if err := os.MkdirAll(filepath.Join(trash, dir), 0700); err != nil {
+			return "", err
+		}
+	}
+
+	fullName, err = filepath.Abs(fullName)
+	if err != nil {
+		return "", err
+	}
+	base := filepath.Base(fullName)
+	info := "[Trash Info]\nPath=" + (&url.URL{Path: fullName}).EscapedPath() + "\n" +
+		"DeletionDate=" + time.Now().Format(trashDateFormat) + "\n"
+	for number := 1; ; number++ {
+		name := base
+		if number > 1 {
+			name += "." + strconv.Itoa(number)
+		}
+		if _, err := os.Lstat(filepath.Join(trash, "files", name)); err == nil {
+			continue
+		}
+		infoName := filepath.Join(trash, "info", name+".trashinfo")
+		file, err := os.OpenFile(infoName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		_, err = file.WriteString(info)
+		if errClose := file.Close(); err == nil {
+			err = errClose
+		}
+		if err == nil {
+			err = moveFile(e, fullName, filepath.Join(trash, "files", name))
+		}
+		if err != nil {
+			os.Remove(infoName)
+			return "", err
+		}
+		return name, nil
+	}
+}
+
+// moveFile function is helper for moving source to target, which are possibly on different file systems
+func moveFile(e ExecuteCommand, source string, target string) error {
+	err := os.Rename(source, target)
+	if errors.Is(err, syscall.EXDEV) {
+		info, err := os.Lstat(source)
+		if err != nil {
+			return err
+		}
+		cr := copier{command: e, recursive: true, preserve: true}
+		if err := cr.copyEntry(source, target, info); err != nil {
+			return err
+		}
+		if len(cr.errs) > 0 {
+			return joinErrors(cr.errs)
+		}
+		return os.RemoveAll(source)
+	}
+	return err
+}
+
+// readTrashInfo function reads the information for all files in the trash and sorts them by deletion date and name.
+// The trash is shared with other programs, so the files with information, which cannot be read, are skipped and their errors are added to errs.
+func readTrashInfo(trash string, errs *[]error) ([]trashedFile, error) {
+	names, err := ioutil.ReadDir(filepath.Join(trash, "info"))
+	if os.IsNotExist(err) { // the trash is still not made
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var files []trashedFile
+	for _, name := range names {
+		if !strings.HasSuffix(name.Name(), ".trashinfo") {
+			continue
+		}
+		file, err := readTrashInfoFile(filepath.Join(trash, "info", name.Name()))
+		if err != nil {
+			*errs = append(*errs, err)
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].deletionDate.Equal(files[j].deletionDate) {
+			return files[i].name < files[j].name
+		}
+		return files[i].deletionDate.Before(files[j].deletionDate)
+	})
+	return files, nil
+}
+
+// readTrashInfoFile function parses .trashinfo file with infoName
+func readTrashInfoFile(infoName string) (trashedFile, error) {
+	file := trashedFile{name: strings.TrimSuffix(filepath.Base(infoName), ".trashinfo")}
+	info, err := os.Open(infoName)
+	if err != nil {
+		return file, err
+	}
+	defer info.Close()
+
+	scanner := bufio.NewScanner(info)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "Path="):
+			if file.path, err = url.PathUnescape(line[len("Path="):]); err != nil {
+				return file, fmt.Errorf("%s - %w", infoName, ErrTrashInfo)
+			}
+		case strings.HasPrefix(line, "DeletionDate="):
+			if file.deletionDate, err = time.ParseInLocation(trashDateFormat, line[len("DeletionDate="):], time.Local); err != nil {
+				return file, fmt.Errorf("%s - %w", infoName, ErrTrashInfo)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return file, err
+	}
+	if file.path == "" {
+		return file, fmt.Errorf("%s - %w", infoName, ErrTrashInfo)
+	}
+	return file, nil
+}
+
+// parseAge function parses age like 30d, 2w or any duration accepted by time.ParseDuration like 12h
+func parseAge(text string) (time.Duration, error) {
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if len(text) > 1 {
+		if unit, ok := units[text[len(text)-1]]; ok == true {
+			if number, err := strconv.Atoi(text[:len(text)-1]); err == nil && number > 0 {
+				return time.Duration(number) * unit, nil
+			}
+			return 0, fmt.Errorf("%s - %w", text, ErrTrashInvalidAge)
+		}
+	}
+	age, err := time.ParseDuration(text)
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("%s - %w", text, ErrTrashInvalidAge)
+	}
+	return age, nil
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runTestCommand is a helper function for executing command with words and returning its output
+func runTestCommand(t *testing.T, command ExecuteCommand, path string, words []string) (string, error) {
+	outputR, outputW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make pipe! - %v", err)
+	}
+	command.InitStopSignalCatching()
+	errCommand := command.Execute(CommandProperties{path, nil, nil, os.Stdin, outputW, words})
+	outputW.Close()
+	output, _ := ioutil.ReadAll(outputR)
+	return string(output), errCommand
+}
+
+func TestTrash(t *testing.T) {
+	path, err := ioutil.TempDir("", "trash-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	os.Setenv("XDG_DATA_HOME", filepath.Join(path, "data"))
+	defer os.Setenv("XDG_DATA_HOME", dataHome)
+	trash := filepath.Join(path, "data", "Trash")
+	makeTestTree(t, path, map[string]string{"work/a file": "first", "work/dir/b": "b"})
+
+	output, err := runTestCommand(t, &Rm{}, path, []string{"--trash", "-v", "work/a file"})
+	if err != nil {
+		t.Fatalf("Expected no error from rm --trash, but got: %v", err)
+	}
+	if expected := "trashed '" + filepath.Join(path, "work/a file") + "' as 'a file'\n"; output != expected {
+		t.Errorf("Expected output %q, but got: %q", expected, output)
+	}
+	if _, err := runTestCommand(t, &Rm{}, path, []string{"--trash", "work/dir"}); !errors.Is(err, ErrRmIsDir) {
+		t.Errorf("Expected error %v, but got: %v", ErrRmIsDir, err)
+	}
+	if _, err := runTestCommand(t, &Rm{UseTrash: true}, path, []string{"-r", "work/dir"}); err != nil {
+		t.Fatalf("Expected no error from rm in trash mode, but got: %v", err)
+	}
+	makeTestTree(t, path, map[string]string{"work/a file": "second"})
+	if _, err := runTestCommand(t, &Rm{}, path, []string{"--trash", "work/a file"}); err != nil {
+		t.Fatalf("Expected no error from rm --trash, but got: %v", err)
+	}
+
+	for name, content := range map[string]string{"files/a file": "first", "files/a file.2": "second", "files/dir/b": "b"} {
+		if result := readTestFile(filepath.Join(trash, name)); result != content {
+			t.Errorf("Expected trashed %s to have content %q, but got: %q", name, content, result)
+		}
+	}
+	info := readTestFile(filepath.Join(trash, "info", "a file.trashinfo"))
+	if !strings.HasPrefix(info, "[Trash Info]\nPath="+strings.Replace(filepath.Join(path, "work/a file"), " ", "%20", -1)+"\nDeletionDate=") {
+		t.Errorf("Unexpected trash information: %q", info)
+	}
+
+	output, err = runTestCommand(t, &Trash{}, path, []string{"list"})
+	if err != nil {
+		t.Fatalf("Expected no error from trash list, but got: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n"); len(lines) != 3 ||
+		!strings.HasSuffix(lines[2], "  dir  "+filepath.Join(path, "work/dir")) {
+		t.Errorf("Unexpected output from trash list: %q", output)
+	}
+
+	// the second file is restored, then the first gets numbered name, because its path is taken
+	for _, name := range []string{"a file.2", "a file", "dir"} {
+		if _, err := runTestCommand(t, &Trash{}, path, []string{"restore", name}); err != nil {
+			t.Errorf("Expected no error from trash restore %s, but got: %v", name, err)
+		}
+	}
+	for name, content := range map[string]string{"work/a file": "second", "work/a file.~1~": "first", "work/dir/b": "b"} {
+		if result := readTestFile(filepath.Join(path, name)); result != content {
+			t.Errorf("Expected restored %s to have content %q, but got: %q", name, content, result)
+		}
+	}
+	if _, err := runTestCommand(t, &Trash{}, path, []string{"restore", "dir"}); !errors.Is(err, ErrTrashNotFound) {
+		t.Errorf("Expected error %v, but got: %v", ErrTrashNotFound, err)
+	}
+
+	if _, err := runTestCommand(t, &Rm{}, path, []string{"--trash", "work/a file.~1~", "work/a file"}); err != nil {
+		t.Fatalf("Expected no error from rm --trash, but got: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	infoName := filepath.Join(trash, "info", "a file.~1~.trashinfo")
+	ioutil.WriteFile(infoName, []byte("[Trash Info]\nPath=/a\nDeletionDate="+old.Format(trashDateFormat)+"\n"), 0600)
+	if _, err := runTestCommand(t, &Trash{}, path, []string{"empty", "--older-than", "1d"}); err != nil {
+		t.Errorf("Expected no error from trash empty, but got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trash, "files", "a file.~1~")); !os.IsNotExist(err) {
+		t.Errorf("Expected old file to be removed from the trash, but got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trash, "files", "a file")); err != nil {
+		t.Errorf("Expected new file to stay in the trash, but got: %v", err)
+	}
+	if _, err := runTestCommand(t, &Trash{}, path, []string{"empty"}); err != nil {
+		t.Errorf("Expected no error from trash empty, but got: %v", err)
+	}
+	if files, _ := ioutil.ReadDir(filepath.Join(trash, "files")); len(files) != 0 {
+		t.Errorf("Expected empty trash, but got %d files", len(files))
+	}
+}
+
+func TestTrashRestoreInvalidName(t *testing.T) {
+	path, err := ioutil.TempDir("", "trash-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	os.Setenv("XDG_DATA_HOME", filepath.Join(path, "data"))
+	defer os.Setenv("XDG_DATA_HOME", dataHome)
+	// the file and its information are outside the trash, where ../../secret would find them
+	makeTestTree(t, path, map[string]string{
+		"data/Trash/files/": "", "data/Trash/info/": "", "data/secret": "secret",
+		"data/secret.trashinfo": "[Trash Info]\nPath=" + filepath.Join(path, "stolen") + "\nDeletionDate=2020-01-01T00:00:00\n",
+	})
+
+	for _, name := range []string{"../../secret", "a/b", ".", "..", ""} {
+		if _, err := runTestCommand(t, &Trash{}, path, []string{"restore", name}); !errors.Is(err, ErrTrashInvalidName) {
+			t.Errorf("Expected error %v for %q, but got: %v", ErrTrashInvalidName, name, err)
+		}
+	}
+	if result := readTestFile(filepath.Join(path, "data", "secret")); result != "secret" {
+		t.Errorf("Expected the file outside the trash to stay, but got: %q", result)
+	}
+	if result := readTestFile(filepath.Join(path, "stolen")); result != "<missing>" {
+		t.Errorf("Expected no restored file, but got: %q", result)
+	}
+}
+
+func TestTrashMalformedInfo(t *testing.T) {
+	path, err := ioutil.TempDir("", "trash-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	os.Setenv("XDG_DATA_HOME", filepath.Join(path, "data"))
+	defer os.Setenv("XDG_DATA_HOME", dataHome)
+	makeTestTree(t, path, map[string]string{
+		"data/Trash/files/good": "good", "data/Trash/files/bad": "bad",
+		"data/Trash/info/good.trashinfo": "[Trash Info]\nPath=" + filepath.Join(path, "good") + "\nDeletionDate=2020-01-01T00:00:00\n",
+		"data/Trash/info/bad.trashinfo":  "[Trash Info]\nDeletionDate=yesterday\n",
+	})
+
+	output, err := runTestCommand(t, &Trash{}, path, []string{"list"})
+	if !errors.Is(err, ErrTrashInfo) {
+		t.Errorf("Expected error %v for the malformed information, but got: %v", ErrTrashInfo, err)
+	}
+	if expected := "2020-01-01 00:00:00  good  " + filepath.Join(path, "good") + "\n"; output != expected {
+		t.Errorf("Expected the other files to be listed %q, but got: %q", expected, output)
+	}
+
+	if _, err := runTestCommand(t, &Trash{}, path, []string{"empty"}); !errors.Is(err, ErrTrashInfo) {
+		t.Errorf("Expected error %v for the malformed information, but got: %v", ErrTrashInfo, err)
+	}
+	for name, expected := range map[string]string{"files/good": "<missing>", "info/good.trashinfo": "<missing>", "files/bad": "bad"} {
+		if result := readTestFile(filepath.Join(path, "data", "Trash", name)); result != expected {
+			t.Errorf("Expected %s in the trash to be %q, but got: %q", name, expected, result)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	var tests = []struct {
+		text string
+		age  time.Duration
+		err  error
+	}{
+		{"30d", 30 * 24 * time.Hour, nil},
+		{"2w", 14 * 24 * time.Hour, nil},
+		{"12h", 12 * time.Hour, nil},
+		{"1h30m", 90 * time.Minute, nil},
+		{"d", 0, ErrTrashInvalidAge},
+		{"-3d", 0, ErrTrashInvalidAge},
+		{"ten", 0, ErrTrashInvalidAge},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parseAge(%s)", test.text), func(t *testing.T) {
+			age, err := parseAge(test.text)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if age != test.age {
+				t.Errorf("Expected age %v, but got: %v", test.age, age)
+			}
+		})
+	}
+}
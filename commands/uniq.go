@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrUniqTooManyArgs indicates that uniq command has more than 2 arguments
+	ErrUniqTooManyArgs = errors.New("Too many arguments")
+)
+
+// Uniq is a structure for uniq command, implementing ExecuteCommand interface
+type Uniq struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// GetName is a getter for command name
+func (u *Uniq) GetName() string {
+	return "uniq"
+}
+
+// GetPath is a getter for path
+func (u *Uniq) GetPath() string {
+	return u.path
+}
+
+// Clone is a method for cloning uniq command
+func (u *Uniq) Clone() ExecuteCommand {
+	clone := *u
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (u *Uniq) InitStopSignalCatching() {
+	u.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (u *Uniq) SendStopSignal() {
+	u.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (u *Uniq) IsStopSignalReceived() bool {
+	select {
+	case <-u.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of uniq command
+//
+// The adjacent equal lines of the input are written only once. The first argument is the input file, - is for the input,
+// and the second argument is the output file.
+// The options are like in uniq: -c writes the number of the lines before every line, -d writes only the repeated lines,
+// -u writes only the lines, which are not repeated, and -i ignores case when the lines are compared.
+func (u *Uniq) Execute(cp CommandProperties) error {
+	u.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "cdui", []string{"count", "repeated", "unique", "ignore-case"})
+	if err != nil {
+		return err
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+	if len(arguments) > 2 {
+		return ErrUniqTooManyArgs
+	}
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+	file, err := openInputFile(u.path, arguments[0], inputFile)
+	if err != nil {
+		return err
+	}
+	if file != inputFile {
+		defer file.Close()
+	}
+	if len(arguments) == 2 && arguments[1] != "-" {
+		if outputFile, err = os.Create(FullFileName(u.path, arguments[1])); err != nil {
+			return fmt.Errorf("%s - %w", arguments[1], err)
+		}
+		defer outputFile.Close()
+	}
+
+	count, repeated, unique, ignoreCase := has("c", "count"), has("d", "repeated"), has("u", "unique"), has("i", "ignore-case")
+	out := newStreamWriter(u, outputFile)
+	in := newStreamReader(u, file)
+	group, size := "", 0 // group is the first line of the current group of equal lines and size is the number of lines in it
+	writeGroup := func() error {
+		if size == 0 || (repeated == true && size == 1) || (unique == true && size > 1) {
+			return nil
+		}
+		if count == true {
+			return out.WriteString(fmt.Sprintf("%7d %s\n", size, group))
+		}
+		return out.WriteString(group + "\n")
+	}
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return out.finish(err)
+		}
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+			if size > 0 && (line == group || (ignoreCase == true && strings.EqualFold(line, group))) {
+				size++
+			} else {
+				if err := writeGroup(); err != nil {
+					return out.finish(err)
+				}
+				group, size = line, 1
+			}
+		}
+		if err == io.EOF {
+			return out.finish(writeGroup())
+		}
+		if err := out.flushIfWaiting(in); err != nil {
+			return out.finish(err)
+		}
+	}
+}
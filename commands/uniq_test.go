@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUniq(t *testing.T) {
+	path, err := ioutil.TempDir("", "uniq-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"lines": "x\nx\ny\n"})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"a\na\nb\na\n", []string{}, "a\nb\na\n", nil},
+		{"a\na\nb\na\n", []string{"-c"}, "      2 a\n      1 b\n      1 a\n", nil},
+		{"a\na\nb\na\n", []string{"-d"}, "a\n", nil},
+		{"a\na\nb\na\n", []string{"--unique"}, "b\na\n", nil},
+		{"a\na\nb\na\n", []string{"-d", "-u"}, "", nil},
+		{"a\nA\nb\n", []string{"-i"}, "a\nb\n", nil},
+		{"a\nA\nb\n", []string{"-ic"}, "      2 a\n      1 b\n", nil},
+		{"a\nA\nb\n", []string{}, "a\nA\nb\n", nil},
+		{"a\na", []string{}, "a\n", nil},
+		{"\n\nж\n", []string{"-c"}, "      2 \n      1 ж\n", nil},
+		{"", []string{}, "", nil},
+		{"", []string{"lines"}, "x\ny\n", nil},
+		{"", []string{"-c", "lines", "-"}, "      2 x\n      1 y\n", nil},
+		{"", []string{"missing"}, "", os.ErrNotExist},
+		{"", []string{"a", "b", "c"}, "", ErrUniqTooManyArgs},
+		{"", []string{"-x"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Uniq test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Uniq{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+
+	t.Run("Uniq test with output file", func(t *testing.T) {
+		output, err := runTestCommandWithInput(t, &Uniq{}, path, []string{"lines", "result"}, "")
+		if err != nil || output != "" {
+			t.Errorf("Expected no error and output, but got: %v and %q", err, output)
+		}
+		if result := readTestFile(filepath.Join(path, "result")); result != "x\ny\n" {
+			t.Errorf("Expected output file %q, but got: %q", "x\ny\n", result)
+		}
+	})
+}
@@ -0,0 +1,505 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrWalkLoop indicates that symbolic link, which is followed, points to directory above it
+	ErrWalkLoop = errors.New("file system loop detected")
+)
+
+// walker is a structure for walking directory trees, where the directories are read with os.ReadDir by fixed pool of goroutines.
+//
+// In sorted mode, the files are visited in deterministic order - depth-first with sorted names, like with filepath.Walk,
+// and the next directories are read in advance by the pool, at most prefetchPerWorker for every goroutine.
+// Otherwise the goroutines of the pool take the directories from queue and visit is called concurrently from them.
+//
+// Like in filepath.WalkDir, visit is called for every file with nil error and for every directory, which cannot be read, for the second time with the error.
+// If visit returns filepath.SkipDir for directory, the files in it are not visited. Any other error stops the walk.
+type walker struct {
+	command     ExecuteCommand // command is checked for stop signal
+	workers     int            // workers is the maximum number of directories read at the same time, by default it depends on the number of CPUs
+	sorted      bool
+	followLinks bool // followLinks is for walking the directories, to which symbolic links point
+	gitignore   bool // gitignore is for skipping the files ignored by .gitignore files and .git directories
+	maxDepth    int  // maxDepth limits the depth of the read directories, if it is positive
+
+	visit func(entry *walkEntry, err error) error
+	list  func(dir *walkEntry, entries []*walkEntry) error // list is called in sorted mode with the files in directory before they are visited
+	leave func(dir *walkEntry) error                       // leave is called in sorted mode after all files in directory are visited
+
+	queue      chan *walkListing // queue stores the directories, which should be read in advance in sorted mode
+	prefetched chan struct{}     // prefetched has element for every directory in queue or read in advance, which is not walked yet
+	done       chan struct{}     // done is closed when the walk ends, so the directories, which are read in advance, are not needed
+}
+
+// prefetchPerWorker is the maximum number of directories read in advance in sorted mode for every goroutine of the pool
+const prefetchPerWorker = 4
+
+// walkEntry stores the information for file in the walk
+type walkEntry struct {
+	name      string // name is the full name of the file
+	relative  string // relative is the name relative to the start of the walk, it is empty for the start
+	depth     int
+	isDir     bool // isDir is true for directories and for symbolic links to directories, when links are followed
+	err       error
+	dirEntry  os.DirEntry
+	info      os.FileInfo
+	ancestors []os.FileInfo // ancestors are the directories above the file, used for finding loops of symbolic links
+	ignore    *gitignore
+}
+
+// Info is a method returning the information for the file.
+// It is read only when it is needed, except for the start of the walk and for the symbolic links, which are followed.
+func (e *walkEntry) Info() (os.FileInfo, error) {
+	if e.info == nil {
+		info, err := e.dirEntry.Info()
+		if err != nil {
+			return nil, err
+		}
+		e.info = info
+	}
+	return e.info, nil
+}
+
+// walkListing is a structure for the result of reading directory in advance, which may be still in progress
+type walkListing struct {
+	dir     *walkEntry
+	ready   chan struct{}
+	entries []*walkEntry
+	err     error
+}
+
+// walk is a method for walking the tree with start root
+func (w *walker) walk(root string) error {
+	workers := w.workers
+	if workers <= 0 {
+		workers = 4 * runtime.NumCPU() // reading directories waits mostly for the file system
+	}
+	w.done = make(chan struct{})
+	defer close(w.done)
+
+	var info os.FileInfo
+	var err error
+	if w.followLinks == true {
+		info, err = os.Stat(root)
+	} else {
+		info, err = os.Lstat(root)
+	}
+	entry := &walkEntry{name: root, info: info}
+	if err != nil {
+		if err := w.visit(entry, err); err != filepath.SkipDir {
+			return err
+		}
+		return nil
+	}
+	entry.isDir = info.IsDir()
+	if w.gitignore == true {
+		entry.ignore = loadGitignores(root)
+	}
+
+	if w.sorted == true {
+		w.queue = make(chan *walkListing, prefetchPerWorker*workers)
+		w.prefetched = make(chan struct{}, prefetchPerWorker*workers)
+		for n := 0; n < workers; n++ {
+			go w.readInAdvance()
+		}
+		return w.walkSorted(entry, nil)
+	}
+	return w.walkConcurrent(entry, workers)
+}
+
+// walkSorted is a method for visiting entry and the files in it in sorted order.
+// The directory may already be read in advance in listing.
+func (w *walker) walkSorted(entry *walkEntry, listing *walkListing) error {
+	if w.command.IsStopSignalReceived() == true {
+		w.release(listing)
+		return ErrStoppedExec
+	}
+	err := w.visitEntry(entry)
+	if err != nil || w.descend(entry) == false {
+		w.release(listing)
+		if err == filepath.SkipDir {
+			return nil
+		}
+		return err
+	}
+
+	var entries []*walkEntry
+	if listing == nil {
+		entries, err = w.readDir(entry)
+	} else {
+		<-listing.ready
+		entries, err = listing.entries, listing.err
+		w.release(listing)
+	}
+	if err != nil {
+		if err := w.visit(entry, err); err != nil && err != filepath.SkipDir {
+			return err
+		}
+	}
+	if w.list != nil {
+		if err := w.list(entry, entries); err != nil {
+			return err
+		}
+	}
+
+	listings := make([]*walkListing, len(entries))
+	next := 0 // next is the first file in entry, after the directories which are read in advance
+	for i, child := range entries {
+		for ; next < len(entries); next++ { // the next directories are read in advance, while the limit allows it
+			if w.descend(entries[next]) == false {
+				continue
+			}
+			if listings[next] = w.prefetch(entries[next]); listings[next] == nil {
+				break
+			}
+		}
+		if next == i { // the directory, which is not read in advance, is read when it is walked
+			next++
+		}
+		if err := w.walkSorted(child, listings[i]); err != nil {
+			for _, listing := range listings[i+1:] {
+				w.release(listing)
+			}
+			return err
+		}
+	}
+	if w.leave != nil {
+		return w.leave(entry)
+	}
+	return nil
+}
+
+// prefetch is a method for adding dir to the queue of directories read in advance.
+// It returns nil if the limit of directories read in advance is reached.
+func (w *walker) prefetch(dir *walkEntry) *walkListing {
+	select {
+	case w.prefetched <- struct{}{}:
+	default:
+		return nil
+	}
+	listing := &walkListing{dir: dir, ready: make(chan struct{})}
+	select {
+	case w.queue <- listing:
+		return listing
+	default: // the queue can still have directories, which are released, because they are not needed
+		<-w.prefetched
+		return nil
+	}
+}
+
+// release is a method for freeing the place of listing in the directories read in advance, when it is walked or not needed
+func (w *walker) release(listing *walkListing) {
+	if listing != nil {
+		<-w.prefetched
+	}
+}
+
+// readInAdvance is a method for goroutine of the pool, which reads the directories from queue until the walk ends
+func (w *walker) readInAdvance() {
+	for {
+		select {
+		case listing := <-w.queue:
+			listing.entries, listing.err = w.readDir(listing.dir)
+			close(listing.ready)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// walkConcurrent is a method for visiting entry and the files in it by pool of workers goroutines.
+// The directories, which are not read yet, are kept in stack, so that like in depth-first walk it does not grow too much.
+func (w *walker) walkConcurrent(root *walkEntry, workers int) error {
+	if err := w.visitEntry(root); err != nil {
+		if err == filepath.SkipDir {
+			return nil
+		}
+		return err
+	}
+	if w.descend(root) == false {
+		return nil
+	}
+
+	var mutex sync.Mutex
+	cond := sync.NewCond(&mutex)
+	queue := []*walkEntry{root}
+	pending := 1     // pending is the number of directories in queue or being read
+	var result error // result is the first error, which stops the walk
+	stopped := func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return result != nil
+	}
+	work := func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for {
+			for len(queue) == 0 && pending > 0 && result == nil {
+				cond.Wait()
+			}
+			if pending == 0 || result != nil {
+				return
+			}
+			dir := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			mutex.Unlock()
+			dirs, err := w.walkDir(dir, stopped)
+			mutex.Lock()
+			if err != nil && result == nil {
+				result = err
+			}
+			queue = append(queue, dirs...)
+			pending += len(dirs) - 1
+			cond.Broadcast()
+		}
+	}
+
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// walkDir is a method for reading directory dir and visiting the files in it, it returns the directories in it, which should be walked.
+// It stops when stopped returns true, because of error in other directory.
+func (w *walker) walkDir(dir *walkEntry, stopped func() bool) ([]*walkEntry, error) {
+	entries, err := w.readDir(dir)
+	if err != nil {
+		if err := w.visit(dir, err); err != nil && err != filepath.SkipDir {
+			return nil, err
+		}
+		return nil, nil
+	}
+	var dirs []*walkEntry
+	for _, entry := range entries {
+		if stopped() == true {
+			return nil, nil
+		}
+		if w.command.IsStopSignalReceived() == true {
+			return nil, ErrStoppedExec
+		}
+		err := w.visitEntry(entry)
+		if err == filepath.SkipDir {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if w.descend(entry) == true {
+			dirs = append(dirs, entry)
+		}
+	}
+	return dirs, nil
+}
+
+// visitEntry is a method for visiting entry, giving the error for it, if there is such
+func (w *walker) visitEntry(entry *walkEntry) error {
+	if entry.err != nil {
+		return w.visit(entry, entry.err)
+	}
+	return w.visit(entry, nil)
+}
+
+// descend is a method for checking if the files in entry should be visited
+func (w *walker) descend(entry *walkEntry) bool {
+	return entry.isDir && entry.err == nil && (w.maxDepth <= 0 || entry.depth < w.maxDepth)
+}
+
+// readDir is a method for reading the files in directory dir, sorted by name
+func (w *walker) readDir(dir *walkEntry) ([]*walkEntry, error) {
+	dirEntries, err := os.ReadDir(dir.name)
+	if err != nil {
+		return nil, err
+	}
+
+	ignore := dir.ignore
+	var ancestors []os.FileInfo
+	if w.followLinks == true {
+		info, err := dir.Info()
+		if err != nil {
+			return nil, err
+		}
+		ancestors = append(dir.ancestors[:len(dir.ancestors):len(dir.ancestors)], info)
+	}
+	entries := make([]*walkEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if w.gitignore == true && dirEntry.Name() == ".gitignore" {
+			ignore = ignore.load(dir.name)
+		}
+	}
+	for _, dirEntry := range dirEntries {
+		entry := &walkEntry{
+			name: filepath.Join(dir.name, dirEntry.Name()), relative: filepath.Join(dir.relative, dirEntry.Name()),
+			depth: dir.depth + 1, isDir: dirEntry.IsDir(), dirEntry: dirEntry, ancestors: ancestors, ignore: ignore,
+		}
+		if w.gitignore == true && (dirEntry.Name() == ".git" || ignore.match(entry.name, entry.isDir)) {
+			continue
+		}
+		if w.followLinks == true && dirEntry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(entry.name); err == nil { // broken links are visited as links
+				entry.info, entry.isDir = info, info.IsDir()
+			}
+		}
+		if w.followLinks == true && entry.isDir {
+			info, err := entry.Info()
+			if err != nil {
+				entry.err = err
+			}
+			for _, ancestor := range ancestors {
+				if err == nil && os.SameFile(ancestor, info) {
+					entry.err = fmt.Errorf("%s - %w", entry.name, ErrWalkLoop)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// gitignore is a structure for the rules of .gitignore file, which are added to the rules of the parent directories
+type gitignore struct {
+	parent *gitignore
+	dir    string // dir is the directory of the .gitignore file, the rules are for the names relative to it
+	rules  []gitignoreRule
+}
+
+// gitignoreRule is a structure for one pattern of .gitignore file
+type gitignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool // negate is true for the patterns starting with !, which include again the files
+	dirOnly bool // dirOnly is true for the patterns ending with /
+}
+
+// loadGitignores function loads the .gitignore files in dir and in the directories above it, until the root of the git repository
+func loadGitignores(dir string) *gitignore {
+	var dirs []string
+	for current := filepath.Clean(dir); ; current = filepath.Dir(current) {
+		dirs = append(dirs, current)
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil || current == filepath.Dir(current) {
+			break
+		}
+	}
+	var ignore *gitignore
+	for i := len(dirs) - 1; i > 0; i-- { // the .gitignore file in dir is loaded when it is read
+		ignore = ignore.load(dirs[i])
+	}
+	return ignore
+}
+
+// load is a method for adding the rules of the .gitignore file in dir
+func (g *gitignore) load(dir string) *gitignore {
+	file, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return g
+	}
+	defer file.Close()
+
+	ignore := &gitignore{parent: g, dir: dir}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseGitignoreRule(scanner.Text()); ok == true {
+			ignore.rules = append(ignore.rules, rule)
+		}
+	}
+	return ignore
+}
+
+// match is a method for checking if the file with name is ignored.
+// Like in git, the last matching rule decides and the rules of .gitignore files in deeper directories are stronger.
+func (g *gitignore) match(name string, isDir bool) bool {
+	for ignore := g; ignore != nil; ignore = ignore.parent {
+		relative, err := filepath.Rel(ignore.dir, name)
+		if err != nil {
+			continue
+		}
+		relative = filepath.ToSlash(relative)
+		for i := len(ignore.rules) - 1; i >= 0; i-- {
+			rule := ignore.rules[i]
+			if (rule.dirOnly == false || isDir) && rule.pattern.MatchString(relative) {
+				return !rule.negate
+			}
+		}
+	}
+	return false
+}
+
+// parseGitignoreRule function parses line of .gitignore file, it returns false for empty lines and comments
+func parseGitignoreRule(line string) (gitignoreRule, bool) {
+	var rule gitignoreRule
+	line = strings.TrimRight(line, " \r")
+	if line == "" || line[0] == '#' {
+		return rule, false
+	}
+	if line[0] == '!' {
+		rule.negate, line = true, line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly, line = true, strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return rule, false
+	}
+
+	var expr strings.Builder
+	if strings.Contains(line, "/") { // patterns with / are relative to the directory of the .gitignore file
+		expr.WriteString("^")
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+	segments := strings.Split(line, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch {
+		case segment == "**" && last:
+			expr.WriteString(".*")
+		case segment == "**":
+			expr.WriteString("(?:.*/)?")
+		default:
+			expr.WriteString(globExpression(segment, "[^/]*", "[^/]"))
+			if last == false {
+				expr.WriteString("/")
+			}
+		}
+	}
+	expr.WriteString("$")
+
+	pattern, err := regexp.Compile(expr.String())
+	if err != nil {
+		return rule, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// walkInfo is a helper type implementing os.FileInfo with the information from os.DirEntry, used when the file cannot be read
+type walkInfo struct {
+	entry os.DirEntry
+}
+
+func (info walkInfo) Name() string       { return info.entry.Name() }
+func (info walkInfo) Size() int64        { return 0 }
+func (info walkInfo) Mode() os.FileMode  { return info.entry.Type() }
+func (info walkInfo) ModTime() time.Time { return time.Time{} }
+func (info walkInfo) IsDir() bool        { return info.entry.IsDir() }
+func (info walkInfo) Sys() interface{}   { return nil }
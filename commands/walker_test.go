@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// walkTestTree is a helper function for walking path with w and returning the visited relative names and the events of list and leave
+func walkTestTree(t *testing.T, w *walker, path string) ([]string, []string, error) {
+	var mutex sync.Mutex
+	var visited, events []string
+	command := &Find{}
+	command.InitStopSignalCatching()
+	w.command = command
+	if w.visit == nil {
+		w.visit = func(entry *walkEntry, err error) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				visited = append(visited, "error "+filepath.ToSlash(entry.relative))
+				return nil
+			}
+			visited = append(visited, filepath.ToSlash(entry.relative))
+			return nil
+		}
+	}
+	if w.sorted == true {
+		w.list = func(dir *walkEntry, entries []*walkEntry) error {
+			events = append(events, fmt.Sprintf("list %s %d", filepath.ToSlash(dir.relative), len(entries)))
+			return nil
+		}
+		w.leave = func(dir *walkEntry) error {
+			events = append(events, "leave "+filepath.ToSlash(dir.relative))
+			return nil
+		}
+	}
+	err := w.walk(path)
+	return visited, events, err
+}
+
+func TestWalker(t *testing.T) {
+	path, err := ioutil.TempDir("", "walker-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a": "", "b/c": "", "b/d/e": "", "f/": ""})
+	all := []string{"", "a", "b", "b/c", "b/d", "b/d/e", "f"}
+
+	visited, events, err := walkTestTree(t, &walker{sorted: true, workers: 2}, path)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if fmt.Sprint(visited) != fmt.Sprint(all) {
+		t.Errorf("Expected sorted visits %v, but got: %v", all, visited)
+	}
+	expected := []string{"list  3", "list b 2", "list b/d 1", "leave b/d", "leave b", "list f 0", "leave f", "leave "}
+	if fmt.Sprint(events) != fmt.Sprint(expected) {
+		t.Errorf("Expected events %q, but got: %q", expected, events)
+	}
+
+	visited, _, err = walkTestTree(t, &walker{}, path)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	sort.Strings(visited)
+	if fmt.Sprint(visited) != fmt.Sprint(all) {
+		t.Errorf("Expected concurrent visits %v, but got: %v", all, visited)
+	}
+
+	visited, _, _ = walkTestTree(t, &walker{sorted: true, maxDepth: 1}, path)
+	if expected := []string{"", "a", "b", "f"}; fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("Expected visits with maximum depth %v, but got: %v", expected, visited)
+	}
+
+	visited = nil
+	w := &walker{sorted: true}
+	w.visit = func(entry *walkEntry, err error) error {
+		visited = append(visited, filepath.ToSlash(entry.relative))
+		if entry.relative == "b" {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	walkTestTree(t, w, path)
+	if expected := []string{"", "a", "b", "f"}; fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("Expected visits without skipped directory %v, but got: %v", expected, visited)
+	}
+
+	w = &walker{sorted: true}
+	w.visit = func(entry *walkEntry, err error) error {
+		if entry.relative == "b" {
+			return ErrInvalidOption
+		}
+		return nil
+	}
+	if _, _, err := walkTestTree(t, w, path); err != ErrInvalidOption {
+		t.Errorf("Expected error %v from visit, but got: %v", ErrInvalidOption, err)
+	}
+
+	if _, _, err := walkTestTree(t, &walker{sorted: true}, filepath.Join(path, "missing")); err != nil {
+		t.Errorf("Expected the error to be given to visit, but got: %v", err)
+	}
+}
+
+func TestWalkerLinks(t *testing.T) {
+	path, err := ioutil.TempDir("", "walker-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"dir/a": "", "other/b": ""})
+	for link, target := range map[string]string{"dir/loop": "..", "dir/other": "../other", "dir/broken": "missing"} {
+		if err := os.Symlink(target, filepath.Join(path, link)); err != nil {
+			t.Fatalf("Fatal error - cannot make symbolic link! - %v", err)
+		}
+	}
+
+	visited, _, _ := walkTestTree(t, &walker{sorted: true}, filepath.Join(path, "dir"))
+	if expected := []string{"", "a", "broken", "loop", "other"}; fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("Expected visits without following links %v, but got: %v", expected, visited)
+	}
+	visited, _, _ = walkTestTree(t, &walker{sorted: true, followLinks: true}, filepath.Join(path, "dir"))
+	// loop points to the parent of the start, so the loop is found in it
+	expected := []string{"", "a", "broken", "loop", "error loop/dir", "loop/other", "loop/other/b", "other", "other/b"}
+	if fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("Expected visits with following links %v, but got: %v", expected, visited)
+	}
+
+	var loopErr error
+	w := &walker{sorted: true, followLinks: true}
+	w.visit = func(entry *walkEntry, err error) error {
+		if err != nil {
+			loopErr = err
+		}
+		return nil
+	}
+	walkTestTree(t, w, filepath.Join(path, "dir"))
+	if !errors.Is(loopErr, ErrWalkLoop) {
+		t.Errorf("Expected error %v, but got: %v", ErrWalkLoop, loopErr)
+	}
+}
+
+func TestWalkerGitignore(t *testing.T) {
+	path, err := ioutil.TempDir("", "walker-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{
+		".git/HEAD": "", ".gitignore": "*.log\n/build/\n!keep.log\n# comment\n", "a.log": "", "keep.log": "", "build/out": "",
+		"src/build/x": "", "src/.gitignore": "x\n**/deep/*.tmp\n", "src/y": "", "src/a/deep/z.tmp": "",
+	})
+
+	visited, _, _ := walkTestTree(t, &walker{sorted: true, gitignore: true}, path)
+	expected := []string{"", ".gitignore", "keep.log", "src", "src/.gitignore", "src/a", "src/a/deep", "src/build", "src/y"}
+	if fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("Expected visits %v, but got: %v", expected, visited)
+	}
+	// the rules of the .gitignore files above the start are used too
+	visited, _, _ = walkTestTree(t, &walker{sorted: true, gitignore: true}, filepath.Join(path, "src"))
+	if expected := []string{"", ".gitignore", "a", "a/deep", "build", "y"}; fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.Errorf("Expected visits %v, but got: %v", expected, visited)
+	}
+}
+
+func TestParseGitignoreRule(t *testing.T) {
+	var tests = []struct {
+		line    string
+		name    string
+		isDir   bool
+		ignored bool
+	}{
+		{"*.o", "a.o", false, true},
+		{"*.o", "dir/a.o", false, true},
+		{"/*.o", "dir/a.o", false, false},
+		{"dir/", "dir", false, false},
+		{"dir/", "sub/dir", true, true},
+		{"doc/*.txt", "doc/a.txt", false, true},
+		{"doc/*.txt", "doc/sub/a.txt", false, false},
+		{"**/logs", "a/b/logs", true, true},
+		{"a/**/b", "a/x/y/b", false, true},
+		{"a/**/b", "a/b", false, true},
+		{"a/**", "a/x/y", false, true},
+		{`\#file`, "#file", false, true},
+		{"!*.o", "a.o", false, false},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parseGitignoreRule(%s) on %s", test.line, test.name), func(t *testing.T) {
+			rule, ok := parseGitignoreRule(test.line)
+			if ok == false {
+				t.Fatalf("Expected rule, but got none")
+			}
+			ignore := &gitignore{dir: "/repo", rules: []gitignoreRule{rule}}
+			if ignored := ignore.match("/repo/"+test.name, test.isDir); ignored != test.ignored {
+				t.Errorf("Expected ignored %v, but got: %v", test.ignored, ignored)
+			}
+		})
+	}
+	for _, line := range []string{"", "# comment", "   "} {
+		if _, ok := parseGitignoreRule(line); ok == true {
+			t.Errorf("Expected no rule for %q", line)
+		}
+	}
+}
+
+func TestWalkerStop(t *testing.T) {
+	path, err := ioutil.TempDir("", "walker-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"a/b": "", "c/d": ""})
+
+	for _, sorted := range []bool{true, false} {
+		command := &Find{}
+		command.InitStopSignalCatching()
+		visits := 0
+		w := walker{command: command, sorted: sorted, workers: 1}
+		w.visit = func(entry *walkEntry, err error) error {
+			if visits++; visits == 2 {
+				command.SendStopSignal()
+			}
+			return nil
+		}
+		if err := w.walk(path); err != ErrStoppedExec {
+			t.Errorf("Expected error %v, but got: %v", ErrStoppedExec, err)
+		}
+		if visits != 2 {
+			t.Errorf("Expected the walk to stop after 2 visits, but got %d", visits)
+		}
+	}
+}
+
+// benchmarkTree is the tree with 10 directories with 10 directories with 50 files, made once for the benchmarks
+var benchmarkTree struct {
+	once sync.Once
+	path string
+}
+
+func TestWalkerBoundedGoroutines(t *testing.T) {
+	path, err := ioutil.TempDir("", "walker-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	files := make(map[string]string)
+	for i := 0; i < 200; i++ { // wide tree, where every directory has other directory in it
+		files[fmt.Sprintf("dir%03d/sub/file", i)] = ""
+	}
+	makeTestTree(t, path, files)
+
+	for _, sorted := range []bool{true, false} {
+		before := runtime.NumGoroutine()
+		var mutex sync.Mutex
+		maximum, count := 0, 0
+		w := &walker{sorted: sorted, workers: 2}
+		w.visit = func(entry *walkEntry, err error) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if n := runtime.NumGoroutine(); n > maximum {
+				maximum = n
+			}
+			count++
+			return nil
+		}
+		if _, _, err := walkTestTree(t, w, path); err != nil {
+			t.Errorf("Expected no error, but got: %v", err)
+		}
+		if count != 1+3*200 {
+			t.Errorf("Expected %d visits with sorted %v, but got: %d", 1+3*200, sorted, count)
+		}
+		if maximum > before+2 {
+			t.Errorf("Expected at most %d goroutines with sorted %v, but got: %d", before+2, sorted, maximum)
+		}
+	}
+}
+
+func makeBenchmarkTree(b *testing.B) string {
+	benchmarkTree.once.Do(func() {
+		path, err := ioutil.TempDir("", "walker-benchmark")
+		if err != nil {
+			b.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			for j := 0; j < 10; j++ {
+				dir := filepath.Join(path, fmt.Sprintf("dir%d", i), fmt.Sprintf("sub%d", j))
+				if err := os.MkdirAll(dir, 0777); err != nil {
+					b.Fatalf("Fatal error - cannot make directory! - %v", err)
+				}
+				for k := 0; k < 50; k++ {
+					if err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d", k)), nil, 0666); err != nil {
+						b.Fatalf("Fatal error - cannot make file! - %v", err)
+					}
+				}
+			}
+		}
+		benchmarkTree.path = path
+	})
+	if benchmarkTree.path == "" {
+		b.Skip("the benchmark tree cannot be made")
+	}
+	return benchmarkTree.path
+}
+
+func BenchmarkFilepathWalk(b *testing.B) {
+	path := makeBenchmarkTree(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		files := 0
+		filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+			files++
+			return nil
+		})
+	}
+}
+
+func benchmarkWalker(b *testing.B, sorted bool) {
+	path := makeBenchmarkTree(b)
+	command := &Find{}
+	command.InitStopSignalCatching()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var files int64
+		var mutex sync.Mutex
+		w := walker{command: command, sorted: sorted}
+		w.visit = func(entry *walkEntry, err error) error {
+			mutex.Lock()
+			files++
+			mutex.Unlock()
+			return nil
+		}
+		w.walk(path)
+	}
+}
+
+func BenchmarkWalkerSorted(b *testing.B) {
+	benchmarkWalker(b, true)
+}
+
+func BenchmarkWalkerConcurrent(b *testing.B) {
+	benchmarkWalker(b, false)
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if benchmarkTree.path != "" {
+		os.RemoveAll(benchmarkTree.path)
+	}
+	if catBenchmarkFile.name != "" {
+		os.Remove(catBenchmarkFile.name)
+	}
+	os.Exit(code)
+}
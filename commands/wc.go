@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Wc is a structure for wc command, implementing ExecuteCommand interface
+type Wc struct {
+	path          string
+	stopExecution chan struct{}
+}
+
+// wcCounts stores the counts of wc command for one file
+type wcCounts struct {
+	lines int64
+	words int64
+	chars int64
+	bytes int64
+}
+
+// GetName is a getter for command name
+func (w *Wc) GetName() string {
+	return "wc"
+}
+
+// GetPath is a getter for path
+func (w *Wc) GetPath() string {
+	return w.path
+}
+
+// Clone is a method for cloning wc command
+func (w *Wc) Clone() ExecuteCommand {
+	clone := *w
+	return &clone
+}
+
+// InitStopSignalCatching is a method for initializing stopExecution channel
+func (w *Wc) InitStopSignalCatching() {
+	w.stopExecution = make(chan struct{}, 1)
+}
+
+// SendStopSignal is a method for registering stop signal of the execution of the command
+// It writes to stopExecution channel
+func (w *Wc) SendStopSignal() {
+	w.stopExecution <- struct{}{}
+}
+
+// IsStopSignalReceived is a method for checking if stop signal was sent
+// It checks if there is a signal in stopExecution channel
+func (w *Wc) IsStopSignalReceived() bool {
+	select {
+	case <-w.stopExecution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Execute is go implementation of wc command
+//
+// For every file in the arguments, the number of lines, words and bytes are written, followed by the name of the file.
+// The option -l is for the lines, -w for the words, -m for the characters in UTF-8 and -c for the bytes, the counts are always in this order.
+// Without arguments the input is counted and - is also for the input. When there are more files, the last line has the totals.
+//
+// Like in wc, the width of the counts depends on the sizes of the files, so that the columns are aligned.
+func (w *Wc) Execute(cp CommandProperties) error {
+	w.path = cp.Path
+	inputFile, outputFile := cp.InputFile, cp.OutputFile
+
+	options, arguments, err := parseOptions(cp, "lwmc", []string{"lines", "words", "chars", "bytes"})
+	if err != nil {
+		return err
+	}
+	has := func(short string, long string) bool {
+		return options.has(short) || options.has(long)
+	}
+	show := [4]bool{has("l", "lines"), has("w", "words"), has("m", "chars"), has("c", "bytes")}
+	if show == [4]bool{} {
+		show = [4]bool{true, true, false, true}
+	}
+	names := arguments
+	if len(arguments) == 0 {
+		arguments = []string{"-"}
+	}
+
+	var errs []error // in slice errs we collect all the errors
+	files := make([]*os.File, len(arguments))
+	for ind, argument := range arguments {
+		if files[ind], err = openInputFile(w.path, argument, inputFile); err != nil {
+			errs = append(errs, err)
+		} else if files[ind] != inputFile {
+			defer files[ind].Close()
+		}
+	}
+	width := wcWidth(files, show)
+
+	out := newStreamWriter(w, outputFile)
+	var total wcCounts
+	for ind, file := range files {
+		if file == nil {
+			continue
+		}
+		counts, err := w.count(file)
+		if err == ErrStoppedExec {
+			return joinErrors(errs)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s - %w", arguments[ind], err))
+		}
+		total.lines, total.words, total.chars, total.bytes = total.lines+counts.lines, total.words+counts.words,
+			total.chars+counts.chars, total.bytes+counts.bytes
+		name := ""
+		if len(names) > 0 {
+			name = names[ind]
+		}
+		if err := out.WriteString(counts.format(show, width, name)); err != nil {
+			return out.finish(err)
+		}
+	}
+	if len(arguments) > 1 {
+		err = out.WriteString(total.format(show, width, "total"))
+	}
+	if err != nil {
+		return out.finish(err)
+	}
+	return out.finish(joinErrors(errs))
+}
+
+// wcWidth function returns the width of the counts of wc command, it is the number of digits in the total size of the files.
+// It is at least 7 when some of the files is not regular file and its size is not known, and it is 1 when only one count is written.
+func wcWidth(files []*os.File, show [4]bool) int {
+	shown := 0
+	for _, s := range show {
+		if s == true {
+			shown++
+		}
+	}
+	if len(files) == 1 && shown == 1 {
+		return 1
+	}
+	var size int64
+	minimum := 1
+	for _, file := range files {
+		if file == nil {
+			continue
+		}
+		if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		} else {
+			minimum = 7
+		}
+	}
+	if width := len(strconv.FormatInt(size, 10)); width > minimum {
+		return width
+	}
+	return minimum
+}
+
+// count is a method for counting the lines, words, characters and bytes of file
+func (w *Wc) count(file *os.File) (wcCounts, error) {
+	in := newStreamReader(w, file)
+	var counts wcCounts
+	inWord := false
+	var pending []byte // pending are the bytes at the end of the last read, which are not full character
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := in.Read(buf)
+		counts.bytes += int64(n)
+		counts.lines += int64(bytes.Count(buf[:n], []byte{'\n'}))
+		data := append(pending, buf[:n]...)
+		for len(data) > 0 {
+			if utf8.FullRune(data) == false && err == nil {
+				break
+			}
+			r, size := utf8.DecodeRune(data)
+			data = data[size:]
+			counts.chars++
+			if unicode.IsSpace(r) {
+				inWord = false
+			} else if inWord == false {
+				inWord = true
+				counts.words++
+			}
+		}
+		pending = append(pending[:0], data...)
+		if err == io.EOF {
+			return counts, nil
+		}
+		if err != nil {
+			return counts, err
+		}
+	}
+}
+
+// format is a method returning the line of wc command with the shown counts and the name of the file
+func (c wcCounts) format(show [4]bool, width int, name string) string {
+	var fields []string
+	for ind, count := range []int64{c.lines, c.words, c.chars, c.bytes} {
+		if show[ind] == true {
+			fields = append(fields, fmt.Sprintf("%*d", width, count))
+		}
+	}
+	if name != "" {
+		fields = append(fields, name)
+	}
+	return strings.Join(fields, " ") + "\n"
+}
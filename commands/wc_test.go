@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestWc(t *testing.T) {
+	path, err := ioutil.TempDir("", "wc-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	makeTestTree(t, path, map[string]string{"words": "a b\nc\n", "utf8": "ъгъл\n", "no-new-line": "x  y", "empty": ""})
+
+	var tests = []struct {
+		input  string
+		words  []string
+		output string
+		err    error
+	}{
+		{"", []string{"words"}, "2 3 6 words\n", nil},
+		{"", []string{"-l", "words"}, "2 words\n", nil},
+		{"", []string{"-w", "--lines", "words"}, "2 3 words\n", nil},
+		{"", []string{"-m", "utf8"}, "5 utf8\n", nil},
+		{"", []string{"-c", "utf8"}, "9 utf8\n", nil},
+		{"", []string{"-lwmc", "words", "utf8"}, " 2  3  6  6 words\n 1  1  5  9 utf8\n 3  4 11 15 total\n", nil},
+		{"", []string{"-l", "words", "utf8"}, " 2 words\n 1 utf8\n 3 total\n", nil},
+		{"", []string{"no-new-line"}, "0 2 4 no-new-line\n", nil},
+		{"", []string{"empty"}, "0 0 0 empty\n", nil},
+		{"a b\nв г\n", []string{}, "      2       4      10\n", nil},
+		{"a b\nв г\n", []string{"-m"}, "8\n", nil},
+		{"a b\n", []string{"-l", "-", "words"}, "      1 -\n      2 words\n      3 total\n", nil},
+		{"", []string{"-l", "missing", "words"}, "2 words\n2 total\n", os.ErrNotExist},
+		{"", []string{"-x", "words"}, "", ErrInvalidOption},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Wc test with words %v and input %q", test.words, test.input), func(t *testing.T) {
+			output, err := runTestCommandWithInput(t, &Wc{}, path, test.words, test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected error %v, but got: %v", test.err, err)
+			}
+			if output != test.output {
+				t.Errorf("Expected output %q, but got: %q", test.output, output)
+			}
+		})
+	}
+}
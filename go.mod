@@ -1,6 +1,6 @@
 module github.com/ilian98/go-terminal
 
-go 1.15
+go 1.16
 
 require (
 	github.com/yuin/goldmark v1.3.1 // indirect
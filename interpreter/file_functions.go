@@ -27,13 +27,19 @@ func (i *Interpreter) openOutputFile(fileName string) (*os.File, error) {
 	if fileName == "" { // empty file name means that output file should be os.Stdout
 		return os.Stdout, nil
 	}
-	file, err := os.OpenFile(commands.FullFileName(i.Path, fileName), os.O_CREATE|os.O_WRONLY, 0666)
+	file, err := i.openFile(fileName, os.O_CREATE|os.O_WRONLY)
 	if err != nil {
 		return nil, err
 	}
 	return file, nil
 }
 
+// openFile is a function that opens file with flag like os.OpenFile, the relative file names are in the path of the interpreter.
+// It is given to the commands, which open files, see commands.FileOpenerUser.
+func (i *Interpreter) openFile(fileName string, flag int) (*os.File, error) {
+	return os.OpenFile(commands.FullFileName(i.Path, fileName), flag, 0666)
+}
+
 // openInputOutpuFiles is a function that calls openInputFile and openOutputFile for opening files for input and output
 func (i *Interpreter) openInputOutputFiles(input string, output string) (*os.File, *os.File, error) {
 	inputFile, err := i.openInputFile(input)
@@ -15,6 +15,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"time"
 
 	"github.com/ilian98/go-terminal/commands"
 	"github.com/ilian98/go-terminal/parser"
@@ -26,8 +27,12 @@ type Interpreter struct {
 	exitCommands      []string
 	shellCommandsName []string
 	shellCommands     []commands.ExecuteCommand
+	dirStack          *commands.DirectoryStack // dirStack is the directory stack of pushd, popd and dirs commands
 }
 
+// stopWaitTime is the time for which a stopped command can finish its execution before its files are closed
+const stopWaitTime = 500 * time.Millisecond
+
 var (
 	// ErrCommandExists indicates that command is already registered and won't be added
 	ErrCommandExists = errors.New("command with that name already exists")
@@ -69,15 +74,24 @@ const (
 //
 // This method can run the command in background mode if in the parameters bgRun is true.
 // Also this method can catch os.Interrupt and alters its default behaviour.
-// After the catch, it sends signal to the command that is currently running by writing to its StopExecution channel and then exits the current go routine to call the defer calls closing the opened files!
+// After the catch, it sends signal to the command that is currently running by writing to its StopExecution channel.
+// Then it waits at most stopWaitTime for the command to finish and exits the current go routine to call the defer calls closing the opened files!
+//
+// The options are given to the command before the arguments, use ExecuteCommandWords for keeping the order they were written.
 func (i *Interpreter) ExecuteCommand(name string, arguments []string, options []string, inputFile *os.File, outputFile *os.File, bgRun bool) int {
+	return i.ExecuteCommandWords(name, arguments, options, nil, inputFile, outputFile, bgRun)
+}
+
+// ExecuteCommandWords is a method of Interpreter that executes one command like ExecuteCommand.
+// The parameter words stores the options and the arguments in the order they were written, see parser.Command.
+func (i *Interpreter) ExecuteCommandWords(name string, arguments []string, options []string, words []string, inputFile *os.File, outputFile *os.File, bgRun bool) int {
 	// check if command is for exiting the terminal
 	if result, _ := i.checkForCommand(i.exitCommands, name); result == true {
 		closeInputOutputFiles(inputFile, outputFile)
 		return ExitCommand
 	}
 	// check if command with the parsed name exists
-	result, ind := i.checkForCommand(i.shellCommandsName, name)
+	result, _ := i.checkForCommand(i.shellCommandsName, name)
 	if result == false {
 		closeInputOutputFiles(inputFile, outputFile)
 		return InvalidCommandName
@@ -90,9 +104,10 @@ func (i *Interpreter) ExecuteCommand(name string, arguments []string, options []
 		Options:    options,
 		InputFile:  inputFile,
 		OutputFile: outputFile,
+		Words:      words,
 	}
 
-	command := i.shellCommands[ind].Clone() // we are cloning command so that it runs clean i.e. in initial state
+	command := i.lookupCommand(name) // we are cloning command so that it runs clean i.e. in initial state
 	runCommand := func(cp commands.CommandProperties, bgRun bool) {
 		defer closeInputOutputFiles(inputFile, outputFile) // when function ends, then the command stopped and we have to close the opened files
 
@@ -107,17 +122,19 @@ func (i *Interpreter) ExecuteCommand(name string, arguments []string, options []
 			select {
 			case <-signalInterrupt:
 				command.SendStopSignal() // we send stop signal to executing command
-				runtime.Goexit()         // we stop current goroutine
-			case err := <-result:
-				if err != nil {
-					fmt.Printf("%v\n", err)
+				select {                 // the command can finish its execution, for example to write its statistics
+				case err := <-result:
+					if !errors.Is(err, commands.ErrStoppedExec) {
+						printCommandError(err)
+					}
+				case <-time.After(stopWaitTime):
 				}
+				runtime.Goexit() // we stop current goroutine
+			case err := <-result:
+				printCommandError(err)
 			}
 		} else { // in background mode we don't catch Ctrl+C
-			err := command.Execute(cp)
-			if err != nil {
-				fmt.Printf("%v\n", err)
-			}
+			printCommandError(command.Execute(cp))
 		}
 	}
 
@@ -131,6 +148,13 @@ func (i *Interpreter) ExecuteCommand(name string, arguments []string, options []
 	return Ok
 }
 
+// printCommandError function writes the error returned by command, if it is not only exit status without message
+func printCommandError(err error) {
+	if err != nil && commands.IsSilentError(err) == false {
+		fmt.Printf("%v\n", err)
+	}
+}
+
 // Status is a struct used for storing code and command name after InterpretCommand
 type Status struct {
 	Code    int
@@ -166,6 +190,7 @@ func (i *Interpreter) InterpretCommand(parsedCommand []parser.Command) []Status
 	}
 
 	statuses := make(chan Status, len(parsedCommand)) // channel for collecting the statuses of run commands
+	i.directoryStack()                                // the directory stack is made before copying, so that the copies can share it
 	copyInterpreter := *i                             // we copy the interpreter to not let path change in potential pipe
 	isPipe := false
 	if len(parsedCommand) > 1 {
@@ -199,6 +224,10 @@ func (i *Interpreter) InterpretCommand(parsedCommand []parser.Command) []Status
 			w.Close()
 		}
 
+		currInterpreter := copyInterpreter
+		if isPipe || bgRun { // like in subshell, the commands, which cannot change the path, change only copy of the directory stack
+			currInterpreter.dirStack = i.dirStack.Clone()
+		}
 		go func(currInterpreter Interpreter, c parser.Command, inputFile *os.File, outputFile *os.File) {
 			s := Status{CmdInterrupted, c.Name}
 			defer func(s *Status) { // we run this function in defer to write code for command if go routine was exited
@@ -208,14 +237,17 @@ func (i *Interpreter) InterpretCommand(parsedCommand []parser.Command) []Status
 				}
 			}(&s)
 
-			s = Status{currInterpreter.ExecuteCommand(
-				c.Name, c.Arguments, c.Options, inputFile, outputFile, c.BgRun,
+			s = Status{currInterpreter.ExecuteCommandWords(
+				c.Name, c.Arguments, c.Options, c.Words, inputFile, outputFile, c.BgRun,
 			), c.Name}
 			if !isPipe && !c.BgRun { // path can be changed only for one command not in pipe and bg run
+				if currInterpreter.Path != i.Path { // like in shells, the previous path is stored in OLDPWD for cd -
+					os.Setenv("OLDPWD", i.Path)
+				}
 				i.Path = currInterpreter.Path // we don't have concurrent access to i.Path because it isn't pipe
 			}
 			statuses <- s
-		}(copyInterpreter, c, inputFile, outputFile)
+		}(currInterpreter, c, inputFile, outputFile)
 	}
 
 	var result []Status
@@ -226,6 +258,34 @@ func (i *Interpreter) InterpretCommand(parsedCommand []parser.Command) []Status
 	return result
 }
 
+// lookupCommand is a method returning clone of the registered command with name or nil if there is no such command.
+// Commands, which run other commands, get this method for finding them and commands, which open files, get openFile.
+func (i *Interpreter) lookupCommand(name string) commands.ExecuteCommand {
+	result, ind := i.checkForCommand(i.shellCommandsName, name)
+	if result == false {
+		return nil
+	}
+	command := i.shellCommands[ind].Clone()
+	if runner, ok := command.(commands.CommandRunner); ok == true {
+		runner.SetCommandLookup(i.lookupCommand)
+	}
+	if user, ok := command.(commands.DirectoryStackUser); ok == true {
+		user.SetDirectoryStack(i.directoryStack())
+	}
+	if user, ok := command.(commands.FileOpenerUser); ok == true {
+		user.SetFileOpener(i.openFile)
+	}
+	return command
+}
+
+// directoryStack is a method returning the directory stack of the interpreter, which is made on the first call
+func (i *Interpreter) directoryStack() *commands.DirectoryStack {
+	if i.dirStack == nil {
+		i.dirStack = &commands.DirectoryStack{}
+	}
+	return i.dirStack
+}
+
 // checkForCommand is function for checking if a command name target is present in slice parameter names
 func (i *Interpreter) checkForCommand(names []string, target string) (bool, int) {
 	for i, name := range names {
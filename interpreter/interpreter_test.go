@@ -2,6 +2,9 @@ package interpreter
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -51,6 +54,141 @@ func TestRegisterCommand(t *testing.T) {
 	}
 }
 
+func TestLookupCommand(t *testing.T) {
+	var i Interpreter
+	find := &commands.Find{}
+	i.RegisterCommand(find)
+	command := i.lookupCommand("find")
+	if _, ok := command.(*commands.Find); ok == false || command == commands.ExecuteCommand(find) {
+		t.Errorf("Expecting clone of find command, but got: %v\n", command)
+	}
+	if command := i.lookupCommand("ls"); command != nil {
+		t.Errorf("Expecting no command, but got: %v\n", command)
+	}
+}
+
+func TestExecuteCommand(t *testing.T) {
+	path, err := ioutil.TempDir("", "interpreter-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	var i Interpreter
+	i.RegisterCommand(&commands.Mkdir{})
+	i.Path = path
+	if code := i.ExecuteCommand("mkdir", []string{"a/b"}, []string{"p"}, os.Stdin, os.Stdout, false); code != Ok {
+		t.Errorf("Expecting code %d, but got: %d\n", Ok, code)
+	}
+	if code := i.ExecuteCommandWords("mkdir", nil, nil, []string{"c/d", "-p"}, os.Stdin, os.Stdout, false); code != Ok {
+		t.Errorf("Expecting code %d, but got: %d\n", Ok, code)
+	}
+	for _, name := range []string{"a/b", "c/d"} {
+		if stat, err := os.Stat(filepath.Join(path, name)); err != nil || stat.IsDir() == false {
+			t.Errorf("Expecting directory %s, but got: %v\n", name, err)
+		}
+	}
+}
+
+func TestDirectoryStackAndOldPwd(t *testing.T) {
+	path, err := ioutil.TempDir("", "interpreter-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		t.Fatalf("Fatal error - cannot evaluate temporary directory! - %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(path, "dir"), 0755); err != nil {
+		t.Fatalf("Fatal error - cannot make directory! - %v", err)
+	}
+	defer os.Setenv("OLDPWD", os.Getenv("OLDPWD"))
+	os.Setenv("OLDPWD", "")
+
+	var i Interpreter
+	i.RegisterCommand(&commands.Pushd{})
+	i.RegisterCommand(&commands.Popd{})
+	i.Path = path
+	var tests = []struct {
+		command parser.Command
+		path    string
+		oldPwd  string
+	}{
+		{parser.Command{Name: "pushd", Arguments: []string{"dir"}, Options: []string{"q"}}, filepath.Join(path, "dir"), path},
+		{parser.Command{Name: "popd", Arguments: []string{}, Options: []string{"q"}}, path, filepath.Join(path, "dir")},
+	}
+	for _, test := range tests {
+		i.InterpretCommand([]parser.Command{test.command})
+		if i.Path != test.path {
+			t.Errorf("Expecting path %s after %s, but got: %s\n", test.path, test.command.Name, i.Path)
+		}
+		if oldPwd := os.Getenv("OLDPWD"); oldPwd != test.oldPwd {
+			t.Errorf("Expecting OLDPWD %s after %s, but got: %s\n", test.oldPwd, test.command.Name, oldPwd)
+		}
+	}
+}
+
+func TestFileOpener(t *testing.T) {
+	path, err := ioutil.TempDir("", "interpreter-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if err := ioutil.WriteFile(filepath.Join(path, "input"), []byte("text\n"), 0644); err != nil {
+		t.Fatalf("Fatal error - cannot make file! - %v", err)
+	}
+
+	var i Interpreter
+	i.RegisterCommand(&commands.Tee{})
+	i.Path = path
+	i.InterpretCommand([]parser.Command{{
+		Name:      "tee",
+		Arguments: []string{"copy"},
+		Options:   []string{},
+		Input:     "input",
+		Output:    "output",
+		Words:     []string{"copy"},
+	}})
+	for _, name := range []string{"copy", "output"} {
+		if data, err := ioutil.ReadFile(filepath.Join(path, name)); err != nil || string(data) != "text\n" {
+			t.Errorf("Expecting file %s with content %q, but got: %q and error %v\n", name, "text\n", data, err)
+		}
+	}
+}
+
+func TestDirectoryStackInPipe(t *testing.T) {
+	path, err := ioutil.TempDir("", "interpreter-test")
+	if err != nil {
+		t.Fatalf("Fatal error - cannot make temporary directory! - %v", err)
+	}
+	defer os.RemoveAll(path)
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		t.Fatalf("Fatal error - cannot evaluate temporary directory! - %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(path, "dir"), 0755); err != nil {
+		t.Fatalf("Fatal error - cannot make directory! - %v", err)
+	}
+	defer os.Setenv("OLDPWD", os.Getenv("OLDPWD"))
+
+	var i Interpreter
+	i.RegisterCommand(&commands.Pushd{})
+	i.RegisterCommand(&commands.Dirs{})
+	i.RegisterCommand(&commands.Cat{})
+	i.Path = path
+	// like in subshell, pushd in pipe does not change the path and the directory stack
+	i.InterpretCommand([]parser.Command{
+		{Name: "pushd", Arguments: []string{"dir"}, Options: []string{"q"}},
+		{Name: "cat", Arguments: []string{}, Options: []string{}},
+	})
+	if i.Path != path {
+		t.Errorf("Expecting path %s after pushd in pipe, but got: %s\n", path, i.Path)
+	}
+	i.InterpretCommand([]parser.Command{{Name: "dirs", Arguments: []string{}, Options: []string{"l", "p"}, Output: "stack"}})
+	if data, err := ioutil.ReadFile(filepath.Join(path, "stack")); err != nil || string(data) != path+"\n" {
+		t.Errorf("Expecting directory stack %q after pushd in pipe, but got: %q and error %v\n", path+"\n", data, err)
+	}
+}
+
 func ExampleInterpreter() {
 	var i Interpreter
 	i.RegisterCommand(&commands.Pwd{})
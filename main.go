@@ -23,7 +23,12 @@ func init() { // registering commands in interpreter
 	commands := [...]commands.ExecuteCommand{
 		&commands.Pwd{}, &commands.Cd{}, &commands.Ls{}, &commands.Cat{},
 		&commands.Cp{}, &commands.Mv{}, &commands.Mkdir{}, &commands.Rm{},
-		&commands.Find{}, &commands.Ping{},
+		&commands.Find{}, &commands.Ping{}, &commands.Trash{}, &commands.Du{},
+		&commands.Pushd{}, &commands.Popd{}, &commands.Dirs{}, &commands.Head{},
+		&commands.Tail{}, &commands.Grep{}, &commands.Wc{}, &commands.Sort{},
+		&commands.Uniq{}, &commands.Cut{}, &commands.Tr{}, &commands.Paste{},
+		&commands.Column{}, &commands.Sed{}, &commands.Echo{}, &commands.Printf{},
+		&commands.Tee{},
 	}
 	for _, command := range commands {
 		if err := I.RegisterCommand(command); err != nil {
@@ -43,6 +48,9 @@ func main() {
 	reader := bufio.NewReader(os.Stdin)
 	for {
 		fmt.Println("")
+		if err := commands.CheckPath(I.Path); err != nil { // the current directory can be deleted by other program
+			fmt.Printf("%v\n", err)
+		}
 		fmt.Println(I.Path)
 		fmt.Print("$ ")
 		text, err := reader.ReadString('\n')
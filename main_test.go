@@ -113,8 +113,15 @@ func TestPipe(t *testing.T) {
 	}{
 		{"cat TestPipe\n", "test1", "test1"},
 		{"pwd | cat > TestPipe | cat\n", "", ""},
-		{"pwd | cat\n", "", testPath},
+		{"pwd | cat\n", "", testPath + "\n"},
 		{"cat TestPipe TestPipe | cat | cat | cat | cat\n", "test4", "test4test4"},
+		{"cat TestPipe | grep -n two\n", "one\ntwo\n", "2:two\n"},
+		{"cat TestPipe | grep three\n", "one\ntwo\n", ""},
+		{"cat TestPipe | cut -d, -f2 | tr a-z A-Z\n", "a,b\nc,d\n", "B\nD\n"},
+		{"cat TestPipe | paste -s -d, | column -t -s,\n", "ab\nc\n", "ab  c\n"},
+		{"cat TestPipe | sed -n \"1d;s/o/0/gp\"\n", "one\ntwo\nfoo\n", "tw0\nf00\n"},
+		{"echo -n one two | tr a-z A-Z\n", "", "ONE TWO"},
+		{"printf \"%s,%s\\n\" a b c | tee TestPipe | cut -d, -f1\n", "", "a\nc\n"},
 		{"ping noibg.com | cd\n", "", "write |1: The pipe is being closed.\n"},
 		{"cmd1 | cd\n", "", "No command with name: cmd1\n"},
 	}
@@ -18,6 +18,7 @@ type Command struct {
 	Input     string // Empty Input would mean that we will use stdin for the command, otherwise it would be the name of the input file
 	Output    string // Analogous to Input
 	BgRun     bool
+	Words     []string // Words stores the options (with their leading '-') and the arguments in the order they are written
 }
 
 var (
@@ -81,6 +82,7 @@ func parseCommandText(commandText string) (Command, error) {
 
 		if len(word) > 1 && word[0] == '-' {
 			c.Options = append(c.Options, word[1:])
+			c.Words = append(c.Words, word)
 		} else if c.Input == "" && word[0] == '<' {
 			// First argument with '<' will be considered for input, others will be counted as arguments
 			if len(word) == 1 && (1+ind)+1 < len(words) { // (1+ind) because we start range from 1
@@ -101,6 +103,7 @@ func parseCommandText(commandText string) (Command, error) {
 			}
 		} else {
 			c.Arguments = append(c.Arguments, removeQuotes(word))
+			c.Words = append(c.Words, removeQuotes(word))
 		}
 	}
 	return c, nil
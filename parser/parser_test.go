@@ -98,7 +98,7 @@ func commandToString(c Command) string {
 	return output
 }
 func newCommand(Name string, Arguments []string, Options []string) Command {
-	return Command{Name, Arguments, Options, "", "", false}
+	return Command{Name, Arguments, Options, "", "", false, nil}
 }
 
 func testingParseCommandText(t *testing.T, commandText string, expectedResult Command) {
@@ -130,19 +130,19 @@ func TestParseCommandText(t *testing.T) {
 		{`ls -l arg1 "arg2`, newCommand("ls", []string{"arg1", `"arg2`}, []string{"l"})},
 		{`ls -l ""`, newCommand("ls", []string{`""`}, []string{"l"})},
 
-		{"cat <file.txt", Command{"cat", []string{}, []string{}, "file.txt", "", false}},
-		{`cat <"file 1.txt"`, Command{"cat", []string{}, []string{}, "file 1.txt", "", false}},
-		{`cat >"file 2.txt"`, Command{"cat", []string{}, []string{}, "", "file 2.txt", false}},
-		{"cat <file1.txt <file2.txt >file3.txt >file4.txt", Command{"cat", []string{"<file2.txt", ">file4.txt"}, []string{}, "file1.txt", "file3.txt", false}},
+		{"cat <file.txt", Command{"cat", []string{}, []string{}, "file.txt", "", false, nil}},
+		{`cat <"file 1.txt"`, Command{"cat", []string{}, []string{}, "file 1.txt", "", false, nil}},
+		{`cat >"file 2.txt"`, Command{"cat", []string{}, []string{}, "", "file 2.txt", false, nil}},
+		{"cat <file1.txt <file2.txt >file3.txt >file4.txt", Command{"cat", []string{"<file2.txt", ">file4.txt"}, []string{}, "file1.txt", "file3.txt", false, nil}},
 
-		{"ls -l >output.txt &", Command{"ls", []string{}, []string{"l"}, "", "output.txt", true}},
-		{"ls -l & >output.txt", Command{"ls", []string{}, []string{"l"}, "", "output.txt", true}},
+		{"ls -l >output.txt &", Command{"ls", []string{}, []string{"l"}, "", "output.txt", true, nil}},
+		{"ls -l & >output.txt", Command{"ls", []string{}, []string{"l"}, "", "output.txt", true, nil}},
 
-		{"pwd - < >", Command{"pwd", []string{"-"}, []string{}, ">", "", false}},
-		{`pwd - "<" ">"`, Command{"pwd", []string{"-", "<", ">"}, []string{}, "", "", false}},
+		{"pwd - < >", Command{"pwd", []string{"-"}, []string{}, ">", "", false, nil}},
+		{`pwd - "<" ">"`, Command{"pwd", []string{"-", "<", ">"}, []string{}, "", "", false, nil}},
 
-		{"ls < in.txt > out.txt < in2.txt > out2.txt", Command{"ls", []string{"<", "in2.txt", ">", "out2.txt"}, []string{}, "in.txt", "out.txt", false}},
-		{"ls < in.txt >", Command{"ls", []string{}, []string{}, "in.txt", "", false}},
+		{"ls < in.txt > out.txt < in2.txt > out2.txt", Command{"ls", []string{"<", "in2.txt", ">", "out2.txt"}, []string{}, "in.txt", "out.txt", false, nil}},
+		{"ls < in.txt >", Command{"ls", []string{}, []string{}, "in.txt", "", false, nil}},
 	}
 
 	for _, test := range tests {
@@ -152,6 +152,30 @@ func TestParseCommandText(t *testing.T) {
 	}
 }
 
+func TestParseCommandTextWords(t *testing.T) {
+	var tests = []struct {
+		commandText string
+		words       []string
+	}{
+		{"ls", nil},
+		{"ls -l arg1 -a", []string{"-l", "arg1", "-a"}},
+		{`mkdir -m 755 "dir 1" <input >output --verbose`, []string{"-m", "755", "dir 1", "--verbose"}},
+		{"find . ! -name a &", []string{".", "!", "-name", "a"}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parseCommandText(%s)", test.commandText), func(t *testing.T) {
+			result, err := parseCommandText(test.commandText)
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v\n", err)
+				return
+			}
+			if fmt.Sprintf("%q", result.Words) != fmt.Sprintf("%q", test.words) {
+				t.Errorf("Expected words %q, but got: %q", test.words, result.Words)
+			}
+		})
+	}
+}
+
 func commandsToString(commands []Command) string {
 	output := commandToString(commands[0])
 	for _, command := range commands[1:] {
@@ -198,7 +222,7 @@ func TestParse(t *testing.T) {
 		{`ls -l | cat file1.txt "file 2.txt" >"file 3.txt"`,
 			[]Command{
 				newCommand("ls", []string{}, []string{"l"}),
-				{"cat", []string{"file1.txt", "file 2.txt"}, []string{}, "", "file 3.txt", false},
+				{"cat", []string{"file1.txt", "file 2.txt"}, []string{}, "", "file 3.txt", false, nil},
 			},
 			nil},
 		{`c1 "|" |c2 | c3`,
@@ -211,7 +235,7 @@ func TestParse(t *testing.T) {
 		{`c1 "|" |c2 & | c3`,
 			[]Command{
 				newCommand("c1", []string{"|"}, []string{}),
-				{"c2", []string{}, []string{}, "", "", true},
+				{"c2", []string{}, []string{}, "", "", true, nil},
 				newCommand("c3", []string{}, []string{}),
 			},
 			nil},